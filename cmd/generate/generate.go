@@ -39,10 +39,16 @@ func main() {
 
 	// apply basic crud api on structs or table models which is specified by table name with function
 	// GenerateModel/GenerateModelAs. And generator will generate table models' code when calling Excute.
-	g.ApplyBasic(g.GenerateModel("attachment", gen.FieldType("type", "consts.AttachmentType")),
+	g.ApplyBasic(g.GenerateModelAs("activitypub_article", "ActivityPubArticle"),
+		g.GenerateModelAs("activitypub_delivery", "ActivityPubDelivery", gen.FieldType("status", "consts.ActivityPubDeliveryStatus")),
+		g.GenerateModelAs("activitypub_follower", "ActivityPubFollower"),
+		g.GenerateModel("attachment", gen.FieldType("type", "consts.AttachmentType")),
 		g.GenerateModel("category", gen.FieldType("type", "consts.CategoryType")),
-		g.GenerateModel("comment", gen.FieldType("type", "consts.CommentType"), gen.FieldType("status", "consts.CommentStatus")),
+		g.GenerateModel("comment", gen.FieldType("type", "consts.CommentType"), gen.FieldType("status", "consts.CommentStatus"), gen.FieldType("kind", "consts.CommentKind")),
 		g.GenerateModel("comment_black"),
+		g.GenerateModel("comment_spam_token"),
+		g.GenerateModel("comment_revision"),
+		g.GenerateModel("comment_subscription", gen.FieldType("type", "consts.CommentType"), gen.FieldType("status", "consts.CommentSubscriptionStatus")),
 		g.GenerateModel("flyway_schema_history"),
 		g.GenerateModel("journal", gen.FieldType("type", "consts.JournalType")),
 		g.GenerateModel("link"),
@@ -54,9 +60,12 @@ func main() {
 		g.GenerateModel("post", gen.FieldType("type", "consts.PostType"), gen.FieldType("status", "consts.PostStatus"), gen.FieldType("editor_type", "consts.EditorType")),
 		g.GenerateModel("post_category"),
 		g.GenerateModel("post_tag"),
+		g.GenerateModel("reaction", gen.FieldType("target_type", "consts.ReactionTarget")),
 		g.GenerateModel("tag"),
 		g.GenerateModel("theme_setting"),
 		g.GenerateModel("user", gen.FieldType("mfa_type", "consts.MFAType")),
+		g.GenerateModel("webhook"),
+		g.GenerateModel("webhook_delivery", gen.FieldType("status", "consts.WebhookDeliveryStatus")),
 	)
 
 	// apply diy interfaces on structs or table models
@@ -10,5 +10,7 @@ var (
 	BackupDir         = filepath.Join(TempDir, "sonic-backup") + string(os.PathSeparator)
 	BackupMarkdownDir = filepath.Join(TempDir, "sonic-backup-markdown") + string(os.PathSeparator)
 	DataExportDir     = filepath.Join(TempDir, "sonic-data-export") + string(os.PathSeparator)
+	BackupEpubDir     = filepath.Join(TempDir, "sonic-backup-epub") + string(os.PathSeparator)
+	BackupCommentDir  = filepath.Join(TempDir, "sonic-backup-comment") + string(os.PathSeparator)
 	ResourcesDir, _   = filepath.Abs("./resources")
 )
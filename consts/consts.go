@@ -16,12 +16,42 @@ const (
 	OneTimeTokenQueryName     = "ott"
 	SessionID                 = "session_id"
 	AccessPermissionKeyPrefix = "access_permission_"
+	RateLimitKeyPrefix        = "rate_limit_"
+	CaptchaKeyPrefix          = "captcha_"
+	CaptchaFailureKeyPrefix   = "captcha_failure_"
+)
+
+const (
+	WebhookEventPostPublished    = "post.published"
+	WebhookEventPostUpdated      = "post.updated"
+	WebhookEventPostDeleted      = "post.deleted"
+	WebhookEventCommentCreated   = "comment.created"
+	WebhookEventCommentApproved  = "comment.approved"
+	WebhookEventThemeActivated   = "theme.activated"
+	WebhookEventOptionUpdated    = "option.updated"
+	WebhookSignatureHeader       = "X-Sonic-Signature"
+	WebhookEventHeader           = "X-Sonic-Event"
+	WebhookDeliveryHeader        = "X-Sonic-Delivery"
+	WebhookSignatureSchemePrefix = "sha256="
+)
+
+var WebhookEvents = []string{
+	WebhookEventPostPublished, WebhookEventPostUpdated, WebhookEventPostDeleted,
+	WebhookEventCommentCreated, WebhookEventCommentApproved,
+	WebhookEventThemeActivated, WebhookEventOptionUpdated,
+}
+
+const (
+	CaptchaTypeImage      = "image"
+	CaptchaTypeArithmetic = "arithmetic"
 )
 
 const (
 	SonicBackupPrefix         = "sonic-backup-"
 	SonicDataExportPrefix     = "sonic-data-export-"
 	SonicBackupMarkdownPrefix = "sonic-backup-markdown-"
+	SonicBackupEpubPrefix     = "sonic-backup-epub-"
+	SonicBackupCommentPrefix  = "sonic-backup-comment-"
 	SonicDefaultTagColor      = "#cfd3d7"
 	SonicUploadDir            = "upload"
 	SonicDefaultThemeDirName  = "default-theme-anatole"
@@ -349,6 +349,7 @@ const (
 	CommentStatusPublished CommentStatus = iota
 	CommentStatusAuditing
 	CommentStatusRecycle
+	CommentStatusSpam
 )
 
 func (c CommentStatus) MarshalJSON() ([]byte, error) {
@@ -359,6 +360,8 @@ func (c CommentStatus) MarshalJSON() ([]byte, error) {
 		return []byte(`"AUDITING"`), nil
 	case CommentStatusRecycle:
 		return []byte(`"RECYCLE"`), nil
+	case CommentStatusSpam:
+		return []byte(`"SPAM"`), nil
 	}
 	return nil, nil
 }
@@ -373,6 +376,8 @@ func (c *CommentStatus) UnmarshalJSON(data []byte) error {
 		*c = CommentStatusAuditing
 	case `"RECYCLE"`:
 		*c = CommentStatusRecycle
+	case `"SPAM"`:
+		*c = CommentStatusSpam
 	default:
 		return xerr.BadParam.New("").WithMsg("unknown CommentStatus")
 	}
@@ -404,6 +409,8 @@ func CommentStatusFromString(str string) (CommentStatus, error) {
 		return CommentStatusAuditing, nil
 	case "RECYCLE":
 		return CommentStatusRecycle, nil
+	case "SPAM":
+		return CommentStatusSpam, nil
 	default:
 		return CommentStatusPublished, xerr.BadParam.New("").WithMsg("unknown CommentStatus")
 	}
@@ -590,6 +597,194 @@ func (ct CommentType) Value() (driver.Value, error) {
 	return int64(ct), nil
 }
 
+// CommentKind tells the comments left on the blog from the webmentions and the fediverse replies
+type CommentKind int32
+
+const (
+	CommentKindComment CommentKind = iota
+	CommentKindWebmention
+	CommentKindActivityPub
+)
+
+func (c CommentKind) MarshalJSON() ([]byte, error) {
+	switch c {
+	case CommentKindComment:
+		return []byte(`"COMMENT"`), nil
+	case CommentKindWebmention:
+		return []byte(`"WEBMENTION"`), nil
+	case CommentKindActivityPub:
+		return []byte(`"ACTIVITYPUB"`), nil
+	}
+	return nil, nil
+}
+
+func (c *CommentKind) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*c = CommentKind(data)
+	case int32:
+		*c = CommentKind(data)
+	case int:
+		*c = CommentKind(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (c CommentKind) Value() (driver.Value, error) {
+	return int64(c), nil
+}
+
+type CommentSubscriptionStatus int32
+
+const (
+	// CommentSubscriptionStatusThread receives all the comments of the content
+	CommentSubscriptionStatusThread CommentSubscriptionStatus = iota
+	// CommentSubscriptionStatusUnsubscribed receives no mails, not even the replies
+	CommentSubscriptionStatusUnsubscribed
+)
+
+func (c *CommentSubscriptionStatus) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*c = CommentSubscriptionStatus(data)
+	case int32:
+		*c = CommentSubscriptionStatus(data)
+	case int:
+		*c = CommentSubscriptionStatus(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (c CommentSubscriptionStatus) Value() (driver.Value, error) {
+	return int64(c), nil
+}
+
+// ReactionTarget is the type of the content that the reaction is given to, the sheets are posts as well
+type ReactionTarget int32
+
+const (
+	ReactionTargetPost ReactionTarget = iota
+	ReactionTargetJournal
+	ReactionTargetPhoto
+	ReactionTargetComment
+)
+
+// ReactionLike is counted by the likes field of the target
+const ReactionLike = "like"
+
+func (r *ReactionTarget) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*r = ReactionTarget(data)
+	case int32:
+		*r = ReactionTarget(data)
+	case int:
+		*r = ReactionTarget(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (r ReactionTarget) Value() (driver.Value, error) {
+	return int64(r), nil
+}
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = iota
+	WebhookDeliveryStatusSuccess
+	WebhookDeliveryStatusFailed
+)
+
+func (w WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	switch w {
+	case WebhookDeliveryStatusPending:
+		return []byte(`"PENDING"`), nil
+	case WebhookDeliveryStatusSuccess:
+		return []byte(`"SUCCESS"`), nil
+	case WebhookDeliveryStatusFailed:
+		return []byte(`"FAILED"`), nil
+	}
+	return nil, nil
+}
+
+func (w *WebhookDeliveryStatus) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*w = WebhookDeliveryStatus(data)
+	case int32:
+		*w = WebhookDeliveryStatus(data)
+	case int:
+		*w = WebhookDeliveryStatus(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (w WebhookDeliveryStatus) Value() (driver.Value, error) {
+	return int64(w), nil
+}
+
+type ActivityPubDeliveryStatus int32
+
+const (
+	ActivityPubDeliveryStatusPending ActivityPubDeliveryStatus = iota
+	ActivityPubDeliveryStatusSuccess
+	ActivityPubDeliveryStatusFailed
+)
+
+func (a ActivityPubDeliveryStatus) MarshalJSON() ([]byte, error) {
+	switch a {
+	case ActivityPubDeliveryStatusPending:
+		return []byte(`"PENDING"`), nil
+	case ActivityPubDeliveryStatusSuccess:
+		return []byte(`"SUCCESS"`), nil
+	case ActivityPubDeliveryStatusFailed:
+		return []byte(`"FAILED"`), nil
+	}
+	return nil, nil
+}
+
+func (a *ActivityPubDeliveryStatus) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*a = ActivityPubDeliveryStatus(data)
+	case int32:
+		*a = ActivityPubDeliveryStatus(data)
+	case int:
+		*a = ActivityPubDeliveryStatus(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (a ActivityPubDeliveryStatus) Value() (driver.Value, error) {
+	return int64(a), nil
+}
+
 type SheetPermaLinkType string
 
 const (
@@ -1016,3 +1211,12 @@ func (c CategoryType) Value() (driver.Value, error) {
 func (c CategoryType) Ptr() *CategoryType {
 	return &c
 }
+
+type LinkCheckStatus string
+
+const (
+	LinkCheckStatusIdle     LinkCheckStatus = "IDLE"
+	LinkCheckStatusRunning  LinkCheckStatus = "RUNNING"
+	LinkCheckStatusFinished LinkCheckStatus = "FINISHED"
+	LinkCheckStatusFailed   LinkCheckStatus = "FAILED"
+)
@@ -43,6 +43,9 @@ func newComment(db *gorm.DB, opts ...gen.DOOption) comment {
 	_comment.TopPriority = field.NewInt32(tableName, "top_priority")
 	_comment.UserAgent = field.NewString(tableName, "user_agent")
 	_comment.Likes = field.NewInt32(tableName, "likes")
+	_comment.Kind = field.NewField(tableName, "kind")
+	_comment.SourceURL = field.NewString(tableName, "source_url")
+	_comment.ActorID = field.NewString(tableName, "actor_id")
 
 	_comment.fillFieldMap()
 
@@ -71,6 +74,9 @@ type comment struct {
 	TopPriority       field.Int32
 	UserAgent         field.String
 	Likes             field.Int32
+	Kind              field.Field
+	SourceURL         field.String
+	ActorID           field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -105,6 +111,9 @@ func (c *comment) updateTableName(table string) *comment {
 	c.TopPriority = field.NewInt32(table, "top_priority")
 	c.UserAgent = field.NewString(table, "user_agent")
 	c.Likes = field.NewInt32(table, "likes")
+	c.Kind = field.NewField(table, "kind")
+	c.SourceURL = field.NewString(table, "source_url")
+	c.ActorID = field.NewString(table, "actor_id")
 
 	c.fillFieldMap()
 
@@ -129,7 +138,7 @@ func (c *comment) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (c *comment) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 17)
+	c.fieldMap = make(map[string]field.Expr, 21)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["type"] = c.Type
 	c.fieldMap["create_time"] = c.CreateTime
@@ -148,6 +157,9 @@ func (c *comment) fillFieldMap() {
 	c.fieldMap["top_priority"] = c.TopPriority
 	c.fieldMap["user_agent"] = c.UserAgent
 	c.fieldMap["likes"] = c.Likes
+	c.fieldMap["kind"] = c.Kind
+	c.fieldMap["source_url"] = c.SourceURL
+	c.fieldMap["actor_id"] = c.ActorID
 }
 
 func (c comment) clone(db *gorm.DB) comment {
@@ -30,6 +30,9 @@ func newCommentBlack(db *gorm.DB, opts ...gen.DOOption) commentBlack {
 	_commentBlack.UpdateTime = field.NewTime(tableName, "update_time")
 	_commentBlack.BanTime = field.NewTime(tableName, "ban_time")
 	_commentBlack.IPAddress = field.NewString(tableName, "ip_address")
+	_commentBlack.Email = field.NewString(tableName, "email")
+	_commentBlack.Author = field.NewString(tableName, "author")
+	_commentBlack.Reason = field.NewString(tableName, "reason")
 
 	_commentBlack.fillFieldMap()
 
@@ -45,6 +48,9 @@ type commentBlack struct {
 	UpdateTime field.Time
 	BanTime    field.Time
 	IPAddress  field.String
+	Email      field.String
+	Author     field.String
+	Reason     field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -66,6 +72,9 @@ func (c *commentBlack) updateTableName(table string) *commentBlack {
 	c.UpdateTime = field.NewTime(table, "update_time")
 	c.BanTime = field.NewTime(table, "ban_time")
 	c.IPAddress = field.NewString(table, "ip_address")
+	c.Email = field.NewString(table, "email")
+	c.Author = field.NewString(table, "author")
+	c.Reason = field.NewString(table, "reason")
 
 	c.fillFieldMap()
 
@@ -94,12 +103,15 @@ func (c *commentBlack) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (c *commentBlack) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 5)
+	c.fieldMap = make(map[string]field.Expr, 8)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["create_time"] = c.CreateTime
 	c.fieldMap["update_time"] = c.UpdateTime
 	c.fieldMap["ban_time"] = c.BanTime
 	c.fieldMap["ip_address"] = c.IPAddress
+	c.fieldMap["email"] = c.Email
+	c.fieldMap["author"] = c.Author
+	c.fieldMap["reason"] = c.Reason
 }
 
 func (c commentBlack) clone(db *gorm.DB) commentBlack {
@@ -95,7 +95,9 @@ func dbMigrate() {
 	})
 	err := db.AutoMigrate(&entity.Attachment{}, &entity.Category{}, &entity.Comment{}, &entity.CommentBlack{}, &entity.Journal{},
 		&entity.Link{}, &entity.Log{}, &entity.Menu{}, &entity.Meta{}, &entity.Option{}, &entity.Photo{}, &entity.Post{},
-		&entity.PostCategory{}, &entity.PostTag{}, &entity.Tag{}, &entity.ThemeSetting{}, &entity.User{})
+		&entity.PostCategory{}, &entity.PostTag{}, &entity.Tag{}, &entity.ThemeSetting{}, &entity.User{},
+		&entity.CommentSpamToken{}, &entity.CommentSubscription{}, &entity.CommentRevision{}, &entity.Reaction{}, &entity.Webhook{}, &entity.WebhookDelivery{},
+		&entity.ActivityPubFollower{}, &entity.ActivityPubDelivery{}, &entity.ActivityPubArticle{})
 	if err != nil {
 		sonicLog.Fatal("failed auto migrate db", zap.Error(err))
 	}
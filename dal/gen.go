@@ -15,10 +15,16 @@ import (
 
 var (
 	Q                   = new(Query)
+	ActivityPubArticle  *activityPubArticle
+	ActivityPubDelivery *activityPubDelivery
+	ActivityPubFollower *activityPubFollower
 	Attachment          *attachment
 	Category            *category
 	Comment             *comment
 	CommentBlack        *commentBlack
+	CommentRevision     *commentRevision
+	CommentSpamToken    *commentSpamToken
+	CommentSubscription *commentSubscription
 	FlywaySchemaHistory *flywaySchemaHistory
 	Journal             *journal
 	Link                *link
@@ -30,17 +36,26 @@ var (
 	Post                *post
 	PostCategory        *postCategory
 	PostTag             *postTag
+	Reaction            *reaction
 	Tag                 *tag
 	ThemeSetting        *themeSetting
 	User                *user
+	Webhook             *webhook
+	WebhookDelivery     *webhookDelivery
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	ActivityPubArticle = &Q.ActivityPubArticle
+	ActivityPubDelivery = &Q.ActivityPubDelivery
+	ActivityPubFollower = &Q.ActivityPubFollower
 	Attachment = &Q.Attachment
 	Category = &Q.Category
 	Comment = &Q.Comment
 	CommentBlack = &Q.CommentBlack
+	CommentRevision = &Q.CommentRevision
+	CommentSpamToken = &Q.CommentSpamToken
+	CommentSubscription = &Q.CommentSubscription
 	FlywaySchemaHistory = &Q.FlywaySchemaHistory
 	Journal = &Q.Journal
 	Link = &Q.Link
@@ -52,18 +67,27 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	Post = &Q.Post
 	PostCategory = &Q.PostCategory
 	PostTag = &Q.PostTag
+	Reaction = &Q.Reaction
 	Tag = &Q.Tag
 	ThemeSetting = &Q.ThemeSetting
 	User = &Q.User
+	Webhook = &Q.Webhook
+	WebhookDelivery = &Q.WebhookDelivery
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
 		db:                  db,
+		ActivityPubArticle:  newActivityPubArticle(db, opts...),
+		ActivityPubDelivery: newActivityPubDelivery(db, opts...),
+		ActivityPubFollower: newActivityPubFollower(db, opts...),
 		Attachment:          newAttachment(db, opts...),
 		Category:            newCategory(db, opts...),
 		Comment:             newComment(db, opts...),
 		CommentBlack:        newCommentBlack(db, opts...),
+		CommentRevision:     newCommentRevision(db, opts...),
+		CommentSpamToken:    newCommentSpamToken(db, opts...),
+		CommentSubscription: newCommentSubscription(db, opts...),
 		FlywaySchemaHistory: newFlywaySchemaHistory(db, opts...),
 		Journal:             newJournal(db, opts...),
 		Link:                newLink(db, opts...),
@@ -75,19 +99,28 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 		Post:                newPost(db, opts...),
 		PostCategory:        newPostCategory(db, opts...),
 		PostTag:             newPostTag(db, opts...),
+		Reaction:            newReaction(db, opts...),
 		Tag:                 newTag(db, opts...),
 		ThemeSetting:        newThemeSetting(db, opts...),
 		User:                newUser(db, opts...),
+		Webhook:             newWebhook(db, opts...),
+		WebhookDelivery:     newWebhookDelivery(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
+	ActivityPubArticle  activityPubArticle
+	ActivityPubDelivery activityPubDelivery
+	ActivityPubFollower activityPubFollower
 	Attachment          attachment
 	Category            category
 	Comment             comment
 	CommentBlack        commentBlack
+	CommentRevision     commentRevision
+	CommentSpamToken    commentSpamToken
+	CommentSubscription commentSubscription
 	FlywaySchemaHistory flywaySchemaHistory
 	Journal             journal
 	Link                link
@@ -99,9 +132,12 @@ type Query struct {
 	Post                post
 	PostCategory        postCategory
 	PostTag             postTag
+	Reaction            reaction
 	Tag                 tag
 	ThemeSetting        themeSetting
 	User                user
+	Webhook             webhook
+	WebhookDelivery     webhookDelivery
 }
 
 func (q *Query) Available() bool { return q.db != nil }
@@ -109,10 +145,16 @@ func (q *Query) Available() bool { return q.db != nil }
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
 		db:                  db,
+		ActivityPubArticle:  q.ActivityPubArticle.clone(db),
+		ActivityPubDelivery: q.ActivityPubDelivery.clone(db),
+		ActivityPubFollower: q.ActivityPubFollower.clone(db),
 		Attachment:          q.Attachment.clone(db),
 		Category:            q.Category.clone(db),
 		Comment:             q.Comment.clone(db),
 		CommentBlack:        q.CommentBlack.clone(db),
+		CommentRevision:     q.CommentRevision.clone(db),
+		CommentSpamToken:    q.CommentSpamToken.clone(db),
+		CommentSubscription: q.CommentSubscription.clone(db),
 		FlywaySchemaHistory: q.FlywaySchemaHistory.clone(db),
 		Journal:             q.Journal.clone(db),
 		Link:                q.Link.clone(db),
@@ -124,9 +166,12 @@ func (q *Query) clone(db *gorm.DB) *Query {
 		Post:                q.Post.clone(db),
 		PostCategory:        q.PostCategory.clone(db),
 		PostTag:             q.PostTag.clone(db),
+		Reaction:            q.Reaction.clone(db),
 		Tag:                 q.Tag.clone(db),
 		ThemeSetting:        q.ThemeSetting.clone(db),
 		User:                q.User.clone(db),
+		Webhook:             q.Webhook.clone(db),
+		WebhookDelivery:     q.WebhookDelivery.clone(db),
 	}
 }
 
@@ -141,10 +186,16 @@ func (q *Query) WriteDB() *Query {
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
 		db:                  db,
+		ActivityPubArticle:  q.ActivityPubArticle.replaceDB(db),
+		ActivityPubDelivery: q.ActivityPubDelivery.replaceDB(db),
+		ActivityPubFollower: q.ActivityPubFollower.replaceDB(db),
 		Attachment:          q.Attachment.replaceDB(db),
 		Category:            q.Category.replaceDB(db),
 		Comment:             q.Comment.replaceDB(db),
 		CommentBlack:        q.CommentBlack.replaceDB(db),
+		CommentRevision:     q.CommentRevision.replaceDB(db),
+		CommentSpamToken:    q.CommentSpamToken.replaceDB(db),
+		CommentSubscription: q.CommentSubscription.replaceDB(db),
 		FlywaySchemaHistory: q.FlywaySchemaHistory.replaceDB(db),
 		Journal:             q.Journal.replaceDB(db),
 		Link:                q.Link.replaceDB(db),
@@ -156,17 +207,26 @@ func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 		Post:                q.Post.replaceDB(db),
 		PostCategory:        q.PostCategory.replaceDB(db),
 		PostTag:             q.PostTag.replaceDB(db),
+		Reaction:            q.Reaction.replaceDB(db),
 		Tag:                 q.Tag.replaceDB(db),
 		ThemeSetting:        q.ThemeSetting.replaceDB(db),
 		User:                q.User.replaceDB(db),
+		Webhook:             q.Webhook.replaceDB(db),
+		WebhookDelivery:     q.WebhookDelivery.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
+	ActivityPubArticle  *activityPubArticleDo
+	ActivityPubDelivery *activityPubDeliveryDo
+	ActivityPubFollower *activityPubFollowerDo
 	Attachment          *attachmentDo
 	Category            *categoryDo
 	Comment             *commentDo
 	CommentBlack        *commentBlackDo
+	CommentRevision     *commentRevisionDo
+	CommentSpamToken    *commentSpamTokenDo
+	CommentSubscription *commentSubscriptionDo
 	FlywaySchemaHistory *flywaySchemaHistoryDo
 	Journal             *journalDo
 	Link                *linkDo
@@ -178,17 +238,26 @@ type queryCtx struct {
 	Post                *postDo
 	PostCategory        *postCategoryDo
 	PostTag             *postTagDo
+	Reaction            *reactionDo
 	Tag                 *tagDo
 	ThemeSetting        *themeSettingDo
 	User                *userDo
+	Webhook             *webhookDo
+	WebhookDelivery     *webhookDeliveryDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
+		ActivityPubArticle:  q.ActivityPubArticle.WithContext(ctx),
+		ActivityPubDelivery: q.ActivityPubDelivery.WithContext(ctx),
+		ActivityPubFollower: q.ActivityPubFollower.WithContext(ctx),
 		Attachment:          q.Attachment.WithContext(ctx),
 		Category:            q.Category.WithContext(ctx),
 		Comment:             q.Comment.WithContext(ctx),
 		CommentBlack:        q.CommentBlack.WithContext(ctx),
+		CommentRevision:     q.CommentRevision.WithContext(ctx),
+		CommentSpamToken:    q.CommentSpamToken.WithContext(ctx),
+		CommentSubscription: q.CommentSubscription.WithContext(ctx),
 		FlywaySchemaHistory: q.FlywaySchemaHistory.WithContext(ctx),
 		Journal:             q.Journal.WithContext(ctx),
 		Link:                q.Link.WithContext(ctx),
@@ -200,9 +269,12 @@ func (q *Query) WithContext(ctx context.Context) *queryCtx {
 		Post:                q.Post.WithContext(ctx),
 		PostCategory:        q.PostCategory.WithContext(ctx),
 		PostTag:             q.PostTag.WithContext(ctx),
+		Reaction:            q.Reaction.WithContext(ctx),
 		Tag:                 q.Tag.WithContext(ctx),
 		ThemeSetting:        q.ThemeSetting.WithContext(ctx),
 		User:                q.User.WithContext(ctx),
+		Webhook:             q.Webhook.WithContext(ctx),
+		WebhookDelivery:     q.WebhookDelivery.WithContext(ctx),
 	}
 }
 
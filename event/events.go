@@ -18,8 +18,17 @@ const (
 	ThemeActivatedEventName   = "ThemeActivatedEvent"
 	ThemeFileUpdatedEventName = "ThemeFileUpdatedEvent"
 	PostUpdateEventName       = "PostUpdateEvent"
+	PostPublishedEventName    = "PostPublishedEvent"
+	PostDeleteEventName       = "PostDeleteEvent"
+	PostStatusUpdateEventName = "PostStatusUpdateEvent"
+	SheetUpdateEventName      = "SheetUpdateEvent"
+	CategoryUpdateEventName   = "CategoryUpdateEvent"
+	TagUpdateEventName        = "TagUpdateEvent"
 	CommentNewEventName       = "CommentNewEvent"
 	CommentReplyEventName     = "CommentReplayEvent"
+	CommentCreatedEventName   = "CommentCreatedEvent"
+	CommentApprovedEventName  = "CommentApprovedEvent"
+	CommentSubscribeEventName = "CommentSubscribeEvent"
 )
 
 type LogEvent struct {
@@ -73,6 +82,8 @@ func (t *ThemeFileUpdatedEvent) EventType() string {
 
 type PostUpdateEvent struct {
 	PostID int32
+	// Batch tells the categories or the tags of many posts are changed at once, the content of the post is not changed
+	Batch bool
 }
 
 func (p *PostUpdateEvent) EventType() string {
@@ -94,3 +105,79 @@ type CommentReplyEvent struct {
 func (c *CommentReplyEvent) EventType() string {
 	return CommentReplyEventName
 }
+
+type PostPublishedEvent struct {
+	PostID int32
+}
+
+func (p *PostPublishedEvent) EventType() string {
+	return PostPublishedEventName
+}
+
+// PostDeleteEvent carries the post as it was before it was deleted
+type PostDeleteEvent struct {
+	Post *entity.Post
+}
+
+func (p *PostDeleteEvent) EventType() string {
+	return PostDeleteEventName
+}
+
+// PostStatusUpdateEvent is published when the status of a post or a sheet is changed alone, such as being moved to the recycle bin
+type PostStatusUpdateEvent struct {
+	PostID    int32
+	OldStatus consts.PostStatus
+	Status    consts.PostStatus
+}
+
+func (p *PostStatusUpdateEvent) EventType() string {
+	return PostStatusUpdateEventName
+}
+
+type SheetUpdateEvent struct {
+	SheetID int32
+}
+
+func (s *SheetUpdateEvent) EventType() string {
+	return SheetUpdateEventName
+}
+
+// CategoryUpdateEvent is published when the categories are created, updated or deleted
+type CategoryUpdateEvent struct{}
+
+func (c *CategoryUpdateEvent) EventType() string {
+	return CategoryUpdateEventName
+}
+
+// TagUpdateEvent is published when a tag is created, updated or deleted
+type TagUpdateEvent struct{}
+
+func (t *TagUpdateEvent) EventType() string {
+	return TagUpdateEventName
+}
+
+// CommentCreatedEvent is published for every comment which is not spam, including the replies
+type CommentCreatedEvent struct {
+	Comment *entity.Comment
+}
+
+func (c *CommentCreatedEvent) EventType() string {
+	return CommentCreatedEventName
+}
+
+type CommentApprovedEvent struct {
+	Comment *entity.Comment
+}
+
+func (c *CommentApprovedEvent) EventType() string {
+	return CommentApprovedEventName
+}
+
+// CommentSubscribeEvent is published when the author of the comment asks to subscribe the thread, the subscription waits for the confirmation of the mail
+type CommentSubscribeEvent struct {
+	Comment *entity.Comment
+}
+
+func (c *CommentSubscribeEvent) EventType() string {
+	return CommentSubscribeEventName
+}
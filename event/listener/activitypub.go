@@ -0,0 +1,55 @@
+package listener
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/service"
+)
+
+// ActivityPubListener sends the published, updated and deleted posts to the followers of the blog actor
+type ActivityPubListener struct {
+	ActivityPubService service.ActivityPubService
+	BasePostService    service.BasePostService
+}
+
+func NewActivityPubListener(bus event.Bus, activityPubService service.ActivityPubService, basePostService service.BasePostService) {
+	a := &ActivityPubListener{
+		ActivityPubService: activityPubService,
+		BasePostService:    basePostService,
+	}
+	bus.Subscribe(event.PostPublishedEventName, a.HandlePostPublished)
+	bus.Subscribe(event.PostUpdateEventName, a.HandlePostUpdate)
+	bus.Subscribe(event.PostDeleteEventName, a.HandlePostDelete)
+	bus.Subscribe(event.PostStatusUpdateEventName, a.HandlePostStatusUpdate)
+}
+
+func (a *ActivityPubListener) HandlePostPublished(ctx context.Context, e event.Event) error {
+	return a.publish(ctx, e.(*event.PostPublishedEvent).PostID, "Create")
+}
+
+func (a *ActivityPubListener) HandlePostUpdate(ctx context.Context, e event.Event) error {
+	return a.publish(ctx, e.(*event.PostUpdateEvent).PostID, "Update")
+}
+
+// HandlePostStatusUpdate deletes the article of a post which is no longer published, the publishing is handled by HandlePostPublished
+func (a *ActivityPubListener) HandlePostStatusUpdate(ctx context.Context, e event.Event) error {
+	statusUpdateEvent := e.(*event.PostStatusUpdateEvent)
+	if statusUpdateEvent.OldStatus != consts.PostStatusPublished {
+		return nil
+	}
+	return a.publish(ctx, statusUpdateEvent.PostID, "Update")
+}
+
+func (a *ActivityPubListener) HandlePostDelete(ctx context.Context, e event.Event) error {
+	return a.ActivityPubService.PublishPost(ctx, e.(*event.PostDeleteEvent).Post, "Delete")
+}
+
+func (a *ActivityPubListener) publish(ctx context.Context, postID int32, activityType string) error {
+	post, err := a.BasePostService.GetByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	return a.ActivityPubService.PublishPost(ctx, post, activityType)
+}
@@ -3,9 +3,14 @@ package listener
 import (
 	"bytes"
 	"context"
+	"strings"
+
+	"go.uber.org/zap"
 
 	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/entity"
 	"github.com/go-sonic/sonic/model/property"
 	"github.com/go-sonic/sonic/service"
 	"github.com/go-sonic/sonic/service/assembler"
@@ -14,16 +19,17 @@ import (
 )
 
 type CommentListener struct {
-	OptionService      service.OptionService
-	PostService        service.PostService
-	PostAssembler      assembler.PostAssembler
-	JournalService     service.JournalService
-	SheetService       service.SheetService
-	ThemeService       service.ThemeService
-	EmailService       service.EmailService
-	UserService        service.UserService
-	BaseCommentService service.BaseCommentService
-	Template           *template.Template
+	OptionService       service.OptionService
+	PostService         service.PostService
+	PostAssembler       assembler.PostAssembler
+	JournalService      service.JournalService
+	SheetService        service.SheetService
+	ThemeService        service.ThemeService
+	EmailService        service.EmailService
+	UserService         service.UserService
+	BaseCommentService  service.BaseCommentService
+	SubscriptionService service.CommentSubscriptionService
+	Template            *template.Template
 }
 
 func NewCommentListener(
@@ -38,21 +44,26 @@ func NewCommentListener(
 	userService service.UserService,
 	template *template.Template,
 	baseCommentService service.BaseCommentService,
+	subscriptionService service.CommentSubscriptionService,
 ) {
 	c := &CommentListener{
-		OptionService:      optionService,
-		PostService:        postService,
-		PostAssembler:      postAssembler,
-		JournalService:     journalService,
-		SheetService:       sheetService,
-		ThemeService:       themeService,
-		EmailService:       emailService,
-		UserService:        userService,
-		Template:           template,
-		BaseCommentService: baseCommentService,
+		OptionService:       optionService,
+		PostService:         postService,
+		PostAssembler:       postAssembler,
+		JournalService:      journalService,
+		SheetService:        sheetService,
+		ThemeService:        themeService,
+		EmailService:        emailService,
+		UserService:         userService,
+		Template:            template,
+		BaseCommentService:  baseCommentService,
+		SubscriptionService: subscriptionService,
 	}
 	bus.Subscribe(event.CommentNewEventName, c.HandleCommentNew)
+	bus.Subscribe(event.CommentNewEventName, c.HandleCommentThread)
+	bus.Subscribe(event.CommentApprovedEventName, c.HandleCommentThreadApproved)
 	bus.Subscribe(event.CommentReplyEventName, c.HandleCommentReply)
+	bus.Subscribe(event.CommentSubscribeEventName, c.HandleCommentSubscribe)
 }
 
 func (c *CommentListener) HandleCommentNew(ctx context.Context, ce event.Event) error {
@@ -125,135 +136,248 @@ func (c *CommentListener) HandleCommentNew(ctx context.Context, ce event.Event)
 		data["authorUrl"] = comment.AuthorURL
 		subject = "Your blog journal has a new comment"
 	}
-	template := "common/mail_template/mail_notice"
-	if exist, err := c.ThemeService.TemplateExist(ctx, "mail_template/mail_notice.tmpl"); err != nil && exist {
-		t, err := c.ThemeService.Render(ctx, "mail_template/mail_notice")
-		if err == nil {
-			template = t
-		}
-	}
-	content := bytes.Buffer{}
-	err = c.Template.ExecuteTemplate(&content, template, data)
+	users, err := c.UserService.GetAllUser(ctx)
 	if err != nil {
 		return err
 	}
-	users, err := c.UserService.GetAllUser(ctx)
+	unsubscribed, err := c.SubscriptionService.IsUnsubscribed(ctx, users[0].Email, comment.Type, comment.PostID)
+	if err != nil || unsubscribed {
+		return err
+	}
+	data["user"] = users[0]
+	if err := c.addUnsubscribeURL(ctx, data, users[0].Email, comment); err != nil {
+		return err
+	}
+	content, err := c.renderMail(ctx, "mail_notice", data)
 	if err != nil {
 		return err
 	}
-	return c.EmailService.SendTemplateEmail(ctx, users[0].Email, subject, content.String())
+	return c.EmailService.SendTemplateEmail(ctx, users[0].Email, subject, content)
 }
 
 func (c *CommentListener) HandleCommentReply(ctx context.Context, ce event.Event) error {
-	commentReplyNotice, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CommentReplyNotice, property.CommentNewNotice.DefaultValue)
+	commentEvent, ok := ce.(*event.CommentReplyEvent)
+	if !ok {
+		return nil
+	}
+	comment := commentEvent.Comment
+	parentComment, err := c.BaseCommentService.GetByID(ctx, comment.ParentID)
 	if err != nil {
 		return err
 	}
-	if !commentReplyNotice.(bool) {
+	return c.notifyCommenters(ctx, comment, parentComment)
+}
+
+// HandleCommentThread notifies the subscribers of the thread of a new top-level comment.
+func (c *CommentListener) HandleCommentThread(ctx context.Context, ce event.Event) error {
+	commentEvent, ok := ce.(*event.CommentNewEvent)
+	if !ok {
 		return nil
 	}
-	commentEvent, ok := ce.(*event.CommentReplyEvent)
-	if !ok {
+	return c.notifyCommenters(ctx, commentEvent.Comment, nil)
+}
+
+// HandleCommentThreadApproved notifies the subscribers of the thread of a top-level comment published after moderation,
+// the replies are notified by the CommentReplyEvent of the status change.
+func (c *CommentListener) HandleCommentThreadApproved(ctx context.Context, ce event.Event) error {
+	commentEvent, ok := ce.(*event.CommentApprovedEvent)
+	if !ok || commentEvent.Comment.ParentID != 0 {
 		return nil
 	}
+	return c.notifyCommenters(ctx, commentEvent.Comment, nil)
+}
 
-	comment := commentEvent.Comment
-	if comment.Status != consts.CommentStatusPublished {
+// HandleCommentSubscribe mails the link confirming the subscription of the thread to the author of the comment,
+// unless the author has subscribed the thread already.
+func (c *CommentListener) HandleCommentSubscribe(ctx context.Context, ce event.Event) error {
+	commentEvent, ok := ce.(*event.CommentSubscribeEvent)
+	if !ok {
 		return nil
 	}
-	parentComment, err := c.BaseCommentService.GetByID(ctx, comment.ParentID)
+	comment := commentEvent.Comment
+	subscribers, err := c.SubscriptionService.ListThreadSubscribers(ctx, comment.Type, comment.PostID)
 	if err != nil {
 		return err
 	}
-	if !parentComment.AllowNotification || parentComment.Status != consts.CommentStatusPublished {
-		return nil
+	for _, subscriber := range subscribers {
+		if strings.EqualFold(subscriber, comment.Email) {
+			return nil
+		}
 	}
-
 	blogTitle, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.BlogTitle, "")
 	if err != nil {
 		return err
 	}
-	enabledAbsolutePath, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.GlobalAbsolutePathEnabled, false)
+	pageFullPath, pageTitle, err := c.getPage(ctx, comment)
+	if err != nil || pageFullPath == "" {
+		return err
+	}
+	subscribeURL, err := c.SubscriptionService.BuildSubscribeURL(ctx, comment.Email, comment.Type, comment.PostID)
 	if err != nil {
 		return err
 	}
-	blogBaseURL, err := c.OptionService.GetBlogBaseURL(ctx)
+	data := make(map[string]interface{})
+	data["pageFullPath"] = pageFullPath
+	data["pageTitle"] = pageTitle
+	data["author"] = comment.Author
+	data["subscribeUrl"] = subscribeURL
+	content, err := c.renderMail(ctx, "mail_subscribe", data)
 	if err != nil {
 		return err
 	}
+	return c.EmailService.SendTemplateEmail(ctx, comment.Email, "Please confirm to follow the comments of 《"+pageTitle+"》 on "+blogTitle.(string), content)
+}
 
-	data := make(map[string]interface{})
+// notifyCommenters mails the comment to the author of the parent comment and the subscribers of the thread,
+// except the ones who have unsubscribed.
+func (c *CommentListener) notifyCommenters(ctx context.Context, comment *entity.Comment, parentComment *entity.Comment) error {
+	commentReplyNotice, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CommentReplyNotice, property.CommentNewNotice.DefaultValue)
+	if err != nil {
+		return err
+	}
+	if !commentReplyNotice.(bool) || comment.Status != consts.CommentStatusPublished {
+		return nil
+	}
+
+	recipients := make([]string, 0)
+	if parentComment != nil && parentComment.AllowNotification && parentComment.Status == consts.CommentStatusPublished {
+		recipients = append(recipients, parentComment.Email)
+	}
+	subscribers, err := c.SubscriptionService.ListThreadSubscribers(ctx, comment.Type, comment.PostID)
+	if err != nil {
+		return err
+	}
+	recipients = append(recipients, subscribers...)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	blogTitle, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.BlogTitle, "")
+	if err != nil {
+		return err
+	}
+	pageFullPath, pageTitle, err := c.getPage(ctx, comment)
+	if err != nil || pageFullPath == "" {
+		return err
+	}
 	var subject string
-	if comment.Type == consts.CommentTypePost || comment.Type == consts.CommentTypeSheet {
-		post, err := c.PostService.GetByPostID(ctx, commentEvent.Comment.PostID)
-		if err != nil {
-			return nil
+	switch {
+	case parentComment == nil && comment.Type == consts.CommentTypePost:
+		subject = "There is a new comment in the 《" + pageTitle + "》 article you follow on " + blogTitle.(string)
+	case parentComment == nil && comment.Type == consts.CommentTypeSheet:
+		subject = "There is a new comment in the 《" + pageTitle + "》 page you follow on " + blogTitle.(string)
+	case parentComment == nil:
+		subject = "There is a new comment in the journal page you follow on " + blogTitle.(string)
+	case comment.Type == consts.CommentTypePost:
+		subject = "You have a new reply in the 《" + pageTitle + "》 article you comment on " + blogTitle.(string)
+	case comment.Type == consts.CommentTypeSheet:
+		subject = "You have a new reply in the 《" + pageTitle + "》 page you comment on " + blogTitle.(string)
+	default:
+		subject = "You have a new reply in the journal page you comment on " + blogTitle.(string)
+	}
+
+	// the commenter is not notified of the own comment
+	notified := map[string]bool{strings.ToLower(comment.Email): true}
+	for _, recipient := range recipients {
+		if recipient == "" || notified[strings.ToLower(recipient)] {
+			continue
 		}
-		postDTO, err := c.PostAssembler.ConvertToMinimalDTO(ctx, post)
+		notified[strings.ToLower(recipient)] = true
+		unsubscribed, err := c.SubscriptionService.IsUnsubscribed(ctx, recipient, comment.Type, comment.PostID)
 		if err != nil {
-			return nil
+			return err
 		}
-		data["pageFullPath"] = util.IfElse(enabledAbsolutePath.(bool), postDTO.FullPath, blogBaseURL+postDTO.FullPath).(string)
-		data["pageTitle"] = postDTO.Title
-		data["baseAuthor"] = parentComment.Author
-		data["baseContent"] = parentComment.Content
+		if unsubscribed {
+			continue
+		}
+
+		data := make(map[string]interface{})
+		data["pageFullPath"] = pageFullPath
+		data["pageTitle"] = pageTitle
 		data["replyAuthor"] = comment.Author
 		data["replyContent"] = comment.Content
-		data["baseAuthorEmail"] = parentComment.Email
 		data["replyAuthorEmail"] = comment.Email
 		data["status"] = comment.Status
 		data["createTime"] = comment.CreateTime
 		data["authorUrl"] = comment.AuthorURL
-		if comment.Type == consts.CommentTypePost {
-			subject = "You have a new reply in the 《" + post.Title + "》 article you comment on " + blogTitle.(string)
-		} else {
-			subject = "You have a new reply in the 《" + post.Title + "》 page you comment on " + blogTitle.(string)
+		if parentComment != nil && strings.EqualFold(recipient, parentComment.Email) {
+			data["baseAuthor"] = parentComment.Author
+			data["baseContent"] = parentComment.Content
+			data["baseAuthorEmail"] = parentComment.Email
 		}
-	} else if comment.Type == consts.CommentTypeJournal {
-		blogBaseURL, err := c.OptionService.GetBlogBaseURL(ctx)
-		if err != nil {
+		if err := c.addUnsubscribeURL(ctx, data, recipient, comment); err != nil {
 			return err
 		}
-		journalPrefix, err := c.OptionService.GetJournalPrefix(ctx)
+		content, err := c.renderMail(ctx, "mail_reply", data)
 		if err != nil {
 			return err
 		}
-		journals, err := c.JournalService.GetByJournalIDs(ctx, []int32{comment.PostID})
+		if err := c.EmailService.SendTemplateEmail(ctx, recipient, subject, content); err != nil {
+			log.CtxWarn(ctx, "send comment reply mail err", zap.String("to", recipient), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// getPage returns the url and the title of the content of the comment, the url is empty if the content does not exist.
+func (c *CommentListener) getPage(ctx context.Context, comment *entity.Comment) (string, string, error) {
+	blogBaseURL, err := c.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if comment.Type == consts.CommentTypeJournal {
+		journalPrefix, err := c.OptionService.GetJournalPrefix(ctx)
 		if err != nil {
-			return err
+			return "", "", err
 		}
-		if len(journals) == 0 {
-			return nil
+		journals, err := c.JournalService.GetByJournalIDs(ctx, []int32{comment.PostID})
+		if err != nil || len(journals) == 0 {
+			return "", "", err
 		}
-		journal := journals[comment.PostID]
-		data["pageFullPath"] = blogBaseURL + "/" + journalPrefix
-		data["pageTitle"] = journal.CreateTime.Format("2006-01-02 03:04")
-		data["baseAuthor"] = parentComment.Author
-		data["baseContent"] = parentComment.Content
-		data["replyAuthor"] = comment.Author
-		data["replyContent"] = comment.Content
-		data["baseAuthorEmail"] = parentComment.Email
-		data["replyAuthorEmail"] = comment.Email
-		data["status"] = comment.Status
-		data["createTime"] = comment.CreateTime
-		data["authorUrl"] = comment.AuthorURL
-		subject = "You have a new reply in the journal page you comment on " + blogTitle.(string)
+		return blogBaseURL + "/" + journalPrefix, journals[comment.PostID].CreateTime.Format("2006-01-02 03:04"), nil
 	}
-	template := "common/mail_template/mail_reply"
-	if exist, err := c.ThemeService.TemplateExist(ctx, "mail_template/mail_reply.tmpl"); err != nil && exist {
-		t, err := c.ThemeService.Render(ctx, "mail_template/mail_reply")
-		if err == nil {
-			template = t
-		}
+	enabledAbsolutePath, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.GlobalAbsolutePathEnabled, false)
+	if err != nil {
+		return "", "", err
 	}
-	content := bytes.Buffer{}
-	err = c.Template.ExecuteTemplate(&content, template, data)
+	post, err := c.PostService.GetByPostID(ctx, comment.PostID)
+	if err != nil {
+		return "", "", nil
+	}
+	postDTO, err := c.PostAssembler.ConvertToMinimalDTO(ctx, post)
+	if err != nil {
+		return "", "", nil
+	}
+	return util.IfElse(enabledAbsolutePath.(bool), postDTO.FullPath, blogBaseURL+postDTO.FullPath).(string), postDTO.Title, nil
+}
+
+// addUnsubscribeURL adds the signed links to stop the mails of the content or of all the contents.
+func (c *CommentListener) addUnsubscribeURL(ctx context.Context, data map[string]interface{}, email string, comment *entity.Comment) error {
+	unsubscribeURL, err := c.SubscriptionService.BuildUnsubscribeURL(ctx, email, comment.Type, comment.PostID)
 	if err != nil {
 		return err
 	}
-	users, err := c.UserService.GetAllUser(ctx)
+	unsubscribeAllURL, err := c.SubscriptionService.BuildUnsubscribeURL(ctx, email, comment.Type, 0)
 	if err != nil {
 		return err
 	}
-	return c.EmailService.SendTemplateEmail(ctx, users[0].Email, subject, content.String())
+	data["unsubscribeUrl"] = unsubscribeURL
+	data["unsubscribeAllUrl"] = unsubscribeAllURL
+	return nil
+}
+
+// renderMail renders the mail template of the activated theme, or the built-in one.
+func (c *CommentListener) renderMail(ctx context.Context, name string, data map[string]interface{}) (string, error) {
+	templateName := "common/mail_template/" + name
+	if exist, err := c.ThemeService.TemplateExist(ctx, "mail_template/"+name+".tmpl"); err == nil && exist {
+		t, err := c.ThemeService.Render(ctx, "mail_template/"+name)
+		if err == nil {
+			templateName = t
+		}
+	}
+	content := bytes.Buffer{}
+	if err := c.Template.ExecuteTemplate(&content, templateName, data); err != nil {
+		return "", err
+	}
+	return content.String(), nil
 }
@@ -0,0 +1,32 @@
+package listener
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/service"
+)
+
+// SitemapListener drops the cached sitemaps when the posts, the sheets, the categories, the tags or the options change
+type SitemapListener struct {
+	SitemapService service.SitemapService
+}
+
+func NewSitemapListener(bus event.Bus, sitemapService service.SitemapService) {
+	s := &SitemapListener{
+		SitemapService: sitemapService,
+	}
+	bus.Subscribe(event.PostPublishedEventName, s.HandleContentChange)
+	bus.Subscribe(event.PostUpdateEventName, s.HandleContentChange)
+	bus.Subscribe(event.PostDeleteEventName, s.HandleContentChange)
+	bus.Subscribe(event.PostStatusUpdateEventName, s.HandleContentChange)
+	bus.Subscribe(event.SheetUpdateEventName, s.HandleContentChange)
+	bus.Subscribe(event.CategoryUpdateEventName, s.HandleContentChange)
+	bus.Subscribe(event.TagUpdateEventName, s.HandleContentChange)
+	bus.Subscribe(event.OptionUpdateEventName, s.HandleContentChange)
+}
+
+func (s *SitemapListener) HandleContentChange(ctx context.Context, e event.Event) error {
+	s.SitemapService.Evict()
+	return nil
+}
@@ -175,10 +175,15 @@ func (t *TemplateConfigListener) loadOption(ctx context.Context) error {
 	tagPrefix := t.OptionService.GetOrByDefault(ctx, property.TagsPrefix)
 	linkPrefix := t.OptionService.GetOrByDefault(ctx, property.LinksPrefix)
 	photoPrefix := t.OptionService.GetOrByDefault(ctx, property.PhotosPrefix)
+	webmentionEnabled := t.OptionService.GetOrByDefault(ctx, property.WebmentionEnabled)
 	urlContext := "/"
 	if globalAbsolutePathEnabled.(bool) {
 		urlContext = blogBaseURL.(string) + "/"
 	}
+	webmentionURL := ""
+	if webmentionEnabled.(bool) {
+		webmentionURL = blogBaseURL.(string) + "/api/content/webmention"
+	}
 	t.Template.SetSharedVariable("version", consts.SonicVersion)
 	t.Template.SetSharedVariable("options", optionMap)
 	t.Template.SetSharedVariable("context", urlContext)
@@ -192,6 +197,7 @@ func (t *TemplateConfigListener) loadOption(ctx context.Context) error {
 	t.Template.SetSharedVariable("atom_url", blogBaseURL.(string)+"/atom.xml")
 	t.Template.SetSharedVariable("sitemap_xml_url", blogBaseURL.(string)+"/sitemap.xml")
 	t.Template.SetSharedVariable("sitemap_html_url", blogBaseURL.(string)+"/sitemap.html")
+	t.Template.SetSharedVariable("webmention_url", webmentionURL)
 	t.Template.SetSharedVariable("links_url", urlContext+linkPrefix.(string))
 	t.Template.SetSharedVariable("photos_url", urlContext+photoPrefix.(string))
 	t.Template.SetSharedVariable("journals_url", urlContext+journalPrefix.(string))
@@ -0,0 +1,149 @@
+package listener
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/service/assembler"
+)
+
+// WebhookListener forwards the events to the webhooks configured by the administrator
+type WebhookListener struct {
+	WebhookService    service.WebhookService
+	BasePostService   service.BasePostService
+	BasePostAssembler assembler.BasePostAssembler
+	OptionService     service.OptionService
+}
+
+type webhookPostData struct {
+	Type string           `json:"type"`
+	Post *dto.PostMinimal `json:"post"`
+}
+
+// webhookCommentData leaves out the email and IP address of the commenter
+type webhookCommentData struct {
+	ID         int32                `json:"id"`
+	Type       string               `json:"type"`
+	ContentID  int32                `json:"contentId"`
+	ParentID   int32                `json:"parentId"`
+	Author     string               `json:"author"`
+	AuthorURL  string               `json:"authorUrl"`
+	Content    string               `json:"content"`
+	Status     consts.CommentStatus `json:"status"`
+	IsAdmin    bool                 `json:"isAdmin"`
+	CreateTime int64                `json:"createTime"`
+}
+
+func NewWebhookListener(bus event.Bus,
+	webhookService service.WebhookService,
+	basePostService service.BasePostService,
+	basePostAssembler assembler.BasePostAssembler,
+	optionService service.OptionService,
+) {
+	w := &WebhookListener{
+		WebhookService:    webhookService,
+		BasePostService:   basePostService,
+		BasePostAssembler: basePostAssembler,
+		OptionService:     optionService,
+	}
+	bus.Subscribe(event.PostPublishedEventName, w.HandlePostPublished)
+	bus.Subscribe(event.PostUpdateEventName, w.HandlePostUpdate)
+	bus.Subscribe(event.PostDeleteEventName, w.HandlePostDelete)
+	bus.Subscribe(event.CommentCreatedEventName, w.HandleCommentCreated)
+	bus.Subscribe(event.CommentApprovedEventName, w.HandleCommentApproved)
+	bus.Subscribe(event.ThemeActivatedEventName, w.HandleThemeActivated)
+	bus.Subscribe(event.OptionUpdateEventName, w.HandleOptionUpdate)
+}
+
+func (w *WebhookListener) HandlePostPublished(ctx context.Context, e event.Event) error {
+	return w.triggerPost(ctx, consts.WebhookEventPostPublished, e.(*event.PostPublishedEvent).PostID)
+}
+
+func (w *WebhookListener) HandlePostUpdate(ctx context.Context, e event.Event) error {
+	return w.triggerPost(ctx, consts.WebhookEventPostUpdated, e.(*event.PostUpdateEvent).PostID)
+}
+
+func (w *WebhookListener) HandlePostDelete(ctx context.Context, e event.Event) error {
+	post := e.(*event.PostDeleteEvent).Post
+	return w.WebhookService.Trigger(ctx, consts.WebhookEventPostDeleted, &webhookPostData{
+		Type: postTypeName(post.Type),
+		Post: &dto.PostMinimal{
+			ID:         post.ID,
+			Title:      post.Title,
+			Status:     post.Status,
+			Slug:       post.Slug,
+			EditorType: post.EditorType,
+			CreateTime: post.CreateTime.UnixMilli(),
+		},
+	})
+}
+
+func (w *WebhookListener) HandleCommentCreated(ctx context.Context, e event.Event) error {
+	return w.triggerComment(ctx, consts.WebhookEventCommentCreated, e.(*event.CommentCreatedEvent).Comment)
+}
+
+func (w *WebhookListener) HandleCommentApproved(ctx context.Context, e event.Event) error {
+	return w.triggerComment(ctx, consts.WebhookEventCommentApproved, e.(*event.CommentApprovedEvent).Comment)
+}
+
+func (w *WebhookListener) HandleThemeActivated(ctx context.Context, e event.Event) error {
+	themeID, err := w.OptionService.GetActivatedThemeID(ctx)
+	if err != nil {
+		return err
+	}
+	return w.WebhookService.Trigger(ctx, consts.WebhookEventThemeActivated, map[string]string{
+		"themeId": themeID,
+	})
+}
+
+func (w *WebhookListener) HandleOptionUpdate(ctx context.Context, e event.Event) error {
+	return w.WebhookService.Trigger(ctx, consts.WebhookEventOptionUpdated, map[string]string{})
+}
+
+func (w *WebhookListener) triggerPost(ctx context.Context, webhookEvent string, postID int32) error {
+	post, err := w.BasePostService.GetByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	postDTO, err := w.BasePostAssembler.ConvertToMinimalDTO(ctx, post)
+	if err != nil {
+		return err
+	}
+	return w.WebhookService.Trigger(ctx, webhookEvent, &webhookPostData{
+		Type: postTypeName(post.Type),
+		Post: postDTO,
+	})
+}
+
+func (w *WebhookListener) triggerComment(ctx context.Context, webhookEvent string, comment *entity.Comment) error {
+	commentType := "post"
+	switch comment.Type {
+	case consts.CommentTypeSheet:
+		commentType = "sheet"
+	case consts.CommentTypeJournal:
+		commentType = "journal"
+	}
+	return w.WebhookService.Trigger(ctx, webhookEvent, &webhookCommentData{
+		ID:         comment.ID,
+		Type:       commentType,
+		ContentID:  comment.PostID,
+		ParentID:   comment.ParentID,
+		Author:     comment.Author,
+		AuthorURL:  comment.AuthorURL,
+		Content:    comment.Content,
+		Status:     comment.Status,
+		IsAdmin:    comment.IsAdmin,
+		CreateTime: comment.CreateTime.UnixMilli(),
+	})
+}
+
+func postTypeName(postType consts.PostType) string {
+	if postType == consts.PostTypeSheet {
+		return "sheet"
+	}
+	return "post"
+}
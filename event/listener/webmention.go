@@ -0,0 +1,70 @@
+package listener
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+)
+
+const (
+	webmentionWorkers   = 2
+	webmentionQueueSize = 256
+)
+
+// WebmentionListener notifies the sites linked by a post when it is published or updated
+type WebmentionListener struct {
+	WebmentionService service.WebmentionService
+	// sending holds the posts whose webmentions are queued or being sent
+	sending sync.Map
+	sender  *util.WorkerPool
+}
+
+func NewWebmentionListener(bus event.Bus, webmentionService service.WebmentionService) {
+	w := &WebmentionListener{
+		WebmentionService: webmentionService,
+		sender:            util.NewWorkerPool(webmentionWorkers, webmentionQueueSize),
+	}
+	bus.Subscribe(event.PostPublishedEventName, w.HandlePostPublished)
+	bus.Subscribe(event.PostUpdateEventName, w.HandlePostUpdate)
+}
+
+func (w *WebmentionListener) HandlePostPublished(ctx context.Context, e event.Event) error {
+	return w.send(ctx, e.(*event.PostPublishedEvent).PostID)
+}
+
+// HandlePostUpdate skips the batch changes of the categories and the tags, which do not change the links of the posts.
+func (w *WebmentionListener) HandlePostUpdate(ctx context.Context, e event.Event) error {
+	updateEvent := e.(*event.PostUpdateEvent)
+	if updateEvent.Batch {
+		return nil
+	}
+	return w.send(ctx, updateEvent.PostID)
+}
+
+// send fetches the linked pages in the background, a post queued or being sent is skipped.
+func (w *WebmentionListener) send(ctx context.Context, postID int32) error {
+	endpoint, err := w.WebmentionService.GetEndpoint(ctx)
+	if err != nil || endpoint == "" {
+		return err
+	}
+	if _, loaded := w.sending.LoadOrStore(postID, struct{}{}); loaded {
+		return nil
+	}
+	queued := w.sender.TrySubmit(func() {
+		defer w.sending.Delete(postID)
+		if err := w.WebmentionService.SendForPost(context.Background(), postID); err != nil {
+			log.Error("send webmentions err", zap.Int32("postID", postID), zap.Error(err))
+		}
+	})
+	if !queued {
+		w.sending.Delete(postID)
+		log.CtxWarn(ctx, "webmention queue is full, the webmentions are not sent", zap.Int32("postID", postID))
+	}
+	return nil
+}
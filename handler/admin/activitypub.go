@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/handler/binding"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type ActivityPubHandler struct {
+	ActivityPubService service.ActivityPubService
+}
+
+func NewActivityPubHandler(activityPubService service.ActivityPubService) *ActivityPubHandler {
+	return &ActivityPubHandler{
+		ActivityPubService: activityPubService,
+	}
+}
+
+func (a *ActivityPubHandler) ListFollowers(ctx *gin.Context) (interface{}, error) {
+	page, err := bindActivityPubPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	followers, totalCount, err := a.ActivityPubService.PageFollowers(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(a.ActivityPubService.ConvertToFollowerDTOs(followers), totalCount, page), nil
+}
+
+func (a *ActivityPubHandler) DeleteFollower(ctx *gin.Context) (interface{}, error) {
+	id, err := util.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, a.ActivityPubService.DeleteFollower(ctx, id)
+}
+
+func (a *ActivityPubHandler) ListDeliveries(ctx *gin.Context) (interface{}, error) {
+	page, err := bindActivityPubPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, totalCount, err := a.ActivityPubService.PageDeliveries(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(a.ActivityPubService.ConvertToDeliveryDTOs(deliveries), totalCount, page), nil
+}
+
+func bindActivityPubPage(ctx *gin.Context) (param.Page, error) {
+	var page param.Page
+	err := ctx.ShouldBindWith(&page, binding.CustomFormBinding)
+	if err != nil {
+		return page, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = 10
+	}
+	return page, nil
+}
@@ -126,6 +126,61 @@ func (b *BackupHandler) ImportMarkdown(ctx *gin.Context) (interface{}, error) {
 	return nil, b.BackupService.ImportMarkdown(ctx, fileHeader)
 }
 
+func (b *BackupHandler) ImportGhost(ctx *gin.Context) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithMsg(err, "上传文件错误").WithStatus(xerr.StatusBadRequest)
+	}
+	if path.Ext(fileHeader.Filename) != ".json" {
+		return nil, xerr.BadParam.New("").WithMsg("Unsupported format").WithStatus(xerr.StatusBadRequest)
+	}
+	return b.BackupService.ImportGhost(ctx, fileHeader)
+}
+
+func (b *BackupHandler) ImportHalo(ctx *gin.Context) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithMsg(err, "上传文件错误").WithStatus(xerr.StatusBadRequest)
+	}
+	if path.Ext(fileHeader.Filename) != ".json" {
+		return nil, xerr.BadParam.New("").WithMsg("Unsupported format").WithStatus(xerr.StatusBadRequest)
+	}
+	return b.BackupService.ImportHalo(ctx, fileHeader)
+}
+
+func (b *BackupHandler) ImportDisqus(ctx *gin.Context) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithMsg(err, "上传文件错误").WithStatus(xerr.StatusBadRequest)
+	}
+	if path.Ext(fileHeader.Filename) != ".xml" {
+		return nil, xerr.BadParam.New("").WithMsg("Unsupported format").WithStatus(xerr.StatusBadRequest)
+	}
+	return b.BackupService.ImportDisqus(ctx, fileHeader)
+}
+
+func (b *BackupHandler) ImportTwikoo(ctx *gin.Context) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithMsg(err, "上传文件错误").WithStatus(xerr.StatusBadRequest)
+	}
+	if path.Ext(fileHeader.Filename) != ".json" {
+		return nil, xerr.BadParam.New("").WithMsg("Unsupported format").WithStatus(xerr.StatusBadRequest)
+	}
+	return b.BackupService.ImportTwikoo(ctx, fileHeader)
+}
+
+func (b *BackupHandler) ImportWaline(ctx *gin.Context) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithMsg(err, "上传文件错误").WithStatus(xerr.StatusBadRequest)
+	}
+	if path.Ext(fileHeader.Filename) != ".json" {
+		return nil, xerr.BadParam.New("").WithMsg("Unsupported format").WithStatus(xerr.StatusBadRequest)
+	}
+	return b.BackupService.ImportWaline(ctx, fileHeader)
+}
+
 func (b *BackupHandler) ExportData(ctx *gin.Context) (interface{}, error) {
 	return b.BackupService.ExportData(ctx)
 }
@@ -215,6 +270,94 @@ func (b *BackupHandler) DownloadMarkdown(ctx *gin.Context) {
 	ctx.File(filePath)
 }
 
+func (b *BackupHandler) ExportEpub(ctx *gin.Context) (interface{}, error) {
+	var exportEpubParam param.ExportEpub
+	err := ctx.ShouldBindJSON(&exportEpubParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest)
+	}
+	return b.BackupService.ExportEpub(ctx, &exportEpubParam)
+}
+
+func (b *BackupHandler) ListEpubs(ctx *gin.Context) (interface{}, error) {
+	return b.BackupService.ListFiles(ctx, config.BackupEpubDir, service.Epub)
+}
+
+func (b *BackupHandler) DeleteEpubs(ctx *gin.Context) (interface{}, error) {
+	filename, err := util.MustGetQueryString(ctx, "filename")
+	if err != nil {
+		return nil, err
+	}
+	return nil, b.BackupService.DeleteFile(ctx, config.BackupEpubDir, filename)
+}
+
+func (b *BackupHandler) DownloadEpub(ctx *gin.Context) {
+	filename := ctx.Param("filename")
+	if filename == "" {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, &dto.BaseDTO{
+			Status:  http.StatusBadRequest,
+			Message: "Filename parameter does not exist",
+		})
+		return
+	}
+	filePath, err := b.BackupService.GetBackupFilePath(ctx, config.BackupEpubDir, filename)
+	if err != nil {
+		log.CtxErrorf(ctx, "err=%+v", err)
+		status := xerr.GetHTTPStatus(err)
+		ctx.JSON(status, &dto.BaseDTO{Status: status, Message: xerr.GetMessage(err)})
+		return
+	}
+	ctx.File(filePath)
+}
+
+func (b *BackupHandler) ExportComments(ctx *gin.Context) (interface{}, error) {
+	var exportCommentParam param.ExportComment
+	err := ctx.ShouldBindJSON(&exportCommentParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest)
+	}
+	return b.BackupService.ExportComments(ctx, &exportCommentParam)
+}
+
+func (b *BackupHandler) ListCommentExports(ctx *gin.Context) (interface{}, error) {
+	return b.BackupService.ListFiles(ctx, config.BackupCommentDir, service.Comment)
+}
+
+func (b *BackupHandler) DeleteCommentExports(ctx *gin.Context) (interface{}, error) {
+	filename, err := util.MustGetQueryString(ctx, "filename")
+	if err != nil {
+		return nil, err
+	}
+	return nil, b.BackupService.DeleteFile(ctx, config.BackupCommentDir, filename)
+}
+
+func (b *BackupHandler) DownloadCommentExport(ctx *gin.Context) {
+	filename := ctx.Param("filename")
+	if filename == "" {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, &dto.BaseDTO{
+			Status:  http.StatusBadRequest,
+			Message: "Filename parameter does not exist",
+		})
+		return
+	}
+	filePath, err := b.BackupService.GetBackupFilePath(ctx, config.BackupCommentDir, filename)
+	if err != nil {
+		log.CtxErrorf(ctx, "err=%+v", err)
+		status := xerr.GetHTTPStatus(err)
+		ctx.JSON(status, &dto.BaseDTO{Status: status, Message: xerr.GetMessage(err)})
+		return
+	}
+	ctx.FileAttachment(filePath, filename)
+}
+
 type wrapperHandler func(ctx *gin.Context) (interface{}, error)
 
 func wrapHandler(handler wrapperHandler) gin.HandlerFunc {
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/go-sonic/sonic/handler/binding"
+	"github.com/go-sonic/sonic/handler/trans"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type CommentBlackHandler struct {
+	CommentBlackService service.CommentBlackService
+}
+
+func NewCommentBlackHandler(commentBlackService service.CommentBlackService) *CommentBlackHandler {
+	return &CommentBlackHandler{
+		CommentBlackService: commentBlackService,
+	}
+}
+
+func (c *CommentBlackHandler) ListCommentBlack(ctx *gin.Context) (interface{}, error) {
+	var page param.Page
+	err := ctx.ShouldBindWith(&page, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = 10
+	}
+	commentBlacks, totalCount, err := c.CommentBlackService.Page(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(c.CommentBlackService.ConvertToDTOs(commentBlacks), totalCount, page), nil
+}
+
+func (c *CommentBlackHandler) CreateCommentBlack(ctx *gin.Context) (interface{}, error) {
+	commentBlackParam := &param.CommentBlack{}
+	err := ctx.ShouldBindJSON(commentBlackParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	commentBlack, err := c.CommentBlackService.Create(ctx, commentBlackParam)
+	if err != nil {
+		return nil, err
+	}
+	return c.CommentBlackService.ConvertToDTO(commentBlack), nil
+}
+
+func (c *CommentBlackHandler) UpdateCommentBlack(ctx *gin.Context) (interface{}, error) {
+	id, err := util.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	commentBlackParam := &param.CommentBlack{}
+	err = ctx.ShouldBindJSON(commentBlackParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	commentBlack, err := c.CommentBlackService.Update(ctx, id, commentBlackParam)
+	if err != nil {
+		return nil, err
+	}
+	return c.CommentBlackService.ConvertToDTO(commentBlack), nil
+}
+
+func (c *CommentBlackHandler) DeleteCommentBlack(ctx *gin.Context) (interface{}, error) {
+	id, err := util.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, c.CommentBlackService.Delete(ctx, id)
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+)
+
+// CommentRevisionHandler shows the previous contents of the comments edited by their authors.
+type CommentRevisionHandler struct {
+	CommentRevisionService service.CommentRevisionService
+}
+
+func NewCommentRevisionHandler(commentRevisionService service.CommentRevisionService) *CommentRevisionHandler {
+	return &CommentRevisionHandler{
+		CommentRevisionService: commentRevisionService,
+	}
+}
+
+func (c *CommentRevisionHandler) ListCommentRevisions(ctx *gin.Context) (interface{}, error) {
+	commentID, err := util.ParamInt32(ctx, "commentID")
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := c.CommentRevisionService.ListByCommentID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return c.CommentRevisionService.ConvertToDTOs(revisions), nil
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/handler/binding"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/service/assembler"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// CommentSpamHandler is the queue of the comments of posts, sheets and journals regarded as spam.
+type CommentSpamHandler struct {
+	BaseCommentService   service.BaseCommentService
+	BaseCommentAssembler assembler.BaseCommentAssembler
+}
+
+func NewCommentSpamHandler(baseCommentService service.BaseCommentService, baseCommentAssembler assembler.BaseCommentAssembler) *CommentSpamHandler {
+	return &CommentSpamHandler{
+		BaseCommentService:   baseCommentService,
+		BaseCommentAssembler: baseCommentAssembler,
+	}
+}
+
+func (c *CommentSpamHandler) ListSpamComment(ctx *gin.Context) (interface{}, error) {
+	var page param.Page
+	err := ctx.ShouldBindWith(&page, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = 10
+	}
+	comments, totalCount, err := c.BaseCommentService.PageByStatus(ctx, consts.CommentStatusSpam, page)
+	if err != nil {
+		return nil, err
+	}
+	commentDTOs, err := c.BaseCommentAssembler.ConvertToDTOList(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(commentDTOs, totalCount, page), nil
+}
+
+// ApproveSpamComment publishes the comments which are not spam, the classifier learns from them.
+func (c *CommentSpamHandler) ApproveSpamComment(ctx *gin.Context) (interface{}, error) {
+	ids := make([]int32, 0)
+	err := ctx.ShouldBindJSON(&ids)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("comment ids error")
+	}
+	if err = c.checkSpam(ctx, ids); err != nil {
+		return nil, err
+	}
+	comments, err := c.BaseCommentService.UpdateStatusBatch(ctx, ids, consts.CommentStatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	return c.BaseCommentAssembler.ConvertToDTOList(ctx, comments)
+}
+
+// DeleteSpamComment deletes the spam comments, the classifier learns from them.
+func (c *CommentSpamHandler) DeleteSpamComment(ctx *gin.Context) (interface{}, error) {
+	ids := make([]int32, 0)
+	err := ctx.ShouldBindJSON(&ids)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("comment ids error")
+	}
+	if err = c.checkSpam(ctx, ids); err != nil {
+		return nil, err
+	}
+	return nil, c.BaseCommentService.DeleteBatch(ctx, ids)
+}
+
+// checkSpam rejects the ids of the comments that are not in the spam queue.
+func (c *CommentSpamHandler) checkSpam(ctx *gin.Context, ids []int32) error {
+	comments, err := c.BaseCommentService.LGetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	spamIDs := make(map[int32]struct{}, len(comments))
+	for _, comment := range comments {
+		if comment.Status == consts.CommentStatusSpam {
+			spamIDs[comment.ID] = struct{}{}
+		}
+	}
+	for _, id := range ids {
+		if _, ok := spamIDs[id]; !ok {
+			return xerr.WithStatus(nil, xerr.StatusBadRequest).WithMsg("Only the comments in the spam queue can be approved or deleted here")
+		}
+	}
+	return nil
+}
@@ -4,14 +4,19 @@ import "github.com/go-sonic/sonic/injection"
 
 func init() {
 	injection.Provide(
+		NewActivityPubHandler,
 		NewAdminHandler,
 		NewAttachmentHandler,
 		NewCategoryHandler,
+		NewCommentBlackHandler,
+		NewCommentSpamHandler,
+		NewCommentRevisionHandler,
 		NewBackupHandler,
 		NewInstallHandler,
 		NewJournalHandler,
 		NewJournalCommentHandler,
 		NewLinkHandler,
+		NewLinkCheckHandler,
 		NewLogHandler,
 		NewMenuHandler,
 		NewOptionHandler,
@@ -25,5 +30,6 @@ func init() {
 		NewThemeHandler,
 		NewUserHandler,
 		NewEmailHandler,
+		NewWebhookHandler,
 	)
 }
@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/service"
+)
+
+type LinkCheckHandler struct {
+	LinkCheckService service.LinkCheckService
+}
+
+func NewLinkCheckHandler(linkCheckService service.LinkCheckService) *LinkCheckHandler {
+	return &LinkCheckHandler{
+		LinkCheckService: linkCheckService,
+	}
+}
+
+func (l *LinkCheckHandler) StartLinkCheck(ctx *gin.Context) (interface{}, error) {
+	err := l.LinkCheckService.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return l.LinkCheckService.GetReport(ctx), nil
+}
+
+func (l *LinkCheckHandler) GetLinkCheckReport(ctx *gin.Context) (interface{}, error) {
+	return l.LinkCheckService.GetReport(ctx), nil
+}
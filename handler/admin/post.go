@@ -238,6 +238,19 @@ func (p *PostHandler) UpdatePostStatusBatch(ctx *gin.Context) (interface{}, erro
 	return p.PostService.UpdateStatusBatch(ctx, status, ids)
 }
 
+func (p *PostHandler) UpdatePostBatch(ctx *gin.Context) (interface{}, error) {
+	var batchParam param.PostBatchUpdate
+	err := ctx.ShouldBindJSON(&batchParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	return p.PostService.UpdateBatch(ctx, &batchParam)
+}
+
 func (p *PostHandler) UpdatePostDraft(ctx *gin.Context) (interface{}, error) {
 	postID, err := util.ParamInt32(ctx, "postID")
 	if err != nil {
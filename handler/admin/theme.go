@@ -133,6 +133,22 @@ func (t *ThemeHandler) ListCustomPostTemplate(ctx *gin.Context) (interface{}, er
 	return t.ThemeService.ListCustomTemplates(ctx, activatedThemeID, consts.ThemeCustomPostPrefix)
 }
 
+func (t *ThemeHandler) ListPostMetaFields(ctx *gin.Context) (interface{}, error) {
+	activatedThemeID, err := t.OptionService.GetActivatedThemeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.ThemeService.ListMetaFields(ctx, activatedThemeID, consts.PostTypePost)
+}
+
+func (t *ThemeHandler) ListSheetMetaFields(ctx *gin.Context) (interface{}, error) {
+	activatedThemeID, err := t.OptionService.GetActivatedThemeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.ThemeService.ListMetaFields(ctx, activatedThemeID, consts.PostTypeSheet)
+}
+
 func (t *ThemeHandler) ActivateTheme(ctx *gin.Context) (interface{}, error) {
 	themeID, err := util.ParamString(ctx, "themeID")
 	if err != nil {
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/handler/binding"
+	"github.com/go-sonic/sonic/handler/trans"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type WebhookHandler struct {
+	WebhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		WebhookService: webhookService,
+	}
+}
+
+func (w *WebhookHandler) ListWebhook(ctx *gin.Context) (interface{}, error) {
+	webhooks, err := w.WebhookService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return w.WebhookService.ConvertToDTOs(webhooks), nil
+}
+
+func (w *WebhookHandler) ListWebhookEvent(ctx *gin.Context) (interface{}, error) {
+	return consts.WebhookEvents, nil
+}
+
+func (w *WebhookHandler) CreateWebhook(ctx *gin.Context) (interface{}, error) {
+	webhookParam := &param.Webhook{}
+	err := ctx.ShouldBindJSON(webhookParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	webhook, err := w.WebhookService.Create(ctx, webhookParam)
+	if err != nil {
+		return nil, err
+	}
+	return w.WebhookService.ConvertToDTO(webhook), nil
+}
+
+func (w *WebhookHandler) UpdateWebhook(ctx *gin.Context) (interface{}, error) {
+	id, err := util.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	webhookParam := &param.Webhook{}
+	err = ctx.ShouldBindJSON(webhookParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	webhook, err := w.WebhookService.Update(ctx, id, webhookParam)
+	if err != nil {
+		return nil, err
+	}
+	return w.WebhookService.ConvertToDTO(webhook), nil
+}
+
+func (w *WebhookHandler) DeleteWebhook(ctx *gin.Context) (interface{}, error) {
+	id, err := util.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, w.WebhookService.Delete(ctx, id)
+}
+
+func (w *WebhookHandler) ListWebhookDelivery(ctx *gin.Context) (interface{}, error) {
+	id, err := util.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	var page param.Page
+	err = ctx.ShouldBindWith(&page, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = 10
+	}
+	deliveries, totalCount, err := w.WebhookService.PageDeliveries(ctx, id, page)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(w.WebhookService.ConvertToDeliveryDTOs(deliveries), totalCount, page), nil
+}
+
+func (w *WebhookHandler) RedeliverWebhookDelivery(ctx *gin.Context) (interface{}, error) {
+	deliveryID, err := util.ParamInt32(ctx, "deliveryID")
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := w.WebhookService.Redeliver(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return w.WebhookService.ConvertToDeliveryDTOs([]*entity.WebhookDelivery{delivery})[0], nil
+}
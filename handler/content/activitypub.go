@@ -0,0 +1,75 @@
+package content
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// activityPubMaxBody is the max size of the activities received by the inbox
+const activityPubMaxBody = 1 << 20
+
+type ActivityPubHandler struct {
+	ActivityPubService service.ActivityPubService
+}
+
+func NewActivityPubHandler(activityPubService service.ActivityPubService) *ActivityPubHandler {
+	return &ActivityPubHandler{
+		ActivityPubService: activityPubService,
+	}
+}
+
+func (a *ActivityPubHandler) WebFinger(ctx *gin.Context) (interface{}, error) {
+	resource := ctx.Query("resource")
+	if resource == "" {
+		return nil, xerr.BadParam.New("").WithMsg("resource is required").WithStatus(xerr.StatusBadRequest)
+	}
+	ctx.Header("Content-Type", "application/jrd+json; charset=utf-8")
+	return a.ActivityPubService.WebFinger(ctx, resource)
+}
+
+func (a *ActivityPubHandler) Actor(ctx *gin.Context) (interface{}, error) {
+	return a.ActivityPubService.GetActor(ctx)
+}
+
+func (a *ActivityPubHandler) Outbox(ctx *gin.Context) (interface{}, error) {
+	page := 0
+	if rawPage := ctx.Query("page"); rawPage != "" {
+		var err error
+		page, err = strconv.Atoi(rawPage)
+		if err != nil || page < 1 {
+			return nil, xerr.BadParam.New("page=%s", rawPage).WithMsg("invalid page").WithStatus(xerr.StatusBadRequest)
+		}
+	}
+	return a.ActivityPubService.GetOutbox(ctx, page)
+}
+
+func (a *ActivityPubHandler) Followers(ctx *gin.Context) (interface{}, error) {
+	return a.ActivityPubService.GetFollowers(ctx)
+}
+
+func (a *ActivityPubHandler) Following(ctx *gin.Context) (interface{}, error) {
+	return a.ActivityPubService.GetFollowing(ctx)
+}
+
+func (a *ActivityPubHandler) Article(ctx *gin.Context) (interface{}, error) {
+	postID, err := util.ParamInt32(ctx, "postID")
+	if err != nil {
+		return nil, err
+	}
+	return a.ActivityPubService.GetArticle(ctx, postID)
+}
+
+// Inbox handles the activity and accepts it without a document in the response.
+func (a *ActivityPubHandler) Inbox(ctx *gin.Context) (interface{}, error) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, activityPubMaxBody))
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("invalid body")
+	}
+	return nil, a.ActivityPubService.HandleInbox(ctx, ctx.Request, body)
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/service"
+)
+
+type CaptchaHandler struct {
+	CaptchaService service.CaptchaService
+}
+
+func NewCaptchaHandler(captchaService service.CaptchaService) *CaptchaHandler {
+	return &CaptchaHandler{
+		CaptchaService: captchaService,
+	}
+}
+
+func (c *CaptchaHandler) Generate(ctx *gin.Context) (interface{}, error) {
+	return c.CaptchaService.Generate(ctx)
+}
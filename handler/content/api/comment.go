@@ -1,26 +1,78 @@
 package api
 
 import (
+	"errors"
+	"html/template"
+
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/handler/trans"
+	"github.com/go-sonic/sonic/model/param"
 	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/service/assembler"
 	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
 )
 
 type CommentHandler struct {
-	BaseCommentService service.BaseCommentService
+	BaseCommentService   service.BaseCommentService
+	BaseCommentAssembler assembler.BaseCommentAssembler
+	ReactionService      service.ReactionService
 }
 
-func NewCommentHandler(baseCommentService service.BaseCommentService) *CommentHandler {
+func NewCommentHandler(baseCommentService service.BaseCommentService, baseCommentAssembler assembler.BaseCommentAssembler, reactionService service.ReactionService) *CommentHandler {
 	return &CommentHandler{
-		BaseCommentService: baseCommentService,
+		BaseCommentService:   baseCommentService,
+		BaseCommentAssembler: baseCommentAssembler,
+		ReactionService:      reactionService,
 	}
 }
 
 func (c *CommentHandler) Like(ctx *gin.Context) (interface{}, error) {
+	return nil, like(ctx, c.ReactionService, consts.ReactionTargetComment, "commentID")
+}
+
+func (c *CommentHandler) ListReactions(ctx *gin.Context) (interface{}, error) {
+	return listReactions(ctx, c.ReactionService, consts.ReactionTargetComment, "commentID")
+}
+
+func (c *CommentHandler) React(ctx *gin.Context) (interface{}, error) {
+	return toggleReaction(ctx, c.ReactionService, consts.ReactionTargetComment, "commentID")
+}
+
+func (c *CommentHandler) UpdateByAuthor(ctx *gin.Context) (interface{}, error) {
+	commentID, err := util.ParamInt32(ctx, "commentID")
+	if err != nil {
+		return nil, err
+	}
+	var editParam param.CommentAuthorEdit
+	err = ctx.ShouldBindJSON(&editParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(trans.Translate(e))
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest)
+	}
+	editParam.Content = template.HTMLEscapeString(editParam.Content)
+	comment, err := c.BaseCommentService.UpdateByAuthor(ctx, commentID, &editParam)
+	if err != nil {
+		return nil, err
+	}
+	return c.BaseCommentAssembler.ConvertToDTO(ctx, comment)
+}
+
+func (c *CommentHandler) DeleteByAuthor(ctx *gin.Context) (interface{}, error) {
 	commentID, err := util.ParamInt32(ctx, "commentID")
 	if err != nil {
 		return nil, err
 	}
-	return nil, c.BaseCommentService.IncreaseLike(ctx, commentID)
+	var deleteParam param.CommentAuthorDelete
+	err = ctx.ShouldBindJSON(&deleteParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	return nil, c.BaseCommentService.DeleteByAuthor(ctx, commentID, deleteParam.Token)
 }
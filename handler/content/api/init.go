@@ -13,5 +13,7 @@ func init() {
 		NewOptionHandler,
 		NewPhotoHandler,
 		NewCommentHandler,
+		NewCaptchaHandler,
+		NewWebmentionHandler,
 	)
 }
@@ -22,6 +22,7 @@ type JournalHandler struct {
 	JournalCommentService   service.JournalCommentService
 	OptionService           service.ClientOptionService
 	JournalCommentAssembler assembler.JournalCommentAssembler
+	ReactionService         service.ReactionService
 }
 
 func NewJournalHandler(
@@ -29,12 +30,14 @@ func NewJournalHandler(
 	journalCommentService service.JournalCommentService,
 	optionService service.ClientOptionService,
 	journalCommentAssembler assembler.JournalCommentAssembler,
+	reactionService service.ReactionService,
 ) *JournalHandler {
 	return &JournalHandler{
 		JournalService:          journalService,
 		JournalCommentService:   journalCommentService,
 		OptionService:           optionService,
 		JournalCommentAssembler: journalCommentAssembler,
+		ReactionService:         reactionService,
 	}
 }
 
@@ -153,18 +156,56 @@ func (j *JournalHandler) ListCommentTree(ctx *gin.Context) (interface{}, error)
 	commentQuery.PageSize = pageSize
 	commentQuery.ParentID = util.Int32Ptr(0)
 
-	allComments, err := j.JournalCommentService.GetByContentID(ctx, journalID, consts.CommentTypeJournal, commentQuery.Sort)
+	allComments, err := j.JournalCommentService.GetByContentID(ctx, journalID, consts.CommentTypeJournal, commentQuery.Sort, commentQuery.CommentStatus)
 	if err != nil {
 		return nil, err
 	}
 	_ = j.JournalCommentAssembler.ClearSensitiveField(ctx, allComments)
-	commentVOs, total, err := j.JournalCommentAssembler.PageConvertToVOs(ctx, allComments, commentQuery.Page)
+	maxDepth := j.OptionService.GetOrByDefault(ctx, property.CommentMaxDepth).(int)
+	commentVOs, total, err := j.JournalCommentAssembler.PageConvertToVOs(ctx, allComments, commentQuery.Page, maxDepth)
 	if err != nil {
 		return nil, err
 	}
 	return dto.NewPage(commentVOs, total, commentQuery.Page), nil
 }
 
+func (j *JournalHandler) ListCommentByCursor(ctx *gin.Context) (interface{}, error) {
+	journalID, err := util.ParamInt32(ctx, "journalID")
+	if err != nil {
+		return nil, err
+	}
+	return j.listCommentByCursor(ctx, journalID, 0)
+}
+
+func (j *JournalHandler) ListChildrenByCursor(ctx *gin.Context) (interface{}, error) {
+	journalID, err := util.ParamInt32(ctx, "journalID")
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := util.ParamInt32(ctx, "parentID")
+	if err != nil {
+		return nil, err
+	}
+	return j.listCommentByCursor(ctx, journalID, parentID)
+}
+
+func (j *JournalHandler) listCommentByCursor(ctx *gin.Context, journalID, parentID int32) (interface{}, error) {
+	cursorQuery := param.CommentCursorQuery{}
+	err := ctx.ShouldBindWith(&cursorQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	cursorQuery.ContentID = journalID
+	cursorQuery.ParentID = parentID
+
+	comments, nextCursor, lastLevel, err := j.JournalCommentService.PageByCursor(ctx, &cursorQuery, consts.CommentTypeJournal)
+	if err != nil {
+		return nil, err
+	}
+	_ = j.JournalCommentAssembler.ClearSensitiveField(ctx, comments)
+	return j.JournalCommentAssembler.ConvertToCursorPage(ctx, comments, nextCursor, lastLevel)
+}
+
 func (j *JournalHandler) ListComment(ctx *gin.Context) (interface{}, error) {
 	journalID, err := util.ParamInt32(ctx, "journalID")
 	if err != nil {
@@ -221,17 +262,25 @@ func (j *JournalHandler) CreateComment(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return j.JournalCommentAssembler.ConvertToDTO(ctx, result)
-}
-
-func (j *JournalHandler) Like(ctx *gin.Context) (interface{}, error) {
-	journalID, err := util.ParamInt32(ctx, "journalID")
+	commentDTO, err := j.JournalCommentAssembler.ConvertToDTO(ctx, result)
 	if err != nil {
 		return nil, err
 	}
-	err = j.JournalService.IncreaseLike(ctx, journalID)
+	commentDTO.EditToken, err = j.JournalCommentService.BuildEditToken(ctx, result)
 	if err != nil {
 		return nil, err
 	}
-	return nil, err
+	return commentDTO, nil
+}
+
+func (j *JournalHandler) Like(ctx *gin.Context) (interface{}, error) {
+	return nil, like(ctx, j.ReactionService, consts.ReactionTargetJournal, "journalID")
+}
+
+func (j *JournalHandler) ListReactions(ctx *gin.Context) (interface{}, error) {
+	return listReactions(ctx, j.ReactionService, consts.ReactionTargetJournal, "journalID")
+}
+
+func (j *JournalHandler) React(ctx *gin.Context) (interface{}, error) {
+	return toggleReaction(ctx, j.ReactionService, consts.ReactionTargetJournal, "journalID")
 }
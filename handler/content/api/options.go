@@ -25,5 +25,6 @@ func (o *OptionHandler) Comment(ctx *gin.Context) (interface{}, error) {
 	result[property.CommentGravatarSource.KeyValue] = o.OptionService.GetOrByDefault(ctx, property.CommentGravatarSource)
 	result[property.CommentGravatarDefault.KeyValue] = o.OptionService.GetOrByDefault(ctx, property.CommentGravatarDefault)
 	result[property.CommentContentPlaceholder.KeyValue] = o.OptionService.GetOrByDefault(ctx, property.CommentContentPlaceholder)
+	result[property.CaptchaCommentEnabled.KeyValue] = o.OptionService.GetOrByDefault(ctx, property.CaptchaCommentEnabled)
 	return result, nil
 }
@@ -3,24 +3,30 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/service"
-	"github.com/go-sonic/sonic/util"
 )
 
 type PhotoHandler struct {
-	PhotoService service.PhotoService
+	PhotoService    service.PhotoService
+	ReactionService service.ReactionService
 }
 
-func NewPhotoHandler(photoService service.PhotoService) *PhotoHandler {
+func NewPhotoHandler(photoService service.PhotoService, reactionService service.ReactionService) *PhotoHandler {
 	return &PhotoHandler{
-		PhotoService: photoService,
+		PhotoService:    photoService,
+		ReactionService: reactionService,
 	}
 }
 
 func (p *PhotoHandler) Like(ctx *gin.Context) (interface{}, error) {
-	id, err := util.ParamInt32(ctx, "photoID")
-	if err != nil {
-		return nil, err
-	}
-	return nil, p.PhotoService.IncreaseLike(ctx, id)
+	return nil, like(ctx, p.ReactionService, consts.ReactionTargetPhoto, "photoID")
+}
+
+func (p *PhotoHandler) ListReactions(ctx *gin.Context) (interface{}, error) {
+	return listReactions(ctx, p.ReactionService, consts.ReactionTargetPhoto, "photoID")
+}
+
+func (p *PhotoHandler) React(ctx *gin.Context) (interface{}, error) {
+	return toggleReaction(ctx, p.ReactionService, consts.ReactionTargetPhoto, "photoID")
 }
@@ -21,6 +21,7 @@ type PostHandler struct {
 	PostService          service.PostService
 	PostCommentService   service.PostCommentService
 	PostCommentAssembler assembler.PostCommentAssembler
+	ReactionService      service.ReactionService
 }
 
 func NewPostHandler(
@@ -28,12 +29,14 @@ func NewPostHandler(
 	postService service.PostService,
 	postCommentService service.PostCommentService,
 	postCommentAssembler assembler.PostCommentAssembler,
+	reactionService service.ReactionService,
 ) *PostHandler {
 	return &PostHandler{
 		OptionService:        optionService,
 		PostService:          postService,
 		PostCommentService:   postCommentService,
 		PostCommentAssembler: postCommentAssembler,
+		ReactionService:      reactionService,
 	}
 }
 
@@ -112,18 +115,56 @@ func (p *PostHandler) ListCommentTree(ctx *gin.Context) (interface{}, error) {
 	commentQuery.PageSize = pageSize
 	commentQuery.ParentID = util.Int32Ptr(0)
 
-	allComments, err := p.PostCommentService.GetByContentID(ctx, postID, consts.CommentTypePost, commentQuery.Sort)
+	allComments, err := p.PostCommentService.GetByContentID(ctx, postID, consts.CommentTypePost, commentQuery.Sort, commentQuery.CommentStatus)
 	if err != nil {
 		return nil, err
 	}
 	_ = p.PostCommentAssembler.ClearSensitiveField(ctx, allComments)
-	commentVOs, total, err := p.PostCommentAssembler.PageConvertToVOs(ctx, allComments, commentQuery.Page)
+	maxDepth := p.OptionService.GetOrByDefault(ctx, property.CommentMaxDepth).(int)
+	commentVOs, total, err := p.PostCommentAssembler.PageConvertToVOs(ctx, allComments, commentQuery.Page, maxDepth)
 	if err != nil {
 		return nil, err
 	}
 	return dto.NewPage(commentVOs, total, commentQuery.Page), nil
 }
 
+func (p *PostHandler) ListCommentByCursor(ctx *gin.Context) (interface{}, error) {
+	postID, err := util.ParamInt32(ctx, "postID")
+	if err != nil {
+		return nil, err
+	}
+	return p.listCommentByCursor(ctx, postID, 0)
+}
+
+func (p *PostHandler) ListChildrenByCursor(ctx *gin.Context) (interface{}, error) {
+	postID, err := util.ParamInt32(ctx, "postID")
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := util.ParamInt32(ctx, "parentID")
+	if err != nil {
+		return nil, err
+	}
+	return p.listCommentByCursor(ctx, postID, parentID)
+}
+
+func (p *PostHandler) listCommentByCursor(ctx *gin.Context, postID, parentID int32) (interface{}, error) {
+	cursorQuery := param.CommentCursorQuery{}
+	err := ctx.ShouldBindWith(&cursorQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	cursorQuery.ContentID = postID
+	cursorQuery.ParentID = parentID
+
+	comments, nextCursor, lastLevel, err := p.PostCommentService.PageByCursor(ctx, &cursorQuery, consts.CommentTypePost)
+	if err != nil {
+		return nil, err
+	}
+	_ = p.PostCommentAssembler.ClearSensitiveField(ctx, comments)
+	return p.PostCommentAssembler.ConvertToCursorPage(ctx, comments, nextCursor, lastLevel)
+}
+
 func (p *PostHandler) ListComment(ctx *gin.Context) (interface{}, error) {
 	postID, err := util.ParamInt32(ctx, "postID")
 	if err != nil {
@@ -180,13 +221,25 @@ func (p *PostHandler) CreateComment(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return p.PostCommentAssembler.ConvertToDTO(ctx, result)
-}
-
-func (p *PostHandler) Like(ctx *gin.Context) (interface{}, error) {
-	postID, err := util.ParamInt32(ctx, "postID")
+	commentDTO, err := p.PostCommentAssembler.ConvertToDTO(ctx, result)
 	if err != nil {
 		return nil, err
 	}
-	return nil, p.PostService.IncreaseLike(ctx, postID)
+	commentDTO.EditToken, err = p.PostCommentService.BuildEditToken(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return commentDTO, nil
+}
+
+func (p *PostHandler) Like(ctx *gin.Context) (interface{}, error) {
+	return nil, like(ctx, p.ReactionService, consts.ReactionTargetPost, "postID")
+}
+
+func (p *PostHandler) ListReactions(ctx *gin.Context) (interface{}, error) {
+	return listReactions(ctx, p.ReactionService, consts.ReactionTargetPost, "postID")
+}
+
+func (p *PostHandler) React(ctx *gin.Context) (interface{}, error) {
+	return toggleReaction(ctx, p.ReactionService, consts.ReactionTargetPost, "postID")
 }
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/vo"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	visitorCookieName   = "sonic_visitor"
+	visitorCookieMaxAge = 365 * 24 * 3600
+)
+
+// visitorFingerprint identifies the visitor by the visitor cookie together with the IP, the cookie is issued on the first reaction.
+// A visitor without the cookie is identified by the IP only, so the requests never sending the cookie back cannot react more than once.
+func visitorFingerprint(ctx *gin.Context) string {
+	visitorID, err := ctx.Cookie(visitorCookieName)
+	if err != nil || len(visitorID) != 32 {
+		ctx.SetCookie(visitorCookieName, util.GenUUIDWithOutDash(), visitorCookieMaxAge, "/", "", false, true)
+		visitorID = ""
+	}
+	sum := sha256.Sum256([]byte(visitorID + "|" + util.GetClientIP(ctx)))
+	return hex.EncodeToString(sum[:])
+}
+
+func listReactions(ctx *gin.Context, reactionService service.ReactionService, targetType consts.ReactionTarget, idParam string) (*vo.ReactionSummary, error) {
+	targetID, err := util.ParamInt32(ctx, idParam)
+	if err != nil {
+		return nil, err
+	}
+	return reactionService.GetSummary(ctx, targetType, targetID, visitorFingerprint(ctx))
+}
+
+func toggleReaction(ctx *gin.Context, reactionService service.ReactionService, targetType consts.ReactionTarget, idParam string) (*vo.ReactionSummary, error) {
+	targetID, err := util.ParamInt32(ctx, idParam)
+	if err != nil {
+		return nil, err
+	}
+	var reactionParam param.Reaction
+	err = ctx.ShouldBindJSON(&reactionParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	return reactionService.Toggle(ctx, targetType, targetID, visitorFingerprint(ctx), reactionParam.Reaction)
+}
+
+func like(ctx *gin.Context, reactionService service.ReactionService, targetType consts.ReactionTarget, idParam string) error {
+	targetID, err := util.ParamInt32(ctx, idParam)
+	if err != nil {
+		return err
+	}
+	return reactionService.Like(ctx, targetType, targetID, visitorFingerprint(ctx))
+}
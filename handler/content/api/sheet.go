@@ -112,18 +112,56 @@ func (s *SheetHandler) ListCommentTree(ctx *gin.Context) (interface{}, error) {
 	commentQuery.PageSize = pageSize
 	commentQuery.ParentID = util.Int32Ptr(0)
 
-	allComments, err := s.SheetCommentService.GetByContentID(ctx, sheetID, consts.CommentTypeSheet, commentQuery.Sort)
+	allComments, err := s.SheetCommentService.GetByContentID(ctx, sheetID, consts.CommentTypeSheet, commentQuery.Sort, commentQuery.CommentStatus)
 	if err != nil {
 		return nil, err
 	}
 	_ = s.SheetCommentAssembler.ClearSensitiveField(ctx, allComments)
-	commentVOs, total, err := s.SheetCommentAssembler.PageConvertToVOs(ctx, allComments, commentQuery.Page)
+	maxDepth := s.OptionService.GetOrByDefault(ctx, property.CommentMaxDepth).(int)
+	commentVOs, total, err := s.SheetCommentAssembler.PageConvertToVOs(ctx, allComments, commentQuery.Page, maxDepth)
 	if err != nil {
 		return nil, err
 	}
 	return dto.NewPage(commentVOs, total, commentQuery.Page), nil
 }
 
+func (s *SheetHandler) ListCommentByCursor(ctx *gin.Context) (interface{}, error) {
+	sheetID, err := util.ParamInt32(ctx, "sheetID")
+	if err != nil {
+		return nil, err
+	}
+	return s.listCommentByCursor(ctx, sheetID, 0)
+}
+
+func (s *SheetHandler) ListChildrenByCursor(ctx *gin.Context) (interface{}, error) {
+	sheetID, err := util.ParamInt32(ctx, "sheetID")
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := util.ParamInt32(ctx, "parentID")
+	if err != nil {
+		return nil, err
+	}
+	return s.listCommentByCursor(ctx, sheetID, parentID)
+}
+
+func (s *SheetHandler) listCommentByCursor(ctx *gin.Context, sheetID, parentID int32) (interface{}, error) {
+	cursorQuery := param.CommentCursorQuery{}
+	err := ctx.ShouldBindWith(&cursorQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	cursorQuery.ContentID = sheetID
+	cursorQuery.ParentID = parentID
+
+	comments, nextCursor, lastLevel, err := s.SheetCommentService.PageByCursor(ctx, &cursorQuery, consts.CommentTypeSheet)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.SheetCommentAssembler.ClearSensitiveField(ctx, comments)
+	return s.SheetCommentAssembler.ConvertToCursorPage(ctx, comments, nextCursor, lastLevel)
+}
+
 func (s *SheetHandler) ListComment(ctx *gin.Context) (interface{}, error) {
 	sheetID, err := util.ParamInt32(ctx, "sheetID")
 	if err != nil {
@@ -180,5 +218,13 @@ func (s *SheetHandler) CreateComment(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return s.SheetCommentAssembler.ConvertToDTO(ctx, result)
+	commentDTO, err := s.SheetCommentAssembler.ConvertToDTO(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	commentDTO.EditToken, err = s.SheetCommentService.BuildEditToken(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return commentDTO, nil
 }
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type WebmentionHandler struct {
+	WebmentionService service.WebmentionService
+}
+
+func NewWebmentionHandler(webmentionService service.WebmentionService) *WebmentionHandler {
+	return &WebmentionHandler{
+		WebmentionService: webmentionService,
+	}
+}
+
+// Receive accepts the webmention and verifies it later, the mention is shown after it is approved.
+func (w *WebmentionHandler) Receive(ctx *gin.Context) (interface{}, error) {
+	var webmentionParam param.Webmention
+	err := ctx.ShouldBind(&webmentionParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	return nil, w.WebmentionService.Receive(ctx, webmentionParam.Source, webmentionParam.Target)
+}
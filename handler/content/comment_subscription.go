@@ -0,0 +1,91 @@
+package content
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/handler/binding"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type CommentSubscriptionHandler struct {
+	CommentSubscriptionService service.CommentSubscriptionService
+}
+
+func NewCommentSubscriptionHandler(commentSubscriptionService service.CommentSubscriptionService) *CommentSubscriptionHandler {
+	return &CommentSubscriptionHandler{
+		CommentSubscriptionService: commentSubscriptionService,
+	}
+}
+
+type subscriptionParam struct {
+	Token string `json:"token" form:"token"`
+}
+
+// UnsubscribeConfirm asks the visitor to confirm, so that the links opened by the mail scanners have no effect.
+func (c *CommentSubscriptionHandler) UnsubscribeConfirm(ctx *gin.Context, model template.Model) (string, error) {
+	return c.unsubscribe(ctx, model, false)
+}
+
+func (c *CommentSubscriptionHandler) Unsubscribe(ctx *gin.Context, model template.Model) (string, error) {
+	return c.unsubscribe(ctx, model, true)
+}
+
+func (c *CommentSubscriptionHandler) unsubscribe(ctx *gin.Context, model template.Model, confirmed bool) (string, error) {
+	var p subscriptionParam
+	if err := ctx.ShouldBindWith(&p, binding.CustomFormBinding); err != nil || p.Token == "" {
+		model["errorMsg"] = "退订链接无效"
+		return "common/template/unsubscribe", nil
+	}
+	token, err := c.CommentSubscriptionService.ParseUnsubscribeToken(ctx, p.Token)
+	if err != nil {
+		model["errorMsg"] = "退订链接无效"
+		return "common/template/unsubscribe", nil
+	}
+	model["token"] = p.Token
+	model["email"] = token.Email
+	model["all"] = token.ContentID == 0
+	if confirmed {
+		err = c.CommentSubscriptionService.Unsubscribe(ctx, token.Email, token.Type, token.ContentID)
+		if err != nil {
+			model["errorMsg"] = xerr.GetMessage(err)
+		} else {
+			model["unsubscribed"] = true
+		}
+	}
+	return "common/template/unsubscribe", nil
+}
+
+// SubscribeConfirm asks the visitor to confirm like UnsubscribeConfirm, the thread is only subscribed by the POST.
+func (c *CommentSubscriptionHandler) SubscribeConfirm(ctx *gin.Context, model template.Model) (string, error) {
+	return c.subscribe(ctx, model, false)
+}
+
+func (c *CommentSubscriptionHandler) Subscribe(ctx *gin.Context, model template.Model) (string, error) {
+	return c.subscribe(ctx, model, true)
+}
+
+func (c *CommentSubscriptionHandler) subscribe(ctx *gin.Context, model template.Model, confirmed bool) (string, error) {
+	var p subscriptionParam
+	if err := ctx.ShouldBindWith(&p, binding.CustomFormBinding); err != nil || p.Token == "" {
+		model["errorMsg"] = "订阅链接无效或已过期"
+		return "common/template/subscribe", nil
+	}
+	token, err := c.CommentSubscriptionService.ParseSubscribeToken(ctx, p.Token)
+	if err != nil {
+		model["errorMsg"] = "订阅链接无效或已过期"
+		return "common/template/subscribe", nil
+	}
+	model["token"] = p.Token
+	model["email"] = token.Email
+	if confirmed {
+		err = c.CommentSubscriptionService.Subscribe(ctx, token.Email, token.Type, token.ContentID)
+		if err != nil {
+			model["errorMsg"] = xerr.GetMessage(err)
+		} else {
+			model["subscribed"] = true
+		}
+	}
+	return "common/template/subscribe", nil
+}
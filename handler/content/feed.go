@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/go-sonic/sonic/service/assembler"
 	"github.com/go-sonic/sonic/template"
 	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
 )
 
 type FeedHandler struct {
@@ -25,16 +28,40 @@ type FeedHandler struct {
 	PostService         service.PostService
 	PostCategoryService service.PostCategoryService
 	CategoryService     service.CategoryService
+	PostTagService      service.PostTagService
+	TagService          service.TagService
+	JournalService      service.JournalService
+	BaseCommentService  service.BaseCommentService
+	SitemapService      service.SitemapService
 	PostAssembler       assembler.PostAssembler
+	UserService         service.UserService
 }
 
-func NewFeedHandler(optionService service.OptionService, postService service.PostService, categoryService service.CategoryService, postCategoryService service.PostCategoryService, postAssembler assembler.PostAssembler) *FeedHandler {
+func NewFeedHandler(
+	optionService service.OptionService,
+	postService service.PostService,
+	categoryService service.CategoryService,
+	postCategoryService service.PostCategoryService,
+	postTagService service.PostTagService,
+	tagService service.TagService,
+	journalService service.JournalService,
+	baseCommentService service.BaseCommentService,
+	sitemapService service.SitemapService,
+	postAssembler assembler.PostAssembler,
+	userService service.UserService,
+) *FeedHandler {
 	return &FeedHandler{
 		OptionService:       optionService,
 		PostService:         postService,
 		CategoryService:     categoryService,
 		PostCategoryService: postCategoryService,
+		PostTagService:      postTagService,
+		TagService:          tagService,
+		JournalService:      journalService,
+		BaseCommentService:  baseCommentService,
+		SitemapService:      sitemapService,
 		PostAssembler:       postAssembler,
+		UserService:         userService,
 	}
 }
 
@@ -98,6 +125,7 @@ func (f *FeedHandler) CategoryAtom(ctx *gin.Context, model template.Model) (stri
 	if err != nil {
 		return "", err
 	}
+	posts = f.limitPosts(ctx, posts)
 
 	postDetailVOs, err := f.buildPost(ctx, posts)
 	if err != nil {
@@ -112,28 +140,448 @@ func (f *FeedHandler) CategoryAtom(ctx *gin.Context, model template.Model) (stri
 	return "common/web/atom", nil
 }
 
-func (f *FeedHandler) Robots(ctx *gin.Context, model template.Model) (string, error) {
-	ctx.Header("Content-Type", "text/plain;charset=utf-8")
-	return "common/web/robots", nil
+func (f *FeedHandler) TagFeed(ctx *gin.Context, model template.Model) (string, error) {
+	_, err := f.TagAtom(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	ctx.Header("Content-Type", "application/xml; charset=utf-8")
+	return "common/web/rss", nil
 }
 
-func (f *FeedHandler) SitemapXML(ctx *gin.Context, model template.Model) (string, error) {
-	posts, _, err := f.PostService.Page(ctx, param.PostQuery{
-		Page:     param.Page{PageNum: 0, PageSize: int(^uint(0) >> 1)},
-		Sort:     &param.Sort{Fields: []string{"createTime,desc"}},
-		Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
-	})
+func (f *FeedHandler) TagAtom(ctx *gin.Context, model template.Model) (string, error) {
+	slug, err := util.ParamString(ctx, "slug")
 	if err != nil {
 		return "", err
 	}
+	slug = strings.TrimSuffix(slug, ".xml")
+	tag, err := f.TagService.GetBySlug(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+	tagDTO, err := f.TagService.ConvertToDTO(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+
+	posts, err := f.PostTagService.ListPostByTagID(ctx, tag.ID, consts.PostStatusPublished)
+	if err != nil {
+		return "", err
+	}
+	posts = f.limitPosts(ctx, posts)
+
 	postDetailVOs, err := f.buildPost(ctx, posts)
 	if err != nil {
 		return "", err
 	}
+	model["tag"] = tagDTO
 	model["posts"] = postDetailVOs
+	model["lastModified"] = f.getLastModifiedTime(posts)
+	ctx.Header("Content-Type", "application/xml; charset=utf-8")
+	return "common/web/atom", nil
+}
+
+func (f *FeedHandler) JournalFeed(ctx *gin.Context, model template.Model) (string, error) {
+	if err := f.buildJournalEntries(ctx, model); err != nil {
+		return "", err
+	}
+	return "common/web/entry_rss", nil
+}
+
+func (f *FeedHandler) JournalAtom(ctx *gin.Context, model template.Model) (string, error) {
+	if err := f.buildJournalEntries(ctx, model); err != nil {
+		return "", err
+	}
+	return "common/web/entry_atom", nil
+}
+
+func (f *FeedHandler) CommentFeed(ctx *gin.Context, model template.Model) (string, error) {
+	if err := f.buildCommentEntries(ctx, model); err != nil {
+		return "", err
+	}
+	return "common/web/entry_rss", nil
+}
+
+func (f *FeedHandler) CommentAtom(ctx *gin.Context, model template.Model) (string, error) {
+	if err := f.buildCommentEntries(ctx, model); err != nil {
+		return "", err
+	}
+	return "common/web/entry_atom", nil
+}
+
+func (f *FeedHandler) PostCommentFeed(ctx *gin.Context, model template.Model) (string, error) {
+	if err := f.buildPostCommentEntries(ctx, model); err != nil {
+		return "", err
+	}
+	return "common/web/entry_rss", nil
+}
+
+func (f *FeedHandler) PostCommentAtom(ctx *gin.Context, model template.Model) (string, error) {
+	if err := f.buildPostCommentEntries(ctx, model); err != nil {
+		return "", err
+	}
+	return "common/web/entry_atom", nil
+}
+
+// buildJournalEntries puts the latest public journals into the model.
+func (f *FeedHandler) buildJournalEntries(ctx *gin.Context, model template.Model) error {
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return err
+	}
+	journalPrefix, err := f.OptionService.GetJournalPrefix(ctx)
+	if err != nil {
+		return err
+	}
+	users, err := f.UserService.GetAllUser(ctx)
+	if err != nil {
+		return err
+	}
+	rssPageSize := f.OptionService.GetOrByDefault(ctx, property.RssPageSize).(int)
+	// the journals are listed by pages of 100 at most
+	if rssPageSize > 100 {
+		rssPageSize = 100
+	}
+	journals, _, err := f.JournalService.ListJournal(ctx, param.JournalQuery{
+		Page:        param.Page{PageNum: 0, PageSize: rssPageSize},
+		Sort:        &param.Sort{Fields: []string{"createTime,desc"}},
+		JournalType: consts.JournalTypePublic.Ptr(),
+	})
+	if err != nil {
+		return err
+	}
+
+	journalsURL := blogURL + "/" + journalPrefix
+	entries := make([]*vo.FeedEntry, 0, len(journals))
+	for _, journal := range journals {
+		entry := &vo.FeedEntry{
+			ID:         journalsURL + "#journal-" + strconv.Itoa(int(journal.ID)),
+			Title:      feedEntryTitle(journal.Content),
+			Link:       journalsURL,
+			Content:    xmlInValidChar.ReplaceAllString(journal.Content, ""),
+			AuthorURL:  blogURL,
+			CreateTime: journal.CreateTime,
+			UpdateTime: journal.CreateTime,
+		}
+		if journal.UpdateTime != nil {
+			entry.UpdateTime = *journal.UpdateTime
+		}
+		if len(users) > 0 {
+			entry.Author = users[0].Nickname
+		}
+		entries = append(entries, entry)
+	}
+	model["feedTitle"] = "日志 - " + f.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string)
+	model["feedLink"] = journalsURL
+	model["feedSelfLink"] = blogURL + "/atom/journals"
+	if len(users) > 0 {
+		model["feedDescription"] = users[0].Description
+	}
+	f.putEntries(ctx, model, entries)
+	return nil
+}
+
+// buildCommentEntries puts the latest comments of the site into the model.
+func (f *FeedHandler) buildCommentEntries(ctx *gin.Context, model template.Model) error {
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return err
+	}
+	rssPageSize := f.OptionService.GetOrByDefault(ctx, property.RssPageSize).(int)
+	comments, err := f.BaseCommentService.ListLatestPublic(ctx, rssPageSize)
+	if err != nil {
+		return err
+	}
+	postIDs := make([]int32, 0, len(comments))
+	for _, comment := range comments {
+		if comment.Type != consts.CommentTypeJournal {
+			postIDs = append(postIDs, comment.PostID)
+		}
+	}
+	posts, err := f.PostService.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+	journalPrefix, err := f.OptionService.GetJournalPrefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*vo.FeedEntry, 0, len(comments))
+	for _, comment := range comments {
+		title, link := "日志", blogURL+"/"+journalPrefix
+		if comment.Type != consts.CommentTypeJournal {
+			post, ok := posts[comment.PostID]
+			if !ok {
+				continue
+			}
+			fullPath, err := f.PostService.BuildFullPath(ctx, post)
+			if err != nil {
+				return err
+			}
+			title, link = post.Title, absoluteURL(blogURL, fullPath)
+		}
+		entries = append(entries, f.buildCommentEntry(ctx, comment, title, link))
+	}
+	model["feedTitle"] = "最新评论 - " + f.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string)
+	model["feedLink"] = blogURL
+	model["feedSelfLink"] = blogURL + "/atom/comments"
+	f.putEntries(ctx, model, entries)
+	return nil
+}
+
+// buildPostCommentEntries puts the latest comments of the post into the model, the post has to be published.
+func (f *FeedHandler) buildPostCommentEntries(ctx *gin.Context, model template.Model) error {
+	postID, err := util.ParamInt32(ctx, "postID")
+	if err != nil {
+		return err
+	}
+	post, err := f.PostService.GetByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.Type != consts.PostTypePost || post.Status != consts.PostStatusPublished {
+		return xerr.WithStatus(nil, xerr.StatusNotFound).WithMsg("查询不到文章信息")
+	}
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return err
+	}
+	fullPath, err := f.PostService.BuildFullPath(ctx, post)
+	if err != nil {
+		return err
+	}
+	link := absoluteURL(blogURL, fullPath)
+	comments, err := f.BaseCommentService.GetByContentID(ctx, post.ID, consts.CommentTypePost, &param.Sort{Fields: []string{"createTime,desc"}}, consts.CommentStatusPublished.Ptr())
+	if err != nil {
+		return err
+	}
+	rssPageSize := f.OptionService.GetOrByDefault(ctx, property.RssPageSize).(int)
+	if len(comments) > rssPageSize {
+		comments = comments[:rssPageSize]
+	}
 
+	entries := make([]*vo.FeedEntry, 0, len(comments))
+	for _, comment := range comments {
+		entries = append(entries, f.buildCommentEntry(ctx, comment, post.Title, link))
+	}
+	model["feedTitle"] = "《" + post.Title + "》的评论 - " + f.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string)
+	model["feedLink"] = link
+	model["feedSelfLink"] = blogURL + "/atom/posts/" + strconv.Itoa(int(post.ID)) + "/comments"
+	f.putEntries(ctx, model, entries)
+	return nil
+}
+
+func (f *FeedHandler) buildCommentEntry(ctx context.Context, comment *entity.Comment, title, link string) *vo.FeedEntry {
+	entry := &vo.FeedEntry{
+		ID:         link + "#comment-" + strconv.Itoa(int(comment.ID)),
+		Title:      comment.Author + " 评论了《" + title + "》",
+		Link:       link,
+		Content:    xmlInValidChar.ReplaceAllString(f.BaseCommentService.RenderContent(ctx, comment.Content), ""),
+		Author:     comment.Author,
+		AuthorURL:  comment.AuthorURL,
+		CreateTime: comment.CreateTime,
+		UpdateTime: comment.CreateTime,
+	}
+	if comment.UpdateTime != nil {
+		entry.UpdateTime = *comment.UpdateTime
+	}
+	return entry
+}
+
+func (f *FeedHandler) putEntries(ctx *gin.Context, model template.Model, entries []*vo.FeedEntry) {
+	lastModified := time.Now()
+	if len(entries) > 0 {
+		lastModified = entries[0].UpdateTime
+		for _, entry := range entries {
+			if entry.UpdateTime.After(lastModified) {
+				lastModified = entry.UpdateTime
+			}
+		}
+	}
+	model["entries"] = entries
+	model["lastModified"] = lastModified
 	ctx.Header("Content-Type", "application/xml; charset=utf-8")
-	return "common/web/sitemap_xml", nil
+}
+
+// feedEntryTitle takes the beginning of the text as the title of an entry which has no title.
+func feedEntryTitle(htmlContent string) string {
+	text := []rune(strings.TrimSpace(xmlInValidChar.ReplaceAllString(util.CleanHTMLTag(htmlContent), " ")))
+	if len(text) > 50 {
+		return string(text[:50]) + "…"
+	}
+	return string(text)
+}
+
+// limitPosts keeps the latest posts up to the size of the feeds.
+func (f *FeedHandler) limitPosts(ctx context.Context, posts []*entity.Post) []*entity.Post {
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].CreateTime.After(posts[j].CreateTime)
+	})
+	rssPageSize := f.OptionService.GetOrByDefault(ctx, property.RssPageSize).(int)
+	if len(posts) > rssPageSize {
+		posts = posts[:rssPageSize]
+	}
+	return posts
+}
+
+func (f *FeedHandler) JSONFeed(ctx *gin.Context) (interface{}, error) {
+	rssPageSize := f.OptionService.GetOrByDefault(ctx, property.RssPageSize).(int)
+	posts, _, err := f.PostService.Page(ctx, param.PostQuery{
+		Page:     param.Page{PageNum: 0, PageSize: rssPageSize},
+		Sort:     &param.Sort{Fields: []string{"createTime,desc"}},
+		Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := f.buildJSONFeed(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+	feed.HomePageURL = blogURL + "/"
+	feed.FeedURL = blogURL + "/feed.json"
+	return feed, nil
+}
+
+func (f *FeedHandler) CategoryJSONFeed(ctx *gin.Context) (interface{}, error) {
+	slug, err := util.ParamString(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	slug = strings.TrimSuffix(slug, ".json")
+	category, err := f.CategoryService.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	categoryDTO, err := f.CategoryService.ConvertToCategoryDTO(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := f.PostCategoryService.ListByCategoryID(ctx, category.ID, consts.PostStatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	posts = f.limitPosts(ctx, posts)
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := f.buildJSONFeed(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+	feed.Title = "分类：" + categoryDTO.Name + " - " + feed.Title
+	feed.HomePageURL = absoluteURL(blogURL, categoryDTO.FullPath)
+	feed.FeedURL = blogURL + "/feed/categories/" + category.Slug + ".json"
+	if categoryDTO.Description != "" {
+		feed.Description = categoryDTO.Description
+	}
+	return feed, nil
+}
+
+// buildJSONFeed builds the feed of the site with the posts as items, the items have the full content or the summary as the RSS does.
+func (f *FeedHandler) buildJSONFeed(ctx context.Context, posts []*entity.Post) (*vo.JSONFeed, error) {
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users, err := f.UserService.GetAllUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feed := &vo.JSONFeed{
+		Version: "https://jsonfeed.org/version/1.1",
+		Title:   f.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string),
+		Icon:    absoluteURL(blogURL, f.OptionService.GetOrByDefault(ctx, property.BlogLogo).(string)),
+		Favicon: absoluteURL(blogURL, f.OptionService.GetOrByDefault(ctx, property.BlogFavicon).(string)),
+		Items:   make([]*vo.JSONFeedItem, 0, len(posts)),
+	}
+	if len(users) > 0 {
+		feed.Description = users[0].Description
+		feed.Authors = []*vo.JSONFeedAuthor{{
+			Name:   users[0].Nickname,
+			URL:    blogURL + "/",
+			Avatar: absoluteURL(blogURL, users[0].Avatar),
+		}}
+	}
+
+	postDetailVOs, err := f.PostAssembler.ConvertToDetailVOs(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+	fullContent := f.OptionService.GetOrByDefault(ctx, property.RssContentType).(string) == "full"
+	for _, post := range postDetailVOs {
+		url := absoluteURL(blogURL, post.FullPath)
+		item := &vo.JSONFeedItem{
+			ID:            url,
+			URL:           url,
+			Title:         post.Title,
+			Summary:       post.Summary,
+			Image:         absoluteURL(blogURL, post.Thumbnail),
+			DatePublished: time.UnixMilli(post.CreateTime).Format(time.RFC3339),
+			Authors:       feed.Authors,
+		}
+		if fullContent {
+			item.ContentHTML = post.Content
+		} else {
+			item.ContentText = post.Summary
+		}
+		if post.EditTime > 0 {
+			item.DateModified = time.UnixMilli(post.EditTime).Format(time.RFC3339)
+		}
+		for _, tag := range post.Tags {
+			item.Tags = append(item.Tags, tag.Name)
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	return feed, nil
+}
+
+// absoluteURL resolves the path which is relative to the blog, an empty path stays empty.
+func absoluteURL(blogURL, path string) string {
+	if path == "" || strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	if strings.HasPrefix(path, "//") {
+		return "https:" + path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return blogURL + path
+}
+
+func (f *FeedHandler) Robots(ctx *gin.Context, model template.Model) (string, error) {
+	ctx.Header("Content-Type", "text/plain;charset=utf-8")
+	return "common/web/robots", nil
+}
+
+func (f *FeedHandler) SitemapXML(ctx *gin.Context) ([]byte, error) {
+	return f.SitemapService.GetIndex(ctx)
+}
+
+var sitemapNamePattern = regexp.MustCompile(`^([a-z]+)-([0-9]+)\.xml$`)
+
+// Sitemap returns a page of the sitemap of a type, which is named like posts-1.xml.
+func (f *FeedHandler) Sitemap(ctx *gin.Context) ([]byte, error) {
+	name, err := util.ParamString(ctx, "name")
+	if err != nil {
+		return nil, err
+	}
+	matches := sitemapNamePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return nil, xerr.WithStatus(nil, xerr.StatusNotFound).WithMsg("The sitemap does not exist")
+	}
+	page, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return nil, xerr.WithStatus(nil, xerr.StatusNotFound).WithMsg("The sitemap does not exist")
+	}
+	return f.SitemapService.GetSitemap(ctx, matches[1], page)
 }
 
 func (f *FeedHandler) SitemapHTML(ctx *gin.Context, model template.Model) (string, error) {
@@ -15,5 +15,7 @@ func init() {
 		NewPhotoHandler,
 		NewJournalHandler,
 		NewSearchHandler,
+		NewCommentSubscriptionHandler,
+		NewActivityPubHandler,
 	)
 }
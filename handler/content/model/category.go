@@ -11,8 +11,12 @@ import (
 	"github.com/go-sonic/sonic/service"
 	"github.com/go-sonic/sonic/service/assembler"
 	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util"
 )
 
+// categoriesTitle is the title of the page listing all the categories
+const categoriesTitle = "分类"
+
 func NewCategoryModel(optionService service.OptionService,
 	postService service.PostService,
 	themeService service.ThemeService,
@@ -23,6 +27,8 @@ func NewCategoryModel(optionService service.OptionService,
 	postAssembler assembler.PostAssembler,
 	metaService service.MetaService,
 	categoryAuthentication *authentication.CategoryAuthentication,
+	captchaService service.CaptchaService,
+	seoService service.SeoService,
 ) *CategoryModel {
 	return &CategoryModel{
 		OptionService:          optionService,
@@ -35,6 +41,8 @@ func NewCategoryModel(optionService service.OptionService,
 		TagService:             tagService,
 		MetaService:            metaService,
 		CategoryAuthentication: categoryAuthentication,
+		CaptchaService:         captchaService,
+		SeoService:             seoService,
 	}
 }
 
@@ -49,12 +57,23 @@ type CategoryModel struct {
 	MetaService            service.MetaService
 	PostAssembler          assembler.PostAssembler
 	CategoryAuthentication *authentication.CategoryAuthentication
+	CaptchaService         service.CaptchaService
+	SeoService             service.SeoService
 }
 
 func (c *CategoryModel) ListCategories(ctx context.Context, model template.Model) (string, error) {
 	seoKeyWords := c.OptionService.GetOrByDefault(ctx, property.SeoKeywords)
 	seoDescription := c.OptionService.GetOrByDefault(ctx, property.SeoDescription)
 
+	categoryPrefix, err := c.OptionService.GetCategoryPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	model["seo"], err = c.SeoService.BuildForSite(ctx, categoriesTitle, categoryPrefix, 0)
+	if err != nil {
+		return "", err
+	}
+
 	model["is_categories"] = true
 	model["meta_keywords"] = seoKeyWords
 	model["meta_description"] = seoDescription
@@ -71,6 +90,7 @@ func (c *CategoryModel) CategoryDetail(ctx context.Context, model template.Model
 		if isAuthenticated, err := c.CategoryAuthentication.IsAuthenticated(ctx, token, category.ID); err != nil || !isAuthenticated {
 			model["slug"] = category.Slug
 			model["type"] = consts.EncryptTypeCategory.Name()
+			model["captcha_required"], _ = c.CaptchaService.AuthenticationRequired(ctx, util.GetClientIP(ctx))
 			if exist, err := c.ThemeService.TemplateExist(ctx, "post_password.tmpl"); err == nil && exist {
 				return c.ThemeService.Render(ctx, "post_password")
 			}
@@ -112,6 +132,10 @@ func (c *CategoryModel) CategoryDetail(ctx context.Context, model template.Model
 	} else {
 		model["meta_description"] = c.OptionService.GetOrByDefault(ctx, property.SeoDescription)
 	}
+	model["seo"], err = c.SeoService.BuildForCategory(ctx, categoryDTO, categoriesTitle, page)
+	if err != nil {
+		return "", err
+	}
 	model["is_category"] = true
 	model["posts"] = postPage
 	model["category"] = categoryDTO
@@ -14,11 +14,13 @@ import (
 func NewJournalModel(optionService service.OptionService,
 	themeService service.ThemeService,
 	journalService service.JournalService,
+	seoService service.SeoService,
 ) *JournalModel {
 	return &JournalModel{
 		OptionService:  optionService,
 		ThemeService:   themeService,
 		JournalService: journalService,
+		SeoService:     seoService,
 	}
 }
 
@@ -26,6 +28,7 @@ type JournalModel struct {
 	JournalService service.JournalService
 	OptionService  service.OptionService
 	ThemeService   service.ThemeService
+	SeoService     service.SeoService
 }
 
 func (p *JournalModel) Journals(ctx context.Context, model template.Model, page int) (string, error) {
@@ -47,6 +50,14 @@ func (p *JournalModel) Journals(ctx context.Context, model template.Model, page
 		return "", err
 	}
 	journalPage := dto.NewPage(journalDTOs, total, param.Page{PageNum: page, PageSize: pageSize})
+	journalPrefix, err := p.OptionService.GetJournalPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	model["seo"], err = p.SeoService.BuildForSite(ctx, "日志", journalPrefix, page)
+	if err != nil {
+		return "", err
+	}
 	model["is_journals"] = true
 	model["journals"] = journalPage
 	model["meta_keywords"] = p.OptionService.GetOrByDefault(ctx, property.SeoKeywords)
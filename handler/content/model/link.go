@@ -12,11 +12,13 @@ func NewLinkModel(
 	optionService service.OptionService,
 	themeService service.ThemeService,
 	linkService service.LinkService,
+	seoService service.SeoService,
 ) *LinkModel {
 	return &LinkModel{
 		OptionService: optionService,
 		ThemeService:  themeService,
 		LinkService:   linkService,
+		SeoService:    seoService,
 	}
 }
 
@@ -24,9 +26,18 @@ type LinkModel struct {
 	LinkService   service.LinkService
 	OptionService service.OptionService
 	ThemeService  service.ThemeService
+	SeoService    service.SeoService
 }
 
 func (l *LinkModel) Links(ctx context.Context, model template.Model) (string, error) {
+	linkPrefix, err := l.OptionService.GetLinkPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	model["seo"], err = l.SeoService.BuildForSite(ctx, "友情链接", linkPrefix, 0)
+	if err != nil {
+		return "", err
+	}
 	model["is_links"] = true
 	model["meta_keywords"] = l.OptionService.GetOrByDefault(ctx, property.SeoKeywords)
 	model["meta_description"] = l.OptionService.GetOrByDefault(ctx, property.SeoDescription)
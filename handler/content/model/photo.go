@@ -13,11 +13,13 @@ import (
 func NewPhotoModel(optionService service.OptionService,
 	themeService service.ThemeService,
 	photoService service.PhotoService,
+	seoService service.SeoService,
 ) *PhotoModel {
 	return &PhotoModel{
 		OptionService: optionService,
 		ThemeService:  themeService,
 		PhotoService:  photoService,
+		SeoService:    seoService,
 	}
 }
 
@@ -25,6 +27,7 @@ type PhotoModel struct {
 	PhotoService  service.PhotoService
 	OptionService service.OptionService
 	ThemeService  service.ThemeService
+	SeoService    service.SeoService
 }
 
 func (p *PhotoModel) Photos(ctx context.Context, model template.Model, page int) (string, error) {
@@ -42,6 +45,14 @@ func (p *PhotoModel) Photos(ctx context.Context, model template.Model, page int)
 	}
 	photoDTOs := p.PhotoService.ConvertToDTOs(ctx, photos)
 	photoPage := dto.NewPage(photoDTOs, total, param.Page{PageNum: page, PageSize: pageSize})
+	photoPrefix, err := p.OptionService.GetPhotoPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	model["seo"], err = p.SeoService.BuildForSite(ctx, "图库", photoPrefix, page)
+	if err != nil {
+		return "", err
+	}
 	model["is_photos"] = true
 	model["photos"] = photoPage
 	model["meta_keywords"] = p.OptionService.GetOrByDefault(ctx, property.SeoKeywords)
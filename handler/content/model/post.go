@@ -13,6 +13,7 @@ import (
 	"github.com/go-sonic/sonic/service"
 	"github.com/go-sonic/sonic/service/assembler"
 	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util"
 	"github.com/go-sonic/sonic/util/xerr"
 )
 
@@ -26,32 +27,41 @@ func NewPostModel(optionService service.OptionService,
 	postAssembler assembler.PostAssembler,
 	metaService service.MetaService,
 	postAuthentication *authentication.PostAuthentication,
+	responsiveImageService service.ResponsiveImageService,
+	captchaService service.CaptchaService,
+	seoService service.SeoService,
 ) *PostModel {
 	return &PostModel{
-		OptionService:       optionService,
-		PostService:         postService,
-		PostAssembler:       postAssembler,
-		ThemeService:        themeService,
-		PostCategoryService: postCategoryService,
-		CategoryService:     categoryService,
-		PostTagService:      postTagService,
-		TagService:          tagService,
-		MetaService:         metaService,
-		PostAuthentication:  postAuthentication,
+		OptionService:          optionService,
+		PostService:            postService,
+		PostAssembler:          postAssembler,
+		ThemeService:           themeService,
+		PostCategoryService:    postCategoryService,
+		CategoryService:        categoryService,
+		PostTagService:         postTagService,
+		TagService:             tagService,
+		MetaService:            metaService,
+		PostAuthentication:     postAuthentication,
+		ResponsiveImageService: responsiveImageService,
+		CaptchaService:         captchaService,
+		SeoService:             seoService,
 	}
 }
 
 type PostModel struct {
-	OptionService       service.OptionService
-	PostService         service.PostService
-	ThemeService        service.ThemeService
-	PostCategoryService service.PostCategoryService
-	CategoryService     service.CategoryService
-	PostTagService      service.PostTagService
-	TagService          service.TagService
-	MetaService         service.MetaService
-	PostAssembler       assembler.PostAssembler
-	PostAuthentication  *authentication.PostAuthentication
+	OptionService          service.OptionService
+	PostService            service.PostService
+	ThemeService           service.ThemeService
+	PostCategoryService    service.PostCategoryService
+	CategoryService        service.CategoryService
+	PostTagService         service.PostTagService
+	TagService             service.TagService
+	MetaService            service.MetaService
+	PostAssembler          assembler.PostAssembler
+	PostAuthentication     *authentication.PostAuthentication
+	ResponsiveImageService service.ResponsiveImageService
+	CaptchaService         service.CaptchaService
+	SeoService             service.SeoService
 }
 
 func (p *PostModel) Content(ctx context.Context, post *entity.Post, token string, model template.Model) (string, error) {
@@ -64,6 +74,7 @@ func (p *PostModel) Content(ctx context.Context, post *entity.Post, token string
 		if isAuthenticated, err := p.PostAuthentication.IsAuthenticated(ctx, token, post.ID); err != nil || !isAuthenticated {
 			model["slug"] = post.Slug
 			model["type"] = consts.EncryptTypePost.Name()
+			model["captcha_required"], _ = p.CaptchaService.AuthenticationRequired(ctx, util.GetClientIP(ctx))
 			if exist, err := p.ThemeService.TemplateExist(ctx, "post_password.tmpl"); err == nil && exist {
 				return p.ThemeService.Render(ctx, "post_password")
 			}
@@ -75,6 +86,10 @@ func (p *PostModel) Content(ctx context.Context, post *entity.Post, token string
 	if err != nil {
 		return "", err
 	}
+	postVO.Content, err = p.ResponsiveImageService.Filter(ctx, postVO.Content)
+	if err != nil {
+		return "", err
+	}
 	model["post"] = postVO
 
 	prevPosts, err := p.PostService.GetPrevPosts(ctx, post, 1)
@@ -104,19 +119,30 @@ func (p *PostModel) Content(ctx context.Context, post *entity.Post, token string
 	if err != nil {
 		return "", err
 	}
-	model["categories"], _ = p.CategoryService.ConvertToCategoryDTOs(ctx, categories)
+	categoryDTOs, _ := p.CategoryService.ConvertToCategoryDTOs(ctx, categories)
+	model["categories"] = categoryDTOs
 
 	tags, err := p.PostTagService.ListTagByPostID(ctx, post.ID)
 	if err != nil {
 		return "", err
 	}
-	model["tags"], _ = p.TagService.ConvertToDTOs(ctx, tags)
+	tagDTOs, _ := p.TagService.ConvertToDTOs(ctx, tags)
+	model["tags"] = tagDTOs
+
+	model["seo"], err = p.SeoService.BuildForPost(ctx, &postVO.Post, categoryDTOs, tagDTOs)
+	if err != nil {
+		return "", err
+	}
 
 	metas, err := p.MetaService.GetPostMeta(ctx, post.ID)
 	if err != nil {
 		return "", err
 	}
 	model["metas"] = p.MetaService.ConvertToMetaDTOs(metas)
+	model["typed_metas"], err = p.MetaService.ConvertToTypedMetas(ctx, consts.PostTypePost, metas)
+	if err != nil {
+		return "", err
+	}
 
 	if post.MetaDescription != "" {
 		model["meta_description"] = post.MetaDescription
@@ -171,6 +197,10 @@ func (p *PostModel) List(ctx context.Context, page int, model template.Model) (s
 
 	model["is_index"] = true
 	model["posts"] = postPage
+	model["seo"], err = p.SeoService.BuildForSite(ctx, "", "", page)
+	if err != nil {
+		return "", err
+	}
 	model["meta_keywords"] = seoKeyWords
 	model["meta_description"] = seoDescription
 	return p.ThemeService.Render(ctx, "index")
@@ -207,6 +237,15 @@ func (p *PostModel) Archives(ctx context.Context, page int, model template.Model
 	seoKeyWords := p.OptionService.GetOrByDefault(ctx, property.SeoKeywords)
 	seoDescription := p.OptionService.GetOrByDefault(ctx, property.SeoDescription)
 
+	archivePrefix, err := p.OptionService.GetArchivePrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	model["seo"], err = p.SeoService.BuildForSite(ctx, "归档", archivePrefix, page)
+	if err != nil {
+		return "", err
+	}
+
 	model["is_archives"] = true
 	model["posts"] = postPage
 	model["archives"] = archives
@@ -224,6 +263,10 @@ func (p *PostModel) AdminPreview(ctx context.Context, post *entity.Post, model t
 	if err != nil {
 		return "", err
 	}
+	postVO.Content, err = p.ResponsiveImageService.Filter(ctx, postVO.Content)
+	if err != nil {
+		return "", err
+	}
 	model["post"] = postVO
 
 	prevPosts, err := p.PostService.GetPrevPosts(ctx, post, 1)
@@ -253,19 +296,30 @@ func (p *PostModel) AdminPreview(ctx context.Context, post *entity.Post, model t
 	if err != nil {
 		return "", err
 	}
-	model["categories"], _ = p.CategoryService.ConvertToCategoryDTOs(ctx, categories)
+	categoryDTOs, _ := p.CategoryService.ConvertToCategoryDTOs(ctx, categories)
+	model["categories"] = categoryDTOs
 
 	tags, err := p.PostTagService.ListTagByPostID(ctx, post.ID)
 	if err != nil {
 		return "", err
 	}
-	model["tags"], _ = p.TagService.ConvertToDTOs(ctx, tags)
+	tagDTOs, _ := p.TagService.ConvertToDTOs(ctx, tags)
+	model["tags"] = tagDTOs
+
+	model["seo"], err = p.SeoService.BuildForPost(ctx, &postVO.Post, categoryDTOs, tagDTOs)
+	if err != nil {
+		return "", err
+	}
 
 	metas, err := p.MetaService.GetPostMeta(ctx, post.ID)
 	if err != nil {
 		return "", err
 	}
 	model["metas"] = p.MetaService.ConvertToMetaDTOs(metas)
+	model["typed_metas"], err = p.MetaService.ConvertToTypedMetas(ctx, consts.PostTypePost, metas)
+	if err != nil {
+		return "", err
+	}
 
 	if post.MetaDescription != "" {
 		model["meta_description"] = post.MetaDescription
@@ -10,6 +10,7 @@ import (
 	"github.com/go-sonic/sonic/service"
 	"github.com/go-sonic/sonic/service/assembler"
 	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util"
 	"github.com/go-sonic/sonic/util/xerr"
 )
 
@@ -21,28 +22,37 @@ func NewSheetModel(optionService service.OptionService,
 	sheetAssembler assembler.SheetAssembler,
 	sheetService service.SheetService,
 	postAuthentication *authentication.PostAuthentication,
+	responsiveImageService service.ResponsiveImageService,
+	captchaService service.CaptchaService,
+	seoService service.SeoService,
 ) *SheetModel {
 	return &SheetModel{
-		OptionService:      optionService,
-		ThemeService:       themeService,
-		PostTagService:     postTagService,
-		TagService:         tagService,
-		MetaService:        metaService,
-		SheetAssembler:     sheetAssembler,
-		SheetService:       sheetService,
-		PostAuthentication: postAuthentication,
+		OptionService:          optionService,
+		ThemeService:           themeService,
+		PostTagService:         postTagService,
+		TagService:             tagService,
+		MetaService:            metaService,
+		SheetAssembler:         sheetAssembler,
+		SheetService:           sheetService,
+		PostAuthentication:     postAuthentication,
+		ResponsiveImageService: responsiveImageService,
+		CaptchaService:         captchaService,
+		SeoService:             seoService,
 	}
 }
 
 type SheetModel struct {
-	SheetService       service.SheetService
-	OptionService      service.OptionService
-	ThemeService       service.ThemeService
-	PostTagService     service.PostTagService
-	TagService         service.TagService
-	MetaService        service.MetaService
-	SheetAssembler     assembler.SheetAssembler
-	PostAuthentication *authentication.PostAuthentication
+	SheetService           service.SheetService
+	OptionService          service.OptionService
+	ThemeService           service.ThemeService
+	PostTagService         service.PostTagService
+	TagService             service.TagService
+	MetaService            service.MetaService
+	SheetAssembler         assembler.SheetAssembler
+	PostAuthentication     *authentication.PostAuthentication
+	ResponsiveImageService service.ResponsiveImageService
+	CaptchaService         service.CaptchaService
+	SeoService             service.SeoService
 }
 
 func (s *SheetModel) Content(ctx context.Context, sheet *entity.Post, token string, model template.Model) (string, error) {
@@ -55,6 +65,7 @@ func (s *SheetModel) Content(ctx context.Context, sheet *entity.Post, token stri
 		if isAuthenticated, err := s.PostAuthentication.IsAuthenticated(ctx, token, sheet.ID); err != nil || !isAuthenticated {
 			model["slug"] = sheet.Slug
 			model["type"] = consts.EncryptTypePost.Name()
+			model["captcha_required"], _ = s.CaptchaService.AuthenticationRequired(ctx, util.GetClientIP(ctx))
 			if exist, err := s.ThemeService.TemplateExist(ctx, "post_password.tmpl"); err == nil && exist {
 				return s.ThemeService.Render(ctx, "post_password")
 			}
@@ -66,17 +77,29 @@ func (s *SheetModel) Content(ctx context.Context, sheet *entity.Post, token stri
 	if err != nil {
 		return "", err
 	}
+	sheetVO.Content, err = s.ResponsiveImageService.Filter(ctx, sheetVO.Content)
+	if err != nil {
+		return "", err
+	}
 	model["target"] = sheetVO
 	model["type"] = "sheet"
 	model["post"] = sheetVO
 	model["sheet"] = sheetVO
 	model["is_sheet"] = true
+	model["seo"], err = s.SeoService.BuildForSheet(ctx, &sheetVO.Post)
+	if err != nil {
+		return "", err
+	}
 
 	metas, err := s.MetaService.GetPostMeta(ctx, sheet.ID)
 	if err != nil {
 		return "", err
 	}
 	model["metas"] = s.MetaService.ConvertToMetaDTOs(metas)
+	model["typed_metas"], err = s.MetaService.ConvertToTypedMetas(ctx, consts.PostTypeSheet, metas)
+	if err != nil {
+		return "", err
+	}
 
 	tags, err := s.PostTagService.ListTagByPostID(ctx, sheet.ID)
 	if err != nil {
@@ -115,17 +138,29 @@ func (s *SheetModel) AdminPreviewContent(ctx context.Context, sheet *entity.Post
 	if err != nil {
 		return "", err
 	}
+	sheetVO.Content, err = s.ResponsiveImageService.Filter(ctx, sheetVO.Content)
+	if err != nil {
+		return "", err
+	}
 	model["target"] = sheetVO
 	model["type"] = "sheet"
 	model["post"] = sheetVO
 	model["sheet"] = sheetVO
 	model["is_sheet"] = true
+	model["seo"], err = s.SeoService.BuildForSheet(ctx, &sheetVO.Post)
+	if err != nil {
+		return "", err
+	}
 
 	metas, err := s.MetaService.GetPostMeta(ctx, sheet.ID)
 	if err != nil {
 		return "", err
 	}
 	model["metas"] = s.MetaService.ConvertToMetaDTOs(metas)
+	model["typed_metas"], err = s.MetaService.ConvertToTypedMetas(ctx, consts.PostTypeSheet, metas)
+	if err != nil {
+		return "", err
+	}
 
 	tags, err := s.PostTagService.ListTagByPostID(ctx, sheet.ID)
 	if err != nil {
@@ -12,11 +12,15 @@ import (
 	"github.com/go-sonic/sonic/template"
 )
 
+// tagsTitle is the title of the page listing all the tags
+const tagsTitle = "标签"
+
 func NewTagModel(optionService service.OptionService,
 	themeService service.ThemeService,
 	tagService service.TagService,
 	postTagService service.PostTagService,
 	postAssembler assembler.PostAssembler,
+	seoService service.SeoService,
 ) *TagModel {
 	return &TagModel{
 		OptionService:  optionService,
@@ -24,6 +28,7 @@ func NewTagModel(optionService service.OptionService,
 		TagService:     tagService,
 		PostAssembler:  postAssembler,
 		PostTagService: postTagService,
+		SeoService:     seoService,
 	}
 }
 
@@ -34,9 +39,18 @@ type TagModel struct {
 	PostTagService service.PostTagService
 	MetaService    service.MetaService
 	PostAssembler  assembler.PostAssembler
+	SeoService     service.SeoService
 }
 
 func (t *TagModel) Tags(ctx context.Context, model template.Model) (string, error) {
+	tagPrefix, err := t.OptionService.GetTagPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	model["seo"], err = t.SeoService.BuildForSite(ctx, tagsTitle, tagPrefix, 0)
+	if err != nil {
+		return "", err
+	}
 	model["is_tags"] = true
 	model["meta_keywords"] = t.OptionService.GetOrByDefault(ctx, property.SeoKeywords)
 	model["meta_description"] = t.OptionService.GetOrByDefault(ctx, property.SeoDescription)
@@ -75,6 +89,10 @@ func (t *TagModel) TagPosts(ctx context.Context, model template.Model, slug stri
 		PageNum:  page,
 		PageSize: pageSize,
 	})
+	model["seo"], err = t.SeoService.BuildForTag(ctx, tagDTO, tagsTitle, page)
+	if err != nil {
+		return "", err
+	}
 	model["is_tag"] = true
 	model["posts"] = postPage
 	model["tag"] = tagDTO
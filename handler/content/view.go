@@ -24,6 +24,7 @@ type ViewHandler struct {
 	ThemeService           service.ThemeService
 	CategoryAuthentication *authentication.CategoryAuthentication
 	PostAuthentication     *authentication.PostAuthentication
+	CaptchaService         service.CaptchaService
 }
 
 func NewViewHandler(
@@ -34,6 +35,7 @@ func NewViewHandler(
 	themeService service.ThemeService,
 	categoryAuthentication *authentication.CategoryAuthentication,
 	postAuthentication *authentication.PostAuthentication,
+	captchaService service.CaptchaService,
 ) *ViewHandler {
 	return &ViewHandler{
 		OptionService:          optionService,
@@ -43,6 +45,7 @@ func NewViewHandler(
 		ThemeService:           themeService,
 		CategoryAuthentication: categoryAuthentication,
 		PostAuthentication:     postAuthentication,
+		CaptchaService:         captchaService,
 	}
 }
 
@@ -98,6 +101,14 @@ func (v *ViewHandler) Authenticate(ctx *gin.Context, model template.Model) (stri
 	if authenticationParam.Password == "" {
 		return v.authenticateErr(ctx, model, "post", slug, xerr.WithMsg(nil, "密码为空"))
 	}
+	clientIP := util.GetClientIP(ctx)
+	captchaRequired, err := v.CaptchaService.AuthenticationRequired(ctx, clientIP)
+	if err != nil {
+		return v.authenticateErr(ctx, model, contentType, slug, err)
+	}
+	if captchaRequired && !v.CaptchaService.Verify(ctx, authenticationParam.CaptchaID, authenticationParam.CaptchaAnswer) {
+		return v.authenticateErr(ctx, model, contentType, slug, xerr.WithMsg(nil, "验证码错误"))
+	}
 
 	token, _ := ctx.Cookie("authentication")
 
@@ -110,8 +121,10 @@ func (v *ViewHandler) Authenticate(ctx *gin.Context, model template.Model) (stri
 		return v.authenticateErr(ctx, model, "post", slug, xerr.WithStatus(nil, xerr.StatusBadRequest))
 	}
 	if err != nil {
+		v.CaptchaService.AddAuthenticationFailure(ctx, clientIP)
 		return v.authenticateErr(ctx, model, contentType, slug, err)
 	}
+	v.CaptchaService.ResetAuthenticationFailure(ctx, clientIP)
 	ctx.SetCookie("authentication", token, 1800, "/", "", false, true)
 	return "", nil
 }
@@ -157,6 +170,7 @@ func (v *ViewHandler) authenticateErr(ctx *gin.Context, model template.Model, aT
 	model["type"] = aType
 	model["slug"] = slug
 	model["errorMsg"] = xerr.GetMessage(err)
+	model["captcha_required"], _ = v.CaptchaService.AuthenticationRequired(ctx, util.GetClientIP(ctx))
 	if exist, err := v.ThemeService.TemplateExist(ctx, "post_password.tmpl"); err == nil && exist {
 		return v.ThemeService.Render(ctx, "post_password")
 	}
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-sonic/sonic/cache"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+)
+
+// RateLimitClass groups the endpoints sharing the same limits.
+type RateLimitClass string
+
+const (
+	RateLimitComment        RateLimitClass = "comment"
+	RateLimitLike           RateLimitClass = "like"
+	RateLimitAuthentication RateLimitClass = "authentication"
+	RateLimitCaptcha        RateLimitClass = "captcha"
+	// RateLimitFederation limits the deliveries of the remote servers, which send the activities of many users from one address
+	RateLimitFederation RateLimitClass = "federation"
+)
+
+var rateLimitProperties = map[RateLimitClass][2]property.Property{
+	RateLimitComment:        {property.RateLimitCommentBurst, property.RateLimitCommentPerMinute},
+	RateLimitLike:           {property.RateLimitLikeBurst, property.RateLimitLikePerMinute},
+	RateLimitAuthentication: {property.RateLimitAuthenticationBurst, property.RateLimitAuthenticationPerMinute},
+	RateLimitCaptcha:        {property.RateLimitCaptchaBurst, property.RateLimitCaptchaPerMinute},
+	RateLimitFederation:     {property.RateLimitFederationBurst, property.RateLimitFederationPerMinute},
+}
+
+// RateLimitMiddleware limits the requests of every client IP to every route with token buckets kept in the cache.
+type RateLimitMiddleware struct {
+	OptionService service.OptionService
+	Cache         cache.Cache
+
+	mutex sync.Mutex
+}
+
+type tokenBucket struct {
+	Tokens   float64
+	LastTime time.Time
+}
+
+func NewRateLimitMiddleware(optionService service.OptionService, cache cache.Cache) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		OptionService: optionService,
+		Cache:         cache,
+	}
+}
+
+func (r *RateLimitMiddleware) RateLimit(class RateLimitClass) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		wait, err := r.Take(ctx, class)
+		if err != nil {
+			_ = ctx.Error(err)
+			abortWithStatusJSON(ctx, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		if wait > 0 {
+			ctx.Header("Retry-After", RetryAfter(wait))
+			abortWithStatusJSON(ctx, http.StatusTooManyRequests, "Too many requests, please try again later")
+		}
+	}
+}
+
+// Take takes a token of the client of the request from the bucket of the route,
+// it returns how long to wait for the next token if the request is over the limit.
+func (r *RateLimitMiddleware) Take(ctx *gin.Context, class RateLimitClass) (time.Duration, error) {
+	properties := rateLimitProperties[class]
+	enabled, err := r.OptionService.GetOrByDefaultWithErr(ctx, property.RateLimitEnabled, property.RateLimitEnabled.DefaultValue)
+	if err != nil {
+		return 0, err
+	}
+	burst, err := r.OptionService.GetOrByDefaultWithErr(ctx, properties[0], properties[0].DefaultValue)
+	if err != nil {
+		return 0, err
+	}
+	perMinute, err := r.OptionService.GetOrByDefaultWithErr(ctx, properties[1], properties[1].DefaultValue)
+	if err != nil {
+		return 0, err
+	}
+	if !enabled.(bool) || burst.(int) <= 0 || perMinute.(int) <= 0 {
+		return 0, nil
+	}
+
+	key := consts.RateLimitKeyPrefix + string(class) + "_" + ctx.FullPath() + "_" + util.GetClientIP(ctx)
+	return r.take(key, float64(burst.(int)), float64(perMinute.(int))/60), nil
+}
+
+// RetryAfter formats the wait as the value of the Retry-After header.
+func RetryAfter(wait time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(wait.Seconds())))
+}
+
+// take takes a token from the bucket, it returns how long to wait for the next token if the bucket is empty.
+func (r *RateLimitMiddleware) take(key string, burst, ratePerSecond float64) time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	bucket := tokenBucket{Tokens: burst, LastTime: now}
+	if value, ok := r.Cache.Get(key); ok {
+		if cachedBucket, ok := value.(tokenBucket); ok {
+			bucket = cachedBucket
+		}
+	}
+	bucket.Tokens = math.Min(burst, bucket.Tokens+now.Sub(bucket.LastTime).Seconds()*ratePerSecond)
+	bucket.LastTime = now
+
+	var wait time.Duration
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+	} else {
+		wait = time.Duration((1 - bucket.Tokens) / ratePerSecond * float64(time.Second))
+	}
+	// a full bucket is the same as a missing one, so it expires when it is refilled
+	refillTime := time.Duration((burst - bucket.Tokens) / ratePerSecond * float64(time.Second))
+	r.Cache.Set(key, bucket, refillTime+time.Second)
+	return wait
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -48,8 +49,8 @@ func (s *Server) RegisterRouters() {
 			adminAPIRouter := router.Group("/api/admin")
 			adminAPIRouter.Use(s.LogMiddleware.LoggerWithConfig(middleware.GinLoggerConfig{}), s.RecoveryMiddleware.RecoveryWithLogger(), s.InstallRedirectMiddleware.InstallRedirect())
 			adminAPIRouter.GET("/is_installed", s.wrapHandler(s.AdminHandler.IsInstalled))
-			adminAPIRouter.POST("/login/precheck", s.wrapHandler(s.AdminHandler.AuthPreCheck))
-			adminAPIRouter.POST("/login", s.wrapHandler(s.AdminHandler.Auth))
+			adminAPIRouter.POST("/login/precheck", s.RateLimitMiddleware.RateLimit(middleware.RateLimitAuthentication), s.wrapHandler(s.AdminHandler.AuthPreCheck))
+			adminAPIRouter.POST("/login", s.RateLimitMiddleware.RateLimit(middleware.RateLimitAuthentication), s.wrapHandler(s.AdminHandler.Auth))
 			adminAPIRouter.POST("/refresh/:refreshToken", s.wrapHandler(s.AdminHandler.RefreshToken))
 			adminAPIRouter.POST("/installations", s.wrapHandler(s.InstallHandler.InstallBlog))
 			{
@@ -86,6 +87,19 @@ func (s *Server) RegisterRouters() {
 					backupRouter.GET("/markdown/export", s.wrapHandler(s.BackupHandler.ListMarkdowns))
 					backupRouter.DELETE("/markdown/export", s.wrapHandler(s.BackupHandler.DeleteMarkdowns))
 					backupRouter.GET("/markdown/export/:filename", s.BackupHandler.DownloadMarkdown)
+					backupRouter.POST("/ghost/import", s.wrapHandler(s.BackupHandler.ImportGhost))
+					backupRouter.POST("/halo/import", s.wrapHandler(s.BackupHandler.ImportHalo))
+					backupRouter.POST("/disqus/import", s.wrapHandler(s.BackupHandler.ImportDisqus))
+					backupRouter.POST("/twikoo/import", s.wrapHandler(s.BackupHandler.ImportTwikoo))
+					backupRouter.POST("/waline/import", s.wrapHandler(s.BackupHandler.ImportWaline))
+					backupRouter.POST("/epub/export", s.wrapHandler(s.BackupHandler.ExportEpub))
+					backupRouter.GET("/epub/export", s.wrapHandler(s.BackupHandler.ListEpubs))
+					backupRouter.DELETE("/epub/export", s.wrapHandler(s.BackupHandler.DeleteEpubs))
+					backupRouter.GET("/epub/export/:filename", s.BackupHandler.DownloadEpub)
+					backupRouter.POST("/comments/export", s.wrapHandler(s.BackupHandler.ExportComments))
+					backupRouter.GET("/comments/export", s.wrapHandler(s.BackupHandler.ListCommentExports))
+					backupRouter.DELETE("/comments/export", s.wrapHandler(s.BackupHandler.DeleteCommentExports))
+					backupRouter.GET("/comments/export/:filename", s.BackupHandler.DownloadCommentExport)
 				}
 				{
 					categoryRouter := authRouter.Group("/categories")
@@ -107,6 +121,7 @@ func (s *Server) RegisterRouters() {
 					postRouter.PUT("/:postID", s.wrapHandler(s.PostHandler.UpdatePost))
 					postRouter.PUT("/:postID/status/:status", s.wrapHandler(s.PostHandler.UpdatePostStatus))
 					postRouter.PUT("/status/:status", s.wrapHandler(s.PostHandler.UpdatePostStatusBatch))
+					postRouter.PUT("/batch", s.wrapHandler(s.PostHandler.UpdatePostBatch))
 					postRouter.PUT("/:postID/status/draft/content", s.wrapHandler(s.PostHandler.UpdatePostDraft))
 					postRouter.DELETE("/:postID", s.wrapHandler(s.PostHandler.DeletePost))
 					postRouter.DELETE("", s.wrapHandler(s.PostHandler.DeletePostBatch))
@@ -125,6 +140,23 @@ func (s *Server) RegisterRouters() {
 						postCommentRouter.DELETE("", s.wrapHandler(s.PostCommentHandler.DeletePostCommentBatch))
 					}
 				}
+				{
+					commentBlackRouter := authRouter.Group("/comments/blacklist")
+					commentBlackRouter.GET("", s.wrapHandler(s.CommentBlackHandler.ListCommentBlack))
+					commentBlackRouter.POST("", s.wrapHandler(s.CommentBlackHandler.CreateCommentBlack))
+					commentBlackRouter.PUT("/:id", s.wrapHandler(s.CommentBlackHandler.UpdateCommentBlack))
+					commentBlackRouter.DELETE("/:id", s.wrapHandler(s.CommentBlackHandler.DeleteCommentBlack))
+				}
+				{
+					commentSpamRouter := authRouter.Group("/comments/spam")
+					commentSpamRouter.GET("", s.wrapHandler(s.CommentSpamHandler.ListSpamComment))
+					commentSpamRouter.PUT("/approval", s.wrapHandler(s.CommentSpamHandler.ApproveSpamComment))
+					commentSpamRouter.DELETE("", s.wrapHandler(s.CommentSpamHandler.DeleteSpamComment))
+				}
+				{
+					commentRevisionRouter := authRouter.Group("/comments/:commentID/revisions")
+					commentRevisionRouter.GET("", s.wrapHandler(s.CommentRevisionHandler.ListCommentRevisions))
+				}
 				{
 					optionRouter := authRouter.Group("/options")
 					optionRouter.GET("", s.wrapHandler(s.OptionHandler.ListAllOptions))
@@ -199,6 +231,27 @@ func (s *Server) RegisterRouters() {
 					linkRouter.DELETE("/:id", s.wrapHandler(s.LinkHandler.DeleteLink))
 					linkRouter.GET("/teams", s.wrapHandler(s.LinkHandler.ListLinkTeams))
 				}
+				{
+					linkCheckRouter := authRouter.Group("/link-checks")
+					linkCheckRouter.POST("", s.wrapHandler(s.LinkCheckHandler.StartLinkCheck))
+					linkCheckRouter.GET("", s.wrapHandler(s.LinkCheckHandler.GetLinkCheckReport))
+				}
+				{
+					webhookRouter := authRouter.Group("/webhooks")
+					webhookRouter.GET("", s.wrapHandler(s.WebhookHandler.ListWebhook))
+					webhookRouter.GET("/events", s.wrapHandler(s.WebhookHandler.ListWebhookEvent))
+					webhookRouter.POST("", s.wrapHandler(s.WebhookHandler.CreateWebhook))
+					webhookRouter.PUT("/:id", s.wrapHandler(s.WebhookHandler.UpdateWebhook))
+					webhookRouter.DELETE("/:id", s.wrapHandler(s.WebhookHandler.DeleteWebhook))
+					webhookRouter.GET("/:id/deliveries", s.wrapHandler(s.WebhookHandler.ListWebhookDelivery))
+					webhookRouter.POST("/deliveries/:deliveryID/redelivery", s.wrapHandler(s.WebhookHandler.RedeliverWebhookDelivery))
+				}
+				{
+					activityPubRouter := authRouter.Group("/activitypub")
+					activityPubRouter.GET("/followers", s.wrapHandler(s.ActivityPubHandler.ListFollowers))
+					activityPubRouter.DELETE("/followers/:id", s.wrapHandler(s.ActivityPubHandler.DeleteFollower))
+					activityPubRouter.GET("/deliveries", s.wrapHandler(s.ActivityPubHandler.ListDeliveries))
+				}
 				{
 					menuRouter := authRouter.Group("/menus")
 					menuRouter.GET("", s.wrapHandler(s.MenuHandler.ListMenus))
@@ -253,6 +306,8 @@ func (s *Server) RegisterRouters() {
 					themeRouter.PUT("/:themeID/files/content", s.wrapHandler(s.ThemeHandler.UpdateThemeFileByID))
 					themeRouter.GET("activation/template/custom/sheet", s.wrapHandler(s.ThemeHandler.ListCustomSheetTemplate))
 					themeRouter.GET("activation/template/custom/post", s.wrapHandler(s.ThemeHandler.ListCustomPostTemplate))
+					themeRouter.GET("activation/metas/post", s.wrapHandler(s.ThemeHandler.ListPostMetaFields))
+					themeRouter.GET("activation/metas/sheet", s.wrapHandler(s.ThemeHandler.ListSheetMetaFields))
 					themeRouter.POST("/:themeID/activation", s.wrapHandler(s.ThemeHandler.ActivateTheme))
 					themeRouter.GET("activation/configurations", s.wrapHandler(s.ThemeHandler.GetActivatedThemeConfig))
 					themeRouter.GET("/:themeID/configurations", s.wrapHandler(s.ThemeHandler.GetThemeConfigByID))
@@ -281,7 +336,7 @@ func (s *Server) RegisterRouters() {
 			contentRouter := router.Group("")
 			contentRouter.Use(s.LogMiddleware.LoggerWithConfig(middleware.GinLoggerConfig{}), s.RecoveryMiddleware.RecoveryWithLogger(), s.InstallRedirectMiddleware.InstallRedirect())
 
-			contentRouter.POST("/content/:type/:slug/authentication", s.wrapHTMLHandler(s.ViewHandler.Authenticate))
+			contentRouter.POST("/content/:type/:slug/authentication", s.rateLimitHTML(middleware.RateLimitAuthentication), s.wrapHTMLHandler(s.ViewHandler.Authenticate))
 
 			contentRouter.GET("", s.wrapHTMLHandler(s.IndexHandler.Index))
 			contentRouter.GET("/page/:page", s.wrapHTMLHandler(s.IndexHandler.IndexPage))
@@ -292,9 +347,28 @@ func (s *Server) RegisterRouters() {
 			contentRouter.GET("/rss.xml", s.wrapTextHandler(s.FeedHandler.Feed))
 			contentRouter.GET("/feed", s.wrapTextHandler(s.FeedHandler.Feed))
 			contentRouter.GET("/feed.xml", s.wrapTextHandler(s.FeedHandler.Feed))
-			contentRouter.GET("/feed/categories/:slug", s.wrapTextHandler(s.FeedHandler.CategoryFeed))
+			contentRouter.GET("/feed.json", s.wrapDocumentHandler(jsonFeedContentType, s.FeedHandler.JSONFeed))
+			categoryFeed := s.wrapTextHandler(s.FeedHandler.CategoryFeed)
+			categoryJSONFeed := s.wrapDocumentHandler(jsonFeedContentType, s.FeedHandler.CategoryJSONFeed)
+			contentRouter.GET("/feed/categories/:slug", func(ctx *gin.Context) {
+				// the slug of the JSON feed ends with .json as a param can not be followed by a suffix
+				if strings.HasSuffix(ctx.Param("slug"), ".json") {
+					categoryJSONFeed(ctx)
+					return
+				}
+				categoryFeed(ctx)
+			})
 			contentRouter.GET("/atom/categories/:slug", s.wrapTextHandler(s.FeedHandler.CategoryAtom))
-			contentRouter.GET("/sitemap.xml", s.wrapTextHandler(s.FeedHandler.SitemapXML))
+			contentRouter.GET("/feed/tags/:slug", s.wrapTextHandler(s.FeedHandler.TagFeed))
+			contentRouter.GET("/atom/tags/:slug", s.wrapTextHandler(s.FeedHandler.TagAtom))
+			contentRouter.GET("/feed/journals", s.wrapTextHandler(s.FeedHandler.JournalFeed))
+			contentRouter.GET("/atom/journals", s.wrapTextHandler(s.FeedHandler.JournalAtom))
+			contentRouter.GET("/feed/comments", s.wrapTextHandler(s.FeedHandler.CommentFeed))
+			contentRouter.GET("/atom/comments", s.wrapTextHandler(s.FeedHandler.CommentAtom))
+			contentRouter.GET("/feed/posts/:postID/comments", s.wrapTextHandler(s.FeedHandler.PostCommentFeed))
+			contentRouter.GET("/atom/posts/:postID/comments", s.wrapTextHandler(s.FeedHandler.PostCommentAtom))
+			contentRouter.GET("/sitemap.xml", s.wrapXMLHandler(s.FeedHandler.SitemapXML))
+			contentRouter.GET("/sitemap/:name", s.wrapXMLHandler(s.FeedHandler.Sitemap))
 			contentRouter.GET("/sitemap.html", s.wrapHTMLHandler(s.FeedHandler.SitemapHTML))
 
 			contentRouter.GET("/version", s.wrapHandler(s.ViewHandler.Version))
@@ -303,6 +377,17 @@ func (s *Server) RegisterRouters() {
 			contentRouter.GET("/favicon", s.wrapHandler(s.ViewHandler.Favicon))
 			contentRouter.GET("/search", s.wrapHTMLHandler(s.ContentSearchHandler.Search))
 			contentRouter.GET("/search/page/:page", s.wrapHTMLHandler(s.ContentSearchHandler.PageSearch))
+			contentRouter.GET("/comments/unsubscribe", s.wrapHTMLHandler(s.SubscriptionHandler.UnsubscribeConfirm))
+			contentRouter.POST("/comments/unsubscribe", s.rateLimitHTML(middleware.RateLimitAuthentication), s.wrapHTMLHandler(s.SubscriptionHandler.Unsubscribe))
+			contentRouter.GET("/comments/subscribe", s.wrapHTMLHandler(s.SubscriptionHandler.SubscribeConfirm))
+			contentRouter.POST("/comments/subscribe", s.rateLimitHTML(middleware.RateLimitAuthentication), s.wrapHTMLHandler(s.SubscriptionHandler.Subscribe))
+			contentRouter.GET("/.well-known/webfinger", s.wrapActivityPubHandler(s.ContentActivityPubHandler.WebFinger))
+			contentRouter.GET("/activitypub/actor", s.wrapActivityPubHandler(s.ContentActivityPubHandler.Actor))
+			contentRouter.GET("/activitypub/outbox", s.wrapActivityPubHandler(s.ContentActivityPubHandler.Outbox))
+			contentRouter.GET("/activitypub/followers", s.wrapActivityPubHandler(s.ContentActivityPubHandler.Followers))
+			contentRouter.GET("/activitypub/following", s.wrapActivityPubHandler(s.ContentActivityPubHandler.Following))
+			contentRouter.GET("/activitypub/posts/:postID", s.wrapActivityPubHandler(s.ContentActivityPubHandler.Article))
+			contentRouter.POST("/activitypub/inbox", s.RateLimitMiddleware.RateLimit(middleware.RateLimitFederation), s.wrapActivityPubHandler(s.ContentActivityPubHandler.Inbox))
 			err := s.registerDynamicRouters(contentRouter)
 			if err != nil {
 				s.logger.DPanic("regiterDynamicRouters err", zap.Error(err))
@@ -324,30 +409,50 @@ func (s *Server) RegisterRouters() {
 			contentAPIRouter.GET("/journals/:journalID/comments/:parentID/children", s.wrapHandler(s.ContentAPIJournalHandler.ListChildren))
 			contentAPIRouter.GET("/journals/:journalID/comments/tree_view", s.wrapHandler(s.ContentAPIJournalHandler.ListCommentTree))
 			contentAPIRouter.GET("/journals/:journalID/comments/list_view", s.wrapHandler(s.ContentAPIJournalHandler.ListComment))
-			contentAPIRouter.POST("/journals/comments", s.wrapHandler(s.ContentAPIJournalHandler.CreateComment))
-			contentAPIRouter.POST("/journals/:journalID/likes", s.wrapHandler(s.ContentAPIJournalHandler.Like))
+			contentAPIRouter.GET("/journals/:journalID/comments/cursor_view", s.wrapHandler(s.ContentAPIJournalHandler.ListCommentByCursor))
+			contentAPIRouter.GET("/journals/:journalID/comments/:parentID/cursor_view", s.wrapHandler(s.ContentAPIJournalHandler.ListChildrenByCursor))
+			contentAPIRouter.POST("/journals/comments", s.RateLimitMiddleware.RateLimit(middleware.RateLimitComment), s.wrapHandler(s.ContentAPIJournalHandler.CreateComment))
+			contentAPIRouter.POST("/journals/:journalID/likes", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPIJournalHandler.Like))
+			contentAPIRouter.GET("/journals/:journalID/reactions", s.wrapHandler(s.ContentAPIJournalHandler.ListReactions))
+			contentAPIRouter.POST("/journals/:journalID/reactions", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPIJournalHandler.React))
 
-			contentAPIRouter.POST("/photos/:photoID/likes", s.wrapHandler(s.ContentAPIPhotoHandler.Like))
+			contentAPIRouter.POST("/photos/:photoID/likes", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPIPhotoHandler.Like))
+			contentAPIRouter.GET("/photos/:photoID/reactions", s.wrapHandler(s.ContentAPIPhotoHandler.ListReactions))
+			contentAPIRouter.POST("/photos/:photoID/reactions", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPIPhotoHandler.React))
 
 			contentAPIRouter.GET("/posts/:postID/comments/top_view", s.wrapHandler(s.ContentAPIPostHandler.ListTopComment))
 			contentAPIRouter.GET("/posts/:postID/comments/:parentID/children", s.wrapHandler(s.ContentAPIPostHandler.ListChildren))
 			contentAPIRouter.GET("/posts/:postID/comments/tree_view", s.wrapHandler(s.ContentAPIPostHandler.ListCommentTree))
 			contentAPIRouter.GET("/posts/:postID/comments/list_view", s.wrapHandler(s.ContentAPIPostHandler.ListComment))
-			contentAPIRouter.POST("/posts/comments", s.wrapHandler(s.ContentAPIPostHandler.CreateComment))
-			contentAPIRouter.POST("/posts/:postID/likes", s.wrapHandler(s.ContentAPIPostHandler.Like))
+			contentAPIRouter.GET("/posts/:postID/comments/cursor_view", s.wrapHandler(s.ContentAPIPostHandler.ListCommentByCursor))
+			contentAPIRouter.GET("/posts/:postID/comments/:parentID/cursor_view", s.wrapHandler(s.ContentAPIPostHandler.ListChildrenByCursor))
+			contentAPIRouter.POST("/posts/comments", s.RateLimitMiddleware.RateLimit(middleware.RateLimitComment), s.wrapHandler(s.ContentAPIPostHandler.CreateComment))
+			contentAPIRouter.POST("/posts/:postID/likes", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPIPostHandler.Like))
+			contentAPIRouter.GET("/posts/:postID/reactions", s.wrapHandler(s.ContentAPIPostHandler.ListReactions))
+			contentAPIRouter.POST("/posts/:postID/reactions", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPIPostHandler.React))
 
 			contentAPIRouter.GET("/sheets/:sheetID/comments/top_view", s.wrapHandler(s.ContentAPISheetHandler.ListTopComment))
 			contentAPIRouter.GET("/sheets/:sheetID/comments/:parentID/children", s.wrapHandler(s.ContentAPISheetHandler.ListChildren))
 			contentAPIRouter.GET("/sheets/:sheetID/comments/tree_view", s.wrapHandler(s.ContentAPISheetHandler.ListCommentTree))
 			contentAPIRouter.GET("/sheets/:sheetID/comments/list_view", s.wrapHandler(s.ContentAPISheetHandler.ListComment))
-			contentAPIRouter.POST("/sheets/comments", s.wrapHandler(s.ContentAPISheetHandler.CreateComment))
+			contentAPIRouter.GET("/sheets/:sheetID/comments/cursor_view", s.wrapHandler(s.ContentAPISheetHandler.ListCommentByCursor))
+			contentAPIRouter.GET("/sheets/:sheetID/comments/:parentID/cursor_view", s.wrapHandler(s.ContentAPISheetHandler.ListChildrenByCursor))
+			contentAPIRouter.POST("/sheets/comments", s.RateLimitMiddleware.RateLimit(middleware.RateLimitComment), s.wrapHandler(s.ContentAPISheetHandler.CreateComment))
 
 			contentAPIRouter.GET("/links", s.wrapHandler(s.ContentAPILinkHandler.ListLinks))
 			contentAPIRouter.GET("/links/team_view", s.wrapHandler(s.ContentAPILinkHandler.LinkTeamVO))
 
 			contentAPIRouter.GET("/options/comment", s.wrapHandler(s.ContentAPIOptionHandler.Comment))
 
-			contentAPIRouter.POST("/comments/:commentID/likes", s.wrapHandler(s.ContentAPICommentHandler.Like))
+			contentAPIRouter.GET("/captcha", s.RateLimitMiddleware.RateLimit(middleware.RateLimitCaptcha), s.wrapHandler(s.ContentAPICaptchaHandler.Generate))
+
+			contentAPIRouter.POST("/webmention", s.RateLimitMiddleware.RateLimit(middleware.RateLimitComment), s.wrapDocumentHandler(jsonContentType, s.ContentAPIWebmentionHandler.Receive))
+
+			contentAPIRouter.POST("/comments/:commentID/likes", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPICommentHandler.Like))
+			contentAPIRouter.GET("/comments/:commentID/reactions", s.wrapHandler(s.ContentAPICommentHandler.ListReactions))
+			contentAPIRouter.POST("/comments/:commentID/reactions", s.RateLimitMiddleware.RateLimit(middleware.RateLimitLike), s.wrapHandler(s.ContentAPICommentHandler.React))
+			contentAPIRouter.PUT("/comments/:commentID", s.RateLimitMiddleware.RateLimit(middleware.RateLimitComment), s.wrapHandler(s.ContentAPICommentHandler.UpdateByAuthor))
+			contentAPIRouter.DELETE("/comments/:commentID", s.RateLimitMiddleware.RateLimit(middleware.RateLimitComment), s.wrapHandler(s.ContentAPICommentHandler.DeleteByAuthor))
 		}
 	}
 }
@@ -25,115 +25,137 @@ import (
 )
 
 type Server struct {
-	logger                    *zap.Logger
-	Config                    *config.Config
-	HTTPServer                *http.Server
-	Router                    *gin.Engine
-	Template                  *template.Template
-	AuthMiddleware            *middleware.AuthMiddleware
-	LogMiddleware             *middleware.GinLoggerMiddleware
-	RecoveryMiddleware        *middleware.RecoveryMiddleware
-	InstallRedirectMiddleware *middleware.InstallRedirectMiddleware
-	OptionService             service.OptionService
-	ThemeService              service.ThemeService
-	SheetService              service.SheetService
-	AdminHandler              *admin.AdminHandler
-	AttachmentHandler         *admin.AttachmentHandler
-	BackupHandler             *admin.BackupHandler
-	CategoryHandler           *admin.CategoryHandler
-	InstallHandler            *admin.InstallHandler
-	JournalHandler            *admin.JournalHandler
-	JournalCommentHandler     *admin.JournalCommentHandler
-	LinkHandler               *admin.LinkHandler
-	LogHandler                *admin.LogHandler
-	MenuHandler               *admin.MenuHandler
-	OptionHandler             *admin.OptionHandler
-	PhotoHandler              *admin.PhotoHandler
-	PostHandler               *admin.PostHandler
-	PostCommentHandler        *admin.PostCommentHandler
-	SheetHandler              *admin.SheetHandler
-	SheetCommentHandler       *admin.SheetCommentHandler
-	StatisticHandler          *admin.StatisticHandler
-	TagHandler                *admin.TagHandler
-	ThemeHandler              *admin.ThemeHandler
-	UserHandler               *admin.UserHandler
-	EmailHandler              *admin.EmailHandler
-	IndexHandler              *content.IndexHandler
-	FeedHandler               *content.FeedHandler
-	ArchiveHandler            *content.ArchiveHandler
-	ViewHandler               *content.ViewHandler
-	ContentCategoryHandler    *content.CategoryHandler
-	ContentSheetHandler       *content.SheetHandler
-	ContentTagHandler         *content.TagHandler
-	ContentLinkHandler        *content.LinkHandler
-	ContentPhotoHandler       *content.PhotoHandler
-	ContentJournalHandler     *content.JournalHandler
-	ContentSearchHandler      *content.SearchHandler
-	ContentAPIArchiveHandler  *api.ArchiveHandler
-	ContentAPICategoryHandler *api.CategoryHandler
-	ContentAPIJournalHandler  *api.JournalHandler
-	ContentAPILinkHandler     *api.LinkHandler
-	ContentAPIPostHandler     *api.PostHandler
-	ContentAPISheetHandler    *api.SheetHandler
-	ContentAPIOptionHandler   *api.OptionHandler
-	ContentAPIPhotoHandler    *api.PhotoHandler
-	ContentAPICommentHandler  *api.CommentHandler
+	logger                      *zap.Logger
+	Config                      *config.Config
+	HTTPServer                  *http.Server
+	Router                      *gin.Engine
+	Template                    *template.Template
+	AuthMiddleware              *middleware.AuthMiddleware
+	LogMiddleware               *middleware.GinLoggerMiddleware
+	RecoveryMiddleware          *middleware.RecoveryMiddleware
+	InstallRedirectMiddleware   *middleware.InstallRedirectMiddleware
+	RateLimitMiddleware         *middleware.RateLimitMiddleware
+	OptionService               service.OptionService
+	ThemeService                service.ThemeService
+	SheetService                service.SheetService
+	AdminHandler                *admin.AdminHandler
+	AttachmentHandler           *admin.AttachmentHandler
+	BackupHandler               *admin.BackupHandler
+	CategoryHandler             *admin.CategoryHandler
+	CommentBlackHandler         *admin.CommentBlackHandler
+	CommentSpamHandler          *admin.CommentSpamHandler
+	CommentRevisionHandler      *admin.CommentRevisionHandler
+	InstallHandler              *admin.InstallHandler
+	JournalHandler              *admin.JournalHandler
+	JournalCommentHandler       *admin.JournalCommentHandler
+	LinkHandler                 *admin.LinkHandler
+	LinkCheckHandler            *admin.LinkCheckHandler
+	LogHandler                  *admin.LogHandler
+	MenuHandler                 *admin.MenuHandler
+	OptionHandler               *admin.OptionHandler
+	PhotoHandler                *admin.PhotoHandler
+	PostHandler                 *admin.PostHandler
+	PostCommentHandler          *admin.PostCommentHandler
+	SheetHandler                *admin.SheetHandler
+	SheetCommentHandler         *admin.SheetCommentHandler
+	StatisticHandler            *admin.StatisticHandler
+	TagHandler                  *admin.TagHandler
+	ThemeHandler                *admin.ThemeHandler
+	UserHandler                 *admin.UserHandler
+	EmailHandler                *admin.EmailHandler
+	WebhookHandler              *admin.WebhookHandler
+	ActivityPubHandler          *admin.ActivityPubHandler
+	IndexHandler                *content.IndexHandler
+	FeedHandler                 *content.FeedHandler
+	ArchiveHandler              *content.ArchiveHandler
+	ViewHandler                 *content.ViewHandler
+	ContentCategoryHandler      *content.CategoryHandler
+	ContentSheetHandler         *content.SheetHandler
+	ContentTagHandler           *content.TagHandler
+	ContentLinkHandler          *content.LinkHandler
+	ContentPhotoHandler         *content.PhotoHandler
+	ContentJournalHandler       *content.JournalHandler
+	ContentSearchHandler        *content.SearchHandler
+	SubscriptionHandler         *content.CommentSubscriptionHandler
+	ContentActivityPubHandler   *content.ActivityPubHandler
+	ContentAPIArchiveHandler    *api.ArchiveHandler
+	ContentAPICategoryHandler   *api.CategoryHandler
+	ContentAPIJournalHandler    *api.JournalHandler
+	ContentAPILinkHandler       *api.LinkHandler
+	ContentAPIPostHandler       *api.PostHandler
+	ContentAPISheetHandler      *api.SheetHandler
+	ContentAPIOptionHandler     *api.OptionHandler
+	ContentAPIPhotoHandler      *api.PhotoHandler
+	ContentAPICommentHandler    *api.CommentHandler
+	ContentAPICaptchaHandler    *api.CaptchaHandler
+	ContentAPIWebmentionHandler *api.WebmentionHandler
 }
 
 type ServerParams struct {
 	dig.In
-	Config                    *config.Config
-	Logger                    *zap.Logger
-	Event                     event.Bus
-	Template                  *template.Template
-	AuthMiddleware            *middleware.AuthMiddleware
-	LogMiddleware             *middleware.GinLoggerMiddleware
-	RecoveryMiddleware        *middleware.RecoveryMiddleware
-	InstallRedirectMiddleware *middleware.InstallRedirectMiddleware
-	OptionService             service.OptionService
-	ThemeService              service.ThemeService
-	SheetService              service.SheetService
-	AdminHandler              *admin.AdminHandler
-	AttachmentHandler         *admin.AttachmentHandler
-	BackupHandler             *admin.BackupHandler
-	CategoryHandler           *admin.CategoryHandler
-	InstallHandler            *admin.InstallHandler
-	JournalHandler            *admin.JournalHandler
-	JournalCommentHandler     *admin.JournalCommentHandler
-	LinkHandler               *admin.LinkHandler
-	LogHandler                *admin.LogHandler
-	MenuHandler               *admin.MenuHandler
-	OptionHandler             *admin.OptionHandler
-	PhotoHandler              *admin.PhotoHandler
-	PostHandler               *admin.PostHandler
-	PostCommentHandler        *admin.PostCommentHandler
-	SheetHandler              *admin.SheetHandler
-	SheetCommentHandler       *admin.SheetCommentHandler
-	StatisticHandler          *admin.StatisticHandler
-	TagHandler                *admin.TagHandler
-	ThemeHandler              *admin.ThemeHandler
-	UserHandler               *admin.UserHandler
-	EmailHandler              *admin.EmailHandler
-	IndexHandler              *content.IndexHandler
-	FeedHandler               *content.FeedHandler
-	ArchiveHandler            *content.ArchiveHandler
-	ViewHandler               *content.ViewHandler
-	ContentCategoryHandler    *content.CategoryHandler
-	ContentSheetHandler       *content.SheetHandler
-	ContentTagHandler         *content.TagHandler
-	ContentLinkHandler        *content.LinkHandler
-	ContentPhotoHandler       *content.PhotoHandler
-	ContentJournalHandler     *content.JournalHandler
-	ContentSearchHandler      *content.SearchHandler
-	ContentAPIArchiveHandler  *api.ArchiveHandler
-	ContentAPICategoryHandler *api.CategoryHandler
-	ContentAPIJournalHandler  *api.JournalHandler
-	ContentAPILinkHandler     *api.LinkHandler
-	ContentAPIPostHandler     *api.PostHandler
-	ContentAPISheetHandler    *api.SheetHandler
-	ContentAPIOptionHandler   *api.OptionHandler
-	ContentAPIPhotoHandler    *api.PhotoHandler
-	ContentAPICommentHandler  *api.CommentHandler
+	Config                      *config.Config
+	Logger                      *zap.Logger
+	Event                       event.Bus
+	Template                    *template.Template
+	AuthMiddleware              *middleware.AuthMiddleware
+	LogMiddleware               *middleware.GinLoggerMiddleware
+	RecoveryMiddleware          *middleware.RecoveryMiddleware
+	InstallRedirectMiddleware   *middleware.InstallRedirectMiddleware
+	RateLimitMiddleware         *middleware.RateLimitMiddleware
+	OptionService               service.OptionService
+	ThemeService                service.ThemeService
+	SheetService                service.SheetService
+	AdminHandler                *admin.AdminHandler
+	AttachmentHandler           *admin.AttachmentHandler
+	BackupHandler               *admin.BackupHandler
+	CategoryHandler             *admin.CategoryHandler
+	CommentBlackHandler         *admin.CommentBlackHandler
+	CommentSpamHandler          *admin.CommentSpamHandler
+	CommentRevisionHandler      *admin.CommentRevisionHandler
+	InstallHandler              *admin.InstallHandler
+	JournalHandler              *admin.JournalHandler
+	JournalCommentHandler       *admin.JournalCommentHandler
+	LinkHandler                 *admin.LinkHandler
+	LinkCheckHandler            *admin.LinkCheckHandler
+	LogHandler                  *admin.LogHandler
+	MenuHandler                 *admin.MenuHandler
+	OptionHandler               *admin.OptionHandler
+	PhotoHandler                *admin.PhotoHandler
+	PostHandler                 *admin.PostHandler
+	PostCommentHandler          *admin.PostCommentHandler
+	SheetHandler                *admin.SheetHandler
+	SheetCommentHandler         *admin.SheetCommentHandler
+	StatisticHandler            *admin.StatisticHandler
+	TagHandler                  *admin.TagHandler
+	ThemeHandler                *admin.ThemeHandler
+	UserHandler                 *admin.UserHandler
+	EmailHandler                *admin.EmailHandler
+	WebhookHandler              *admin.WebhookHandler
+	ActivityPubHandler          *admin.ActivityPubHandler
+	IndexHandler                *content.IndexHandler
+	FeedHandler                 *content.FeedHandler
+	ArchiveHandler              *content.ArchiveHandler
+	ViewHandler                 *content.ViewHandler
+	ContentCategoryHandler      *content.CategoryHandler
+	ContentSheetHandler         *content.SheetHandler
+	ContentTagHandler           *content.TagHandler
+	ContentLinkHandler          *content.LinkHandler
+	ContentPhotoHandler         *content.PhotoHandler
+	ContentJournalHandler       *content.JournalHandler
+	ContentSearchHandler        *content.SearchHandler
+	SubscriptionHandler         *content.CommentSubscriptionHandler
+	ContentActivityPubHandler   *content.ActivityPubHandler
+	ContentAPIArchiveHandler    *api.ArchiveHandler
+	ContentAPICategoryHandler   *api.CategoryHandler
+	ContentAPIJournalHandler    *api.JournalHandler
+	ContentAPILinkHandler       *api.LinkHandler
+	ContentAPIPostHandler       *api.PostHandler
+	ContentAPISheetHandler      *api.SheetHandler
+	ContentAPIOptionHandler     *api.OptionHandler
+	ContentAPIPhotoHandler      *api.PhotoHandler
+	ContentAPICommentHandler    *api.CommentHandler
+	ContentAPICaptchaHandler    *api.CaptchaHandler
+	ContentAPIWebmentionHandler *api.WebmentionHandler
 }
 
 func NewServer(param ServerParams, lifecycle fx.Lifecycle) *Server {
@@ -147,59 +169,70 @@ func NewServer(param ServerParams, lifecycle fx.Lifecycle) *Server {
 	}
 
 	s := &Server{
-		logger:                    param.Logger,
-		Config:                    param.Config,
-		HTTPServer:                httpServer,
-		Router:                    router,
-		Template:                  param.Template,
-		AuthMiddleware:            param.AuthMiddleware,
-		LogMiddleware:             param.LogMiddleware,
-		RecoveryMiddleware:        param.RecoveryMiddleware,
-		InstallRedirectMiddleware: param.InstallRedirectMiddleware,
-		AdminHandler:              param.AdminHandler,
-		AttachmentHandler:         param.AttachmentHandler,
-		BackupHandler:             param.BackupHandler,
-		CategoryHandler:           param.CategoryHandler,
-		InstallHandler:            param.InstallHandler,
-		JournalHandler:            param.JournalHandler,
-		JournalCommentHandler:     param.JournalCommentHandler,
-		LinkHandler:               param.LinkHandler,
-		LogHandler:                param.LogHandler,
-		MenuHandler:               param.MenuHandler,
-		OptionHandler:             param.OptionHandler,
-		PhotoHandler:              param.PhotoHandler,
-		PostHandler:               param.PostHandler,
-		PostCommentHandler:        param.PostCommentHandler,
-		SheetHandler:              param.SheetHandler,
-		SheetCommentHandler:       param.SheetCommentHandler,
-		StatisticHandler:          param.StatisticHandler,
-		TagHandler:                param.TagHandler,
-		ThemeHandler:              param.ThemeHandler,
-		UserHandler:               param.UserHandler,
-		EmailHandler:              param.EmailHandler,
-		OptionService:             param.OptionService,
-		ThemeService:              param.ThemeService,
-		SheetService:              param.SheetService,
-		IndexHandler:              param.IndexHandler,
-		FeedHandler:               param.FeedHandler,
-		ArchiveHandler:            param.ArchiveHandler,
-		ViewHandler:               param.ViewHandler,
-		ContentCategoryHandler:    param.ContentCategoryHandler,
-		ContentSheetHandler:       param.ContentSheetHandler,
-		ContentTagHandler:         param.ContentTagHandler,
-		ContentLinkHandler:        param.ContentLinkHandler,
-		ContentPhotoHandler:       param.ContentPhotoHandler,
-		ContentJournalHandler:     param.ContentJournalHandler,
-		ContentAPIArchiveHandler:  param.ContentAPIArchiveHandler,
-		ContentAPICategoryHandler: param.ContentAPICategoryHandler,
-		ContentAPIJournalHandler:  param.ContentAPIJournalHandler,
-		ContentAPILinkHandler:     param.ContentAPILinkHandler,
-		ContentAPIPostHandler:     param.ContentAPIPostHandler,
-		ContentAPISheetHandler:    param.ContentAPISheetHandler,
-		ContentAPIOptionHandler:   param.ContentAPIOptionHandler,
-		ContentSearchHandler:      param.ContentSearchHandler,
-		ContentAPIPhotoHandler:    param.ContentAPIPhotoHandler,
-		ContentAPICommentHandler:  param.ContentAPICommentHandler,
+		logger:                      param.Logger,
+		Config:                      param.Config,
+		HTTPServer:                  httpServer,
+		Router:                      router,
+		Template:                    param.Template,
+		AuthMiddleware:              param.AuthMiddleware,
+		LogMiddleware:               param.LogMiddleware,
+		RecoveryMiddleware:          param.RecoveryMiddleware,
+		InstallRedirectMiddleware:   param.InstallRedirectMiddleware,
+		RateLimitMiddleware:         param.RateLimitMiddleware,
+		AdminHandler:                param.AdminHandler,
+		AttachmentHandler:           param.AttachmentHandler,
+		BackupHandler:               param.BackupHandler,
+		CategoryHandler:             param.CategoryHandler,
+		CommentBlackHandler:         param.CommentBlackHandler,
+		CommentSpamHandler:          param.CommentSpamHandler,
+		CommentRevisionHandler:      param.CommentRevisionHandler,
+		InstallHandler:              param.InstallHandler,
+		JournalHandler:              param.JournalHandler,
+		JournalCommentHandler:       param.JournalCommentHandler,
+		LinkHandler:                 param.LinkHandler,
+		LinkCheckHandler:            param.LinkCheckHandler,
+		LogHandler:                  param.LogHandler,
+		MenuHandler:                 param.MenuHandler,
+		OptionHandler:               param.OptionHandler,
+		PhotoHandler:                param.PhotoHandler,
+		PostHandler:                 param.PostHandler,
+		PostCommentHandler:          param.PostCommentHandler,
+		SheetHandler:                param.SheetHandler,
+		SheetCommentHandler:         param.SheetCommentHandler,
+		StatisticHandler:            param.StatisticHandler,
+		TagHandler:                  param.TagHandler,
+		ThemeHandler:                param.ThemeHandler,
+		UserHandler:                 param.UserHandler,
+		EmailHandler:                param.EmailHandler,
+		WebhookHandler:              param.WebhookHandler,
+		ActivityPubHandler:          param.ActivityPubHandler,
+		OptionService:               param.OptionService,
+		ThemeService:                param.ThemeService,
+		SheetService:                param.SheetService,
+		IndexHandler:                param.IndexHandler,
+		FeedHandler:                 param.FeedHandler,
+		ArchiveHandler:              param.ArchiveHandler,
+		ViewHandler:                 param.ViewHandler,
+		ContentCategoryHandler:      param.ContentCategoryHandler,
+		ContentSheetHandler:         param.ContentSheetHandler,
+		ContentTagHandler:           param.ContentTagHandler,
+		ContentLinkHandler:          param.ContentLinkHandler,
+		ContentPhotoHandler:         param.ContentPhotoHandler,
+		ContentJournalHandler:       param.ContentJournalHandler,
+		ContentAPIArchiveHandler:    param.ContentAPIArchiveHandler,
+		ContentAPICategoryHandler:   param.ContentAPICategoryHandler,
+		ContentAPIJournalHandler:    param.ContentAPIJournalHandler,
+		ContentAPILinkHandler:       param.ContentAPILinkHandler,
+		ContentAPIPostHandler:       param.ContentAPIPostHandler,
+		ContentAPISheetHandler:      param.ContentAPISheetHandler,
+		ContentAPIOptionHandler:     param.ContentAPIOptionHandler,
+		ContentSearchHandler:        param.ContentSearchHandler,
+		SubscriptionHandler:         param.SubscriptionHandler,
+		ContentActivityPubHandler:   param.ContentActivityPubHandler,
+		ContentAPIPhotoHandler:      param.ContentAPIPhotoHandler,
+		ContentAPICommentHandler:    param.ContentAPICommentHandler,
+		ContentAPICaptchaHandler:    param.ContentAPICaptchaHandler,
+		ContentAPIWebmentionHandler: param.ContentAPIWebmentionHandler,
 	}
 	lifecycle.Append(fx.Hook{
 		OnStop:  httpServer.Shutdown,
@@ -243,6 +276,49 @@ func (s *Server) wrapHandler(handler wrapperHandler) gin.HandlerFunc {
 	}
 }
 
+var (
+	jsonContentType         = "application/json; charset=utf-8"
+	activityJSONContentType = "application/activity+json; charset=utf-8"
+	jsonFeedContentType     = "application/feed+json; charset=utf-8"
+)
+
+// wrapDocumentHandler writes the document as it is for other servers and feed readers, a handler returning no document accepts the request.
+// The content type set by the handler takes precedence over the given one.
+func (s *Server) wrapDocumentHandler(contentType string, handler wrapperHandler) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		data, err := handler(ctx)
+		if err != nil {
+			s.logger.Error("handler error", zap.Error(err))
+			ctx.JSON(xerr.GetHTTPStatus(err), gin.H{"error": xerr.GetMessage(err)})
+			return
+		}
+		if data == nil {
+			ctx.Status(http.StatusAccepted)
+			return
+		}
+		if ctx.Writer.Header().Get("Content-Type") == "" {
+			ctx.Header("Content-Type", contentType)
+		}
+		ctx.JSON(http.StatusOK, data)
+	}
+}
+
+func (s *Server) wrapActivityPubHandler(handler wrapperHandler) gin.HandlerFunc {
+	return s.wrapDocumentHandler(activityJSONContentType, handler)
+}
+
+// wrapXMLHandler writes the XML document generated by the handler, an error is rendered as the error page.
+func (s *Server) wrapXMLHandler(handler func(ctx *gin.Context) ([]byte, error)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		data, err := handler(ctx)
+		if err != nil {
+			s.handleError(ctx, err)
+			return
+		}
+		ctx.Data(http.StatusOK, xmlContentType[0], data)
+	}
+}
+
 type wrapperHTMLHandler func(ctx *gin.Context, model template.Model) (templateName string, err error)
 
 var (
@@ -291,6 +367,25 @@ func (s *Server) wrapTextHandler(handler wrapperHTMLHandler) gin.HandlerFunc {
 	}
 }
 
+// rateLimitHTML limits the requests like RateLimitMiddleware.RateLimit, but renders the error page for the pages.
+func (s *Server) rateLimitHTML(class middleware.RateLimitClass) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		wait, err := s.RateLimitMiddleware.Take(ctx, class)
+		if err != nil {
+			ctx.Status(http.StatusInternalServerError)
+			s.handleError(ctx, err)
+			ctx.Abort()
+			return
+		}
+		if wait > 0 {
+			ctx.Header("Retry-After", middleware.RetryAfter(wait))
+			ctx.Status(http.StatusTooManyRequests)
+			s.handleError(ctx, xerr.WithStatus(nil, http.StatusTooManyRequests).WithMsg("Too many requests, please try again later"))
+			ctx.Abort()
+		}
+	}
+}
+
 func (s *Server) handleError(ctx *gin.Context, err error) {
 	status := xerr.GetHTTPStatus(err)
 	message := xerr.GetMessage(err)
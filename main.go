@@ -47,6 +47,7 @@ func InitApp() *fx.App {
 			middleware.NewGinLoggerMiddleware,
 			middleware.NewRecoveryMiddleware,
 			middleware.NewInstallRedirectMiddleware,
+			middleware.NewRateLimitMiddleware,
 		),
 		fx.Populate(&dal.DB),
 		fx.Populate(&eventBus),
@@ -56,11 +57,16 @@ func InitApp() *fx.App {
 			listener.NewLogEventListener,
 			listener.NewPostUpdateListener,
 			listener.NewCommentListener,
+			listener.NewWebhookListener,
+			listener.NewWebmentionListener,
+			listener.NewActivityPubListener,
+			listener.NewSitemapListener,
 			extension.RegisterCategoryFunc,
 			extension.RegisterCommentFunc,
 			extension.RegisterTagFunc,
 			extension.RegisterMenuFunc,
 			extension.RegisterPhotoFunc,
+			extension.RegisterReactionFunc,
 			extension.RegisterLinkFunc,
 			extension.RegisterToolFunc,
 			extension.RegisterPaginationFunc,
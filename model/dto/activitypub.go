@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/go-sonic/sonic/consts"
+
+type ActivityPubFollower struct {
+	ID         int32  `json:"id"`
+	ActorID    string `json:"actorId"`
+	Username   string `json:"username"`
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	CreateTime int64  `json:"createTime"`
+}
+
+type ActivityPubDelivery struct {
+	ID             int32                            `json:"id"`
+	Inbox          string                           `json:"inbox"`
+	ActivityType   string                           `json:"activityType"`
+	PostID         int32                            `json:"postId"`
+	Payload        string                           `json:"payload"`
+	Status         consts.ActivityPubDeliveryStatus `json:"status"`
+	Attempts       int32                            `json:"attempts"`
+	ResponseStatus int32                            `json:"responseStatus"`
+	Error          string                           `json:"error"`
+	NextRetryTime  *int64                           `json:"nextRetryTime"`
+	CreateTime     int64                            `json:"createTime"`
+	UpdateTime     *int64                           `json:"updateTime"`
+}
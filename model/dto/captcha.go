@@ -0,0 +1,8 @@
+package dto
+
+type Captcha struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Image is the challenge as a PNG data URI
+	Image string `json:"image"`
+}
@@ -10,6 +10,7 @@ type Comment struct {
 	AuthorURL         string               `json:"authorUrl"`
 	GravatarMD5       string               `json:"gravatarMd5"`
 	Content           string               `json:"content"`
+	ContentHTML       string               `json:"contentHtml"`
 	Status            consts.CommentStatus `json:"status"`
 	UserAgent         string               `json:"userAgent"`
 	ParentID          int32                `json:"parentId"`
@@ -18,4 +19,10 @@ type Comment struct {
 	CreateTime        int64                `json:"createTime"`
 	Avatar            string               `json:"avatar"`
 	Likes             int32                `json:"likes"`
+	Reactions         map[string]int64     `json:"reactions,omitempty"`
+	Kind              consts.CommentKind   `json:"kind"`
+	// SourceURL is the page that sent the webmention, or the fediverse reply
+	SourceURL string `json:"sourceUrl,omitempty"`
+	// EditToken is only returned to the author when the comment is created
+	EditToken string `json:"editToken,omitempty"`
 }
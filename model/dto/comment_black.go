@@ -0,0 +1,12 @@
+package dto
+
+type CommentBlack struct {
+	ID         int32  `json:"id"`
+	IPAddress  string `json:"ipAddress"`
+	Email      string `json:"email"`
+	Author     string `json:"author"`
+	Reason     string `json:"reason"`
+	BanTime    int64  `json:"banTime"`
+	Permanent  bool   `json:"permanent"`
+	CreateTime int64  `json:"createTime"`
+}
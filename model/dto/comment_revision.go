@@ -0,0 +1,10 @@
+package dto
+
+type CommentRevision struct {
+	ID         int32  `json:"id"`
+	CommentID  int32  `json:"commentId"`
+	Content    string `json:"content"`
+	IPAddress  string `json:"ipAddress"`
+	UserAgent  string `json:"userAgent"`
+	CreateTime int64  `json:"createTime"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/go-sonic/sonic/consts"
+
+// SubscriptionToken is the content of the signed link confirming a subscription or unsubscribing
+type SubscriptionToken struct {
+	Email     string             `json:"email"`
+	Type      consts.CommentType `json:"type"`
+	ContentID int32              `json:"contentId"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// ImportReport describes the result of importing data exported by another blog system.
+type ImportReport struct {
+	// Imported is the number of imported records by item, e.g. posts, tags
+	Imported map[string]int `json:"imported"`
+	// Unmapped lists the records that were skipped and why
+	Unmapped []string `json:"unmapped"`
+}
@@ -13,5 +13,6 @@ type Journal struct {
 
 type JournalWithComment struct {
 	Journal
-	CommentCount int64 `json:"commentCount"`
+	CommentCount int64            `json:"commentCount"`
+	Reactions    map[string]int64 `json:"reactions"`
 }
@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/go-sonic/sonic/consts"
+
+// LinkCheckReport is the result of checking the links in the content of posts, sheets and journals.
+type LinkCheckReport struct {
+	Status       consts.LinkCheckStatus `json:"status"`
+	StartTime    int64                  `json:"startTime"`
+	FinishTime   int64                  `json:"finishTime"`
+	CheckedCount int                    `json:"checkedCount"`
+	BrokenCount  int                    `json:"brokenCount"`
+	BrokenLinks  []*BrokenLink          `json:"brokenLinks"`
+}
+
+type BrokenLink struct {
+	URL        string        `json:"url"`
+	Internal   bool          `json:"internal"`
+	StatusCode int           `json:"statusCode"`
+	Reason     string        `json:"reason"`
+	Sources    []*LinkSource `json:"sources"`
+}
+
+// LinkSource is the content that contains the link.
+type LinkSource struct {
+	Type  string `json:"type"`
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+	// Element is the name of the element containing the link, a or img
+	Element string `json:"element"`
+}
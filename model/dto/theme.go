@@ -21,6 +21,9 @@ type ThemeProperty struct {
 	ScreenShots    string                     `json:"screenshots"`
 	PostMetaField  []string                   `json:"postMetaField"`
 	SheetMetaField []string                   `json:"sheetMetaField"`
+	// PostMetaSchema and SheetMetaSchema declare the typed metas of posts and sheets
+	PostMetaSchema  []*MetaField `json:"postMetaSchema" yaml:"post_meta_schema"`
+	SheetMetaSchema []*MetaField `json:"sheetMetaSchema" yaml:"sheet_meta_schema"`
 }
 
 type ThemeAuthor struct {
@@ -55,6 +58,12 @@ type ThemeConfigItem struct {
 	Options      []*ThemeConfigOption        `json:"options"`
 }
 
+// MetaField is a meta of posts or sheets declared by the theme, the meta is validated and converted by its type.
+type MetaField struct {
+	ThemeConfigItem `yaml:",inline"`
+	Required        bool `json:"required"`
+}
+
 type ThemeConfigOption struct {
 	Label string      `json:"label"`
 	Value interface{} `json:"value"`
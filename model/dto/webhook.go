@@ -0,0 +1,29 @@
+package dto
+
+import "github.com/go-sonic/sonic/consts"
+
+// Webhook is the webhook in the responses, the secret is masked, sending the mask back in an update keeps the secret.
+type Webhook struct {
+	ID         int32    `json:"id"`
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	Events     []string `json:"events"`
+	Enabled    bool     `json:"enabled"`
+	CreateTime int64    `json:"createTime"`
+}
+
+type WebhookDelivery struct {
+	ID             int32                        `json:"id"`
+	WebhookID      int32                        `json:"webhookId"`
+	Event          string                       `json:"event"`
+	Payload        string                       `json:"payload"`
+	Status         consts.WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                        `json:"attempts"`
+	ResponseStatus int32                        `json:"responseStatus"`
+	ResponseBody   string                       `json:"responseBody"`
+	Error          string                       `json:"error"`
+	NextRetryTime  *int64                       `json:"nextRetryTime"`
+	CreateTime     int64                        `json:"createTime"`
+	UpdateTime     *int64                       `json:"updateTime"`
+}
@@ -32,6 +32,9 @@ type Comment struct {
 	TopPriority       int32                `gorm:"column:top_priority;type:int;not null" json:"top_priority"`
 	UserAgent         string               `gorm:"column:user_agent;type:varchar(511);not null" json:"user_agent"`
 	Likes             int32                `gorm:"column:likes;type:int;not null;default: 0" json:"likes"`
+	Kind              consts.CommentKind   `gorm:"column:kind;type:bigint;not null;default:0" json:"kind"`
+	SourceURL         string               `gorm:"column:source_url;type:varchar(1023);not null;default:''" json:"source_url"`
+	ActorID           string               `gorm:"column:actor_id;type:varchar(1023);not null;default:''" json:"actor_id"`
 }
 
 // TableName Comment's table name
@@ -17,6 +17,9 @@ type CommentBlack struct {
 	UpdateTime *time.Time `gorm:"column:update_time;type:datetime" json:"update_time"`
 	BanTime    time.Time  `gorm:"column:ban_time;type:datetime;not null" json:"ban_time"`
 	IPAddress  string     `gorm:"column:ip_address;type:varchar(127);not null" json:"ip_address"`
+	Email      string     `gorm:"column:email;type:varchar(255);not null;default:''" json:"email"`
+	Author     string     `gorm:"column:author;type:varchar(50);not null;default:''" json:"author"`
+	Reason     string     `gorm:"column:reason;type:varchar(255);not null;default:''" json:"reason"`
 }
 
 // TableName CommentBlack's table name
@@ -211,3 +211,111 @@ func (m *Meta) BeforeUpdate(tx *gorm.DB) (err error) {
 	tx.Statement.SetColumn("update_time", time.Now())
 	return nil
 }
+
+// ----------------------- CommentSpamToken ---------------------
+
+func (m *CommentSpamToken) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *CommentSpamToken) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- CommentSubscription ---------------------
+
+func (m *CommentSubscription) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *CommentSubscription) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- CommentRevision ---------------------
+
+func (m *CommentRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *CommentRevision) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- Reaction ---------------------
+
+func (m *Reaction) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *Reaction) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- Webhook ---------------------
+
+func (m *Webhook) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *Webhook) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- WebhookDelivery ---------------------
+
+func (m *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *WebhookDelivery) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- ActivityPubArticle ---------------------
+
+func (m *ActivityPubArticle) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *ActivityPubArticle) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- ActivityPubFollower ---------------------
+
+func (m *ActivityPubFollower) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *ActivityPubFollower) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
+
+// ----------------------- ActivityPubDelivery ---------------------
+
+func (m *ActivityPubDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreateTime = time.Now()
+	return nil
+}
+
+func (m *ActivityPubDelivery) BeforeUpdate(tx *gorm.DB) (err error) {
+	tx.Statement.SetColumn("update_time", time.Now())
+	return nil
+}
@@ -1,5 +1,7 @@
 package param
 
 type Authentication struct {
-	Password string `json:"password" form:"password"`
+	Password      string `json:"password" form:"password"`
+	CaptchaID     string `json:"captchaId" form:"captchaId"`
+	CaptchaAnswer string `json:"captchaAnswer" form:"captchaAnswer"`
 }
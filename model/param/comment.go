@@ -20,6 +20,12 @@ type Comment struct {
 	ParentID          int32              `json:"parentId" form:"parentId" binding:"gte=0"`
 	AllowNotification bool               `json:"allowNotification" form:"allowNotification"`
 	CommentType       consts.CommentType `json:"-"`
+	// Honeypot is a hidden field of the comment form, only bots fill it
+	Honeypot      string `json:"honeypot" form:"honeypot"`
+	CaptchaID     string `json:"captchaId" form:"captchaId"`
+	CaptchaAnswer string `json:"captchaAnswer" form:"captchaAnswer"`
+	// SubscribeThread receives the mails of all the comments of the content, not only the replies, once the email is confirmed by a mail
+	SubscribeThread bool `json:"subscribeThread" form:"subscribeThread"`
 }
 
 type AdminComment struct {
@@ -32,3 +38,22 @@ type AdminComment struct {
 	AllowNotification bool               `json:"allowNotification"`
 	CommentType       consts.CommentType `json:"-"`
 }
+
+// CommentCursorQuery pages the published comments by the cursor returned with the previous page.
+type CommentCursorQuery struct {
+	ContentID int32
+	ParentID  int32
+	Cursor    string `json:"cursor" form:"cursor"`
+	Sort      string `json:"sort" form:"sort" binding:"omitempty,oneof=newest oldest likes top"`
+	Size      int    `json:"size" form:"size" binding:"gte=0,lte=100"`
+}
+
+// CommentAuthorEdit is the change of a comment by its author, Token is returned when the comment is created.
+type CommentAuthorEdit struct {
+	Token   string `json:"token" binding:"required"`
+	Content string `json:"content" binding:"gte=1,lte=1023"`
+}
+
+type CommentAuthorDelete struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,10 @@
+package param
+
+type CommentBlack struct {
+	IPAddress string `json:"ipAddress" form:"ipAddress" binding:"omitempty,ip"`
+	Email     string `json:"email" form:"email" binding:"omitempty,email,lte=255"`
+	Author    string `json:"author" form:"author" binding:"lte=50"`
+	Reason    string `json:"reason" form:"reason" binding:"lte=255"`
+	// BanTime is the expiry time in milliseconds, the ban is permanent if it is empty
+	BanTime *int64 `json:"banTime" form:"banTime"`
+}
@@ -0,0 +1,8 @@
+package param
+
+// ExportComment selects the comments to export, ContentID needs Type to tell a post from a journal.
+type ExportComment struct {
+	Format    string `json:"format" binding:"required,oneof=csv json wxr"`
+	Type      string `json:"type" binding:"omitempty,oneof=post sheet journal"`
+	ContentID int32  `json:"contentId" binding:"gte=0"`
+}
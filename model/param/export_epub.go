@@ -0,0 +1,11 @@
+package param
+
+// ExportEpub selects the posts of an e-book, only one of CategoryID, TagID and PostIDs is used.
+// Only the published posts are exported.
+type ExportEpub struct {
+	Title       string  `json:"title" binding:"lte=255"`
+	Description string  `json:"description"`
+	CategoryID  *int32  `json:"categoryId"`
+	TagID       *int32  `json:"tagId"`
+	PostIDs     []int32 `json:"postIds"`
+}
@@ -40,3 +40,15 @@ type PostQuery struct {
 	TagID        *int32               `json:"tagId" form:"tagId"`
 	WithPassword *bool                `json:"-" form:"-"`
 }
+
+// PostBatchUpdate describes the changes applied to many posts at once, nil fields are left unchanged.
+type PostBatchUpdate struct {
+	PostIDs           []int32 `json:"postIds" form:"postIds" binding:"required,min=1"`
+	AddCategoryIDs    []int32 `json:"addCategoryIds" form:"addCategoryIds"`
+	RemoveCategoryIDs []int32 `json:"removeCategoryIds" form:"removeCategoryIds"`
+	AddTagIDs         []int32 `json:"addTagIds" form:"addTagIds"`
+	RemoveTagIDs      []int32 `json:"removeTagIds" form:"removeTagIds"`
+	Template          *string `json:"template" form:"template" binding:"omitempty,lte=255"`
+	DisallowComment   *bool   `json:"disallowComment" form:"disallowComment"`
+	TopPriority       *int32  `json:"topPriority" form:"topPriority" binding:"omitempty,gte=0"`
+}
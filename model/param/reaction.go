@@ -0,0 +1,5 @@
+package param
+
+type Reaction struct {
+	Reaction string `json:"reaction" binding:"required"`
+}
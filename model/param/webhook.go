@@ -0,0 +1,9 @@
+package param
+
+type Webhook struct {
+	Name    string   `json:"name" form:"name" binding:"required,lte=255"`
+	URL     string   `json:"url" form:"url" binding:"required,url,lte=1023"`
+	Secret  string   `json:"secret" form:"secret" binding:"lte=255"`
+	Events  []string `json:"events" form:"events" binding:"required,min=1"`
+	Enabled bool     `json:"enabled" form:"enabled"`
+}
@@ -0,0 +1,7 @@
+package param
+
+// Webmention is sent by other sites as a form, see https://www.w3.org/TR/webmention/
+type Webmention struct {
+	Source string `form:"source" binding:"required,url"`
+	Target string `form:"target" binding:"required,url"`
+}
@@ -0,0 +1,29 @@
+package property
+
+import "reflect"
+
+var (
+	// ActivityPubEnabled lets the fediverse users follow the blog and reply to the posts
+	ActivityPubEnabled = Property{
+		KeyValue:     "activitypub_enabled",
+		DefaultValue: false,
+		Kind:         reflect.Bool,
+	}
+	// ActivityPubUsername is the name of the blog in the fediverse, such as blog@example.com
+	ActivityPubUsername = Property{
+		KeyValue:     "activitypub_username",
+		DefaultValue: "blog",
+		Kind:         reflect.String,
+	}
+	// ActivityPubPrivateKey and ActivityPubPublicKey sign the activities sent by the blog, they are generated when they are needed first
+	ActivityPubPrivateKey = Property{
+		KeyValue:     "activitypub_private_key",
+		DefaultValue: "",
+		Kind:         reflect.String,
+	}
+	ActivityPubPublicKey = Property{
+		KeyValue:     "activitypub_public_key",
+		DefaultValue: "",
+		Kind:         reflect.String,
+	}
+)
@@ -92,11 +92,43 @@ var AllProperty = []Property{
 	CommentReplyNotice,
 	CommentAPIEnabled,
 	CommentPageSize,
+	CommentMaxDepth,
+	CommentEditWindow,
 	CommentContentPlaceholder,
 	CommentInternalPluginJs,
 	CommentGravatarSource,
 	CommentBanTime,
 	CommentRange,
+	CommentSpamCheckEnabled,
+	CommentSpamMaxLinks,
+	CommentSpamBayesThreshold,
+	CommentAkismetEnabled,
+	CommentAkismetKey,
+	CommentAkismetEndpoint,
+	ReactionPostTypes,
+	ReactionJournalTypes,
+	ReactionPhotoTypes,
+	ReactionCommentTypes,
+	WebmentionEnabled,
+	ActivityPubEnabled,
+	ActivityPubUsername,
+	ActivityPubPrivateKey,
+	ActivityPubPublicKey,
+	RateLimitEnabled,
+	RateLimitCommentBurst,
+	RateLimitCommentPerMinute,
+	RateLimitLikeBurst,
+	RateLimitLikePerMinute,
+	RateLimitAuthenticationBurst,
+	RateLimitAuthenticationPerMinute,
+	RateLimitCaptchaBurst,
+	RateLimitCaptchaPerMinute,
+	RateLimitFederationBurst,
+	RateLimitFederationPerMinute,
+	CaptchaType,
+	CaptchaCommentEnabled,
+	CaptchaAuthenticationEnabled,
+	CaptchaAuthenticationFailures,
 	MinioEndpoint,
 	MinioBucketName,
 	MinioAccessKey,
@@ -0,0 +1,30 @@
+package property
+
+import "reflect"
+
+var (
+	// CaptchaType is image or arithmetic
+	CaptchaType = Property{
+		KeyValue:     "captcha_type",
+		DefaultValue: "image",
+		Kind:         reflect.String,
+	}
+	// CaptchaCommentEnabled requires the anonymous commenters to solve a captcha
+	CaptchaCommentEnabled = Property{
+		KeyValue:     "captcha_comment_enabled",
+		DefaultValue: false,
+		Kind:         reflect.Bool,
+	}
+	CaptchaAuthenticationEnabled = Property{
+		KeyValue:     "captcha_authentication_enabled",
+		DefaultValue: false,
+		Kind:         reflect.Bool,
+	}
+	// CaptchaAuthenticationFailures is the number of wrong passwords of the protected content
+	// before a captcha is required, 0 means it is always required
+	CaptchaAuthenticationFailures = Property{
+		KeyValue:     "captcha_authentication_failures",
+		DefaultValue: 3,
+		Kind:         reflect.Int,
+	}
+)
@@ -33,6 +33,18 @@ var (
 		DefaultValue: 10,
 		Kind:         reflect.Int,
 	}
+	// CommentMaxDepth is the levels of replies under a top-level comment, the deeper replies are shown on the last level, 0 means no limit
+	CommentMaxDepth = Property{
+		KeyValue:     "comment_max_depth",
+		DefaultValue: 0,
+		Kind:         reflect.Int,
+	}
+	// CommentEditWindow is the minutes in which the author can edit or delete the comment, 0 disables it
+	CommentEditWindow = Property{
+		KeyValue:     "comment_edit_window",
+		DefaultValue: 15,
+		Kind:         reflect.Int,
+	}
 	CommentContentPlaceholder = Property{
 		KeyValue:     "comment_content_placeholder",
 		DefaultValue: "",
@@ -58,4 +70,34 @@ var (
 		DefaultValue: 30,
 		Kind:         reflect.Int,
 	}
+	CommentSpamCheckEnabled = Property{
+		KeyValue:     "comment_spam_check_enabled",
+		DefaultValue: true,
+		Kind:         reflect.Bool,
+	}
+	CommentSpamMaxLinks = Property{
+		KeyValue:     "comment_spam_max_links",
+		DefaultValue: 3,
+		Kind:         reflect.Int,
+	}
+	CommentSpamBayesThreshold = Property{
+		KeyValue:     "comment_spam_bayes_threshold",
+		DefaultValue: 90,
+		Kind:         reflect.Int,
+	}
+	CommentAkismetEnabled = Property{
+		KeyValue:     "comment_akismet_enabled",
+		DefaultValue: false,
+		Kind:         reflect.Bool,
+	}
+	CommentAkismetKey = Property{
+		KeyValue:     "comment_akismet_key",
+		DefaultValue: "",
+		Kind:         reflect.String,
+	}
+	CommentAkismetEndpoint = Property{
+		KeyValue:     "comment_akismet_endpoint",
+		DefaultValue: "https://rest.akismet.com/1.1",
+		Kind:         reflect.String,
+	}
 )
@@ -0,0 +1,62 @@
+package property
+
+import "reflect"
+
+// the burst is the number of requests allowed at once, then the requests are allowed at the rate per minute
+var (
+	RateLimitEnabled = Property{
+		KeyValue:     "rate_limit_enabled",
+		DefaultValue: true,
+		Kind:         reflect.Bool,
+	}
+	RateLimitCommentBurst = Property{
+		KeyValue:     "rate_limit_comment_burst",
+		DefaultValue: 5,
+		Kind:         reflect.Int,
+	}
+	RateLimitCommentPerMinute = Property{
+		KeyValue:     "rate_limit_comment_per_minute",
+		DefaultValue: 2,
+		Kind:         reflect.Int,
+	}
+	RateLimitLikeBurst = Property{
+		KeyValue:     "rate_limit_like_burst",
+		DefaultValue: 10,
+		Kind:         reflect.Int,
+	}
+	RateLimitLikePerMinute = Property{
+		KeyValue:     "rate_limit_like_per_minute",
+		DefaultValue: 30,
+		Kind:         reflect.Int,
+	}
+	RateLimitAuthenticationBurst = Property{
+		KeyValue:     "rate_limit_authentication_burst",
+		DefaultValue: 5,
+		Kind:         reflect.Int,
+	}
+	RateLimitAuthenticationPerMinute = Property{
+		KeyValue:     "rate_limit_authentication_per_minute",
+		DefaultValue: 2,
+		Kind:         reflect.Int,
+	}
+	RateLimitCaptchaBurst = Property{
+		KeyValue:     "rate_limit_captcha_burst",
+		DefaultValue: 10,
+		Kind:         reflect.Int,
+	}
+	RateLimitCaptchaPerMinute = Property{
+		KeyValue:     "rate_limit_captcha_per_minute",
+		DefaultValue: 10,
+		Kind:         reflect.Int,
+	}
+	RateLimitFederationBurst = Property{
+		KeyValue:     "rate_limit_federation_burst",
+		DefaultValue: 60,
+		Kind:         reflect.Int,
+	}
+	RateLimitFederationPerMinute = Property{
+		KeyValue:     "rate_limit_federation_per_minute",
+		DefaultValue: 120,
+		Kind:         reflect.Int,
+	}
+)
@@ -0,0 +1,27 @@
+package property
+
+import "reflect"
+
+// the reactions are separated by commas, such as "like,heart,laugh", the like reaction is counted by the likes field
+var (
+	ReactionPostTypes = Property{
+		KeyValue:     "reaction_post_types",
+		DefaultValue: "like",
+		Kind:         reflect.String,
+	}
+	ReactionJournalTypes = Property{
+		KeyValue:     "reaction_journal_types",
+		DefaultValue: "like",
+		Kind:         reflect.String,
+	}
+	ReactionPhotoTypes = Property{
+		KeyValue:     "reaction_photo_types",
+		DefaultValue: "like",
+		Kind:         reflect.String,
+	}
+	ReactionCommentTypes = Property{
+		KeyValue:     "reaction_comment_types",
+		DefaultValue: "like",
+		Kind:         reflect.String,
+	}
+)
@@ -0,0 +1,10 @@
+package property
+
+import "reflect"
+
+// WebmentionEnabled receives the webmentions from other sites and sends the webmentions to the sites linked in the posts
+var WebmentionEnabled = Property{
+	KeyValue:     "webmention_enabled",
+	DefaultValue: false,
+	Kind:         reflect.Bool,
+}
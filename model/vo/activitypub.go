@@ -0,0 +1,85 @@
+package vo
+
+// WebFinger is the JSON resource descriptor of the blog actor, see https://www.rfc-editor.org/rfc/rfc7033
+type WebFinger struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases,omitempty"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
+
+// ActivityPubActor is the actor document of the blog
+type ActivityPubActor struct {
+	Context                   interface{}          `json:"@context"`
+	ID                        string               `json:"id"`
+	Type                      string               `json:"type"`
+	PreferredUsername         string               `json:"preferredUsername"`
+	Name                      string               `json:"name"`
+	Summary                   string               `json:"summary,omitempty"`
+	URL                       string               `json:"url"`
+	Icon                      *ActivityPubImage    `json:"icon,omitempty"`
+	Inbox                     string               `json:"inbox"`
+	Outbox                    string               `json:"outbox"`
+	Followers                 string               `json:"followers"`
+	Following                 string               `json:"following"`
+	Endpoints                 map[string]string    `json:"endpoints"`
+	PublicKey                 ActivityPubPublicKey `json:"publicKey"`
+	ManuallyApprovesFollowers bool                 `json:"manuallyApprovesFollowers"`
+	Discoverable              bool                 `json:"discoverable"`
+}
+
+type ActivityPubPublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+type ActivityPubImage struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// ActivityPubCollection is an OrderedCollection or a page of it
+type ActivityPubCollection struct {
+	Context      interface{}   `json:"@context,omitempty"`
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	TotalItems   *int64        `json:"totalItems,omitempty"`
+	First        string        `json:"first,omitempty"`
+	Last         string        `json:"last,omitempty"`
+	PartOf       string        `json:"partOf,omitempty"`
+	Next         string        `json:"next,omitempty"`
+	Prev         string        `json:"prev,omitempty"`
+	OrderedItems []interface{} `json:"orderedItems,omitempty"`
+}
+
+// ActivityPubObject is an activity or an article sent by the blog
+type ActivityPubObject struct {
+	Context      interface{}       `json:"@context,omitempty"`
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Actor        string            `json:"actor,omitempty"`
+	AttributedTo string            `json:"attributedTo,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Content      string            `json:"content,omitempty"`
+	URL          string            `json:"url,omitempty"`
+	Image        *ActivityPubImage `json:"image,omitempty"`
+	Published    string            `json:"published,omitempty"`
+	Updated      string            `json:"updated,omitempty"`
+	To           []string          `json:"to,omitempty"`
+	Cc           []string          `json:"cc,omitempty"`
+	Tag          []*ActivityPubTag `json:"tag,omitempty"`
+	Object       interface{}       `json:"object,omitempty"`
+	FormerType   string            `json:"formerType,omitempty"`
+}
+
+type ActivityPubTag struct {
+	Type string `json:"type"`
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
@@ -31,3 +31,10 @@ type CommentWithHasChildren struct {
 	HasChildren   bool  `json:"hasChildren"`
 	ChildrenCount int64 `json:"childrenCount"`
 }
+
+// CommentCursorPage is a page of comments, NextCursor is opaque and sent back as the cursor to load the next page.
+type CommentCursorPage struct {
+	Content    []*CommentWithHasChildren `json:"content"`
+	HasMore    bool                      `json:"hasMore"`
+	NextCursor *string                   `json:"nextCursor"`
+}
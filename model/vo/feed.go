@@ -0,0 +1,15 @@
+package vo
+
+import "time"
+
+// FeedEntry is an entry of the feeds of the journals and the comments
+type FeedEntry struct {
+	ID         string
+	Title      string
+	Link       string
+	Content    string
+	Author     string
+	AuthorURL  string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
@@ -0,0 +1,34 @@
+package vo
+
+// JSONFeed is a feed in the JSON Feed 1.1 format, see https://www.jsonfeed.org/version/1.1/
+type JSONFeed struct {
+	Version     string            `json:"version"`
+	Title       string            `json:"title"`
+	HomePageURL string            `json:"home_page_url"`
+	FeedURL     string            `json:"feed_url"`
+	Description string            `json:"description,omitempty"`
+	Icon        string            `json:"icon,omitempty"`
+	Favicon     string            `json:"favicon,omitempty"`
+	Authors     []*JSONFeedAuthor `json:"authors,omitempty"`
+	Items       []*JSONFeedItem   `json:"items"`
+}
+
+type JSONFeedAuthor struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type JSONFeedItem struct {
+	ID            string            `json:"id"`
+	URL           string            `json:"url"`
+	Title         string            `json:"title"`
+	ContentHTML   string            `json:"content_html,omitempty"`
+	ContentText   string            `json:"content_text,omitempty"`
+	Summary       string            `json:"summary,omitempty"`
+	Image         string            `json:"image,omitempty"`
+	DatePublished string            `json:"date_published"`
+	DateModified  string            `json:"date_modified,omitempty"`
+	Authors       []*JSONFeedAuthor `json:"authors,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+}
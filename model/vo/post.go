@@ -8,6 +8,7 @@ type Post struct {
 	Tags         []*dto.Tag             `json:"tags"`
 	Categories   []*dto.CategoryDTO     `json:"categories"`
 	Metas        map[string]interface{} `json:"metas"`
+	Reactions    map[string]int64       `json:"reactions"`
 }
 
 type PostDetailVO struct {
@@ -18,4 +19,5 @@ type PostDetailVO struct {
 	Categories  []*dto.CategoryDTO `json:"categories"`
 	MetaIDs     []int32            `json:"metaIds"`
 	Metas       []*dto.Meta        `json:"metas"`
+	Reactions   map[string]int64   `json:"reactions"`
 }
@@ -0,0 +1,9 @@
+package vo
+
+type ReactionSummary struct {
+	// Allowed is the configured reactions in order
+	Allowed   []string         `json:"allowed"`
+	Reactions map[string]int64 `json:"reactions"`
+	// Current is the reaction of the visitor, empty if the visitor hasn't reacted
+	Current string `json:"current"`
+}
@@ -0,0 +1,23 @@
+package vo
+
+// SeoMeta is the metadata of a page for the search engines and the social networks,
+// it is rendered as the Open Graph and Twitter Card properties and the JSON-LD structured data.
+type SeoMeta struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Keywords     string `json:"keywords"`
+	CanonicalURL string `json:"canonicalUrl"`
+	Image        string `json:"image"`
+	// Type is the Open Graph type, website or article
+	Type     string `json:"type"`
+	SiteName string `json:"siteName"`
+	Author   string `json:"author"`
+	// PublishedTime and ModifiedTime are in RFC3339, they are only set for the articles
+	PublishedTime string   `json:"publishedTime"`
+	ModifiedTime  string   `json:"modifiedTime"`
+	Tags          []string `json:"tags"`
+	// TwitterCard is summary_large_image when the page has an image, otherwise summary
+	TwitterCard string `json:"twitterCard"`
+	// JSONLD is the schema.org graph of the page, it is marshaled to JSON by the template
+	JSONLD map[string]interface{} `json:"jsonLd"`
+}
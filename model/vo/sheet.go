@@ -4,11 +4,13 @@ import "github.com/go-sonic/sonic/model/dto"
 
 type SheetDetail struct {
 	dto.PostDetail
-	MetaIDs []int32     `json:"metaIds"`
-	Metas   []*dto.Meta `json:"metas"`
+	MetaIDs   []int32          `json:"metaIds"`
+	Metas     []*dto.Meta      `json:"metas"`
+	Reactions map[string]int64 `json:"reactions"`
 }
 
 type SheetList struct {
 	dto.Post
-	CommentCount int64 `json:"commentCount"`
+	CommentCount int64            `json:"commentCount"`
+	Reactions    map[string]int64 `json:"reactions"`
 }
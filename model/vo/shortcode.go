@@ -0,0 +1,28 @@
+package vo
+
+import "html/template"
+
+// Shortcode is passed to the template of a shortcode as .shortcode
+type Shortcode struct {
+	Name string
+	// Params holds the named params, Positional holds the positional params
+	Params     map[string]any
+	Positional []any
+	// Inner is the rendered content between the opening and the closing shortcode
+	Inner template.HTML
+}
+
+// Get returns the positional param if key is an int, otherwise the named param.
+func (s *Shortcode) Get(key any) any {
+	if index, ok := key.(int); ok {
+		if index < 0 || index >= len(s.Positional) {
+			return nil
+		}
+		return s.Positional[index]
+	}
+	name, ok := key.(string)
+	if !ok {
+		return nil
+	}
+	return s.Params[name]
+}
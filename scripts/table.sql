@@ -41,10 +41,13 @@ create table if not exists category
 create table if not exists comment_black
 (
     id          int auto_increment primary key,
-    create_time datetime(6)  not null,
-    update_time datetime(6)  null,
-    ban_time    datetime(6)  not null,
-    ip_address  varchar(127) not null
+    create_time datetime(6)             not null,
+    update_time datetime(6)             null,
+    ban_time    datetime(6)             not null,
+    ip_address  varchar(127)            not null,
+    email       varchar(255) default '' not null,
+    author      varchar(50)  default '' not null,
+    reason      varchar(255) default '' not null
 ) ENGINE = INNODB
   DEFAULT charset = utf8mb4;
 
@@ -68,6 +71,9 @@ create table if not exists comment
     top_priority       int          default 0  not null,
     user_agent         varchar(511) default '' not null,
     likes              int          default 0 not null ,
+    kind               int          default 0  not null,
+    source_url         varchar(1023) default '' not null,
+    actor_id           varchar(1023) default '' not null,
     index comment_parent_id (parent_id),
     index comment_post_id (post_id),
     index comment_type_status (type, status)
@@ -295,3 +301,129 @@ create table if not exists user
 ) ENGINE = INNODB
   DEFAULT charset = utf8mb4;
 
+create table if not exists comment_spam_token
+(
+    id          int auto_increment primary key,
+    create_time datetime(6)  not null,
+    update_time datetime(6)  null,
+    token       varchar(255) not null,
+    spam_count  int          not null,
+    ham_count   int          not null,
+    unique index uniq_comment_spam_token (token)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists comment_subscription
+(
+    id          int auto_increment primary key,
+    create_time datetime(6)  not null,
+    update_time datetime(6)  null,
+    email       varchar(255) not null,
+    type        bigint       not null,
+    content_id  int          not null,
+    status      bigint       not null,
+    unique index uniq_comment_subscription (email, type, content_id)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists comment_revision
+(
+    id          int auto_increment primary key,
+    create_time datetime(6)   not null,
+    update_time datetime(6)   null,
+    comment_id  int           not null,
+    content     varchar(1023) not null,
+    ip_address  varchar(127)  not null,
+    user_agent  varchar(511)  not null,
+    index comment_revision_comment_id (comment_id)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists reaction
+(
+    id          int auto_increment primary key,
+    create_time datetime(6) not null,
+    update_time datetime(6) null,
+    target_type bigint      not null,
+    target_id   int         not null,
+    fingerprint varchar(64) not null,
+    reaction    varchar(32) not null,
+    unique index uniq_reaction (target_type, target_id, fingerprint)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists webhook
+(
+    id          int auto_increment primary key,
+    create_time datetime(6)   not null,
+    update_time datetime(6)   null,
+    name        varchar(255)  not null,
+    url         varchar(1023) not null,
+    secret      varchar(255)  not null,
+    events      varchar(1023) not null,
+    enabled     tinyint(1)    not null
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists webhook_delivery
+(
+    id              int auto_increment primary key,
+    create_time     datetime(6)   not null,
+    update_time     datetime(6)   null,
+    webhook_id      int           not null,
+    event           varchar(50)   not null,
+    payload         longtext      not null,
+    status          bigint        not null,
+    attempts        int           not null,
+    response_status int           not null,
+    response_body   varchar(1023) not null,
+    error           varchar(1023) not null,
+    next_retry_time datetime(6)   null,
+    index webhook_delivery_webhook_id (webhook_id),
+    index webhook_delivery_status_next_retry_time (status, next_retry_time)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists activitypub_follower
+(
+    id           int auto_increment primary key,
+    create_time  datetime(6)   not null,
+    update_time  datetime(6)   null,
+    actor_id     varchar(511)  not null,
+    username     varchar(255)  not null,
+    name         varchar(255)  not null,
+    url          varchar(1023) not null,
+    inbox        varchar(1023) not null,
+    shared_inbox varchar(1023) not null,
+    unique index uniq_activitypub_follower_actor_id (actor_id)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists activitypub_article
+(
+    id          int auto_increment primary key,
+    create_time datetime(6) not null,
+    update_time datetime(6) null,
+    post_id     int         not null,
+    unique index uniq_activitypub_article_post_id (post_id)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
+
+create table if not exists activitypub_delivery
+(
+    id              int auto_increment primary key,
+    create_time     datetime(6)   not null,
+    update_time     datetime(6)   null,
+    inbox           varchar(1023) not null,
+    activity_type   varchar(32)   not null,
+    post_id         int           not null,
+    payload         longtext      not null,
+    status          bigint        not null,
+    attempts        int           not null,
+    response_status int           not null,
+    error           varchar(1023) not null,
+    next_retry_time datetime(6)   null,
+    index activitypub_delivery_post_id (post_id),
+    index activitypub_delivery_status_next_retry_time (status, next_retry_time)
+) ENGINE = INNODB
+  DEFAULT charset = utf8mb4;
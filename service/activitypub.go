@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/vo"
+)
+
+// ActivityPubService federates the blog as a single actor, whose followers receive the published posts as articles.
+// The documents are not found while ActivityPub is disabled.
+type ActivityPubService interface {
+	WebFinger(ctx context.Context, resource string) (*vo.WebFinger, error)
+	GetActor(ctx context.Context) (*vo.ActivityPubActor, error)
+	// GetOutbox returns the collection of the published posts when the page is 0, and the given page of it otherwise
+	GetOutbox(ctx context.Context, page int) (*vo.ActivityPubCollection, error)
+	GetFollowers(ctx context.Context) (*vo.ActivityPubCollection, error)
+	GetFollowing(ctx context.Context) (*vo.ActivityPubCollection, error)
+	GetArticle(ctx context.Context, postID int32) (*vo.ActivityPubObject, error)
+	// HandleInbox verifies the HTTP signature of the request and handles the activity in the body,
+	// the activities the blog has nothing to do with are ignored.
+	HandleInbox(ctx context.Context, req *http.Request, body []byte) error
+	// PublishPost sends a Create, an Update or a Delete activity of the post to the followers.
+	// The post may have been deleted, an Update or a Delete is only sent if the post has been created in the fediverse.
+	PublishPost(ctx context.Context, post *entity.Post, activityType string) error
+	PageFollowers(ctx context.Context, page param.Page) ([]*entity.ActivityPubFollower, int64, error)
+	DeleteFollower(ctx context.Context, id int32) error
+	PageDeliveries(ctx context.Context, page param.Page) ([]*entity.ActivityPubDelivery, int64, error)
+	ConvertToFollowerDTOs(followers []*entity.ActivityPubFollower) []*dto.ActivityPubFollower
+	ConvertToDeliveryDTOs(deliveries []*entity.ActivityPubDelivery) []*dto.ActivityPubDelivery
+}
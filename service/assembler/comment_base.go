@@ -5,6 +5,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/log"
 	"github.com/go-sonic/sonic/model/dto"
 	"github.com/go-sonic/sonic/model/entity"
@@ -18,7 +19,10 @@ import (
 type BaseCommentAssembler interface {
 	ConvertToDTO(ctx context.Context, comment *entity.Comment) (*dto.Comment, error)
 	ConvertToDTOList(ctx context.Context, comments []*entity.Comment) ([]*dto.Comment, error)
-	PageConvertToVOs(ctx context.Context, comments []*entity.Comment, page param.Page) ([]*vo.Comment, int64, error)
+	// PageConvertToVOs builds the comment tree, the replies deeper than maxDepth are moved to the last level, 0 means no limit
+	PageConvertToVOs(ctx context.Context, comments []*entity.Comment, page param.Page, maxDepth int) ([]*vo.Comment, int64, error)
+	// ConvertToCursorPage converts a page of PageByCursor, the comments on the last level have no children to load
+	ConvertToCursorPage(ctx context.Context, comments []*entity.Comment, nextCursor string, lastLevel bool) (*vo.CommentCursorPage, error)
 	ConvertToWithParentVO(ctx context.Context, comments []*entity.Comment) ([]*vo.CommentWithParent, error)
 	ConvertToWithHasChildren(ctx context.Context, comments []*entity.Comment) ([]*vo.CommentWithHasChildren, error)
 	ClearSensitiveField(ctx context.Context, comments []*entity.Comment) []*entity.Comment
@@ -27,16 +31,19 @@ type BaseCommentAssembler interface {
 func NewBaseCommentAssembler(
 	optionService service.OptionService,
 	baseCommentService service.BaseCommentService,
+	reactionService service.ReactionService,
 ) BaseCommentAssembler {
 	return &baseCommentAssembler{
 		OptionService:      optionService,
 		BaseCommentService: baseCommentService,
+		ReactionService:    reactionService,
 	}
 }
 
 type baseCommentAssembler struct {
 	OptionService      service.OptionService
 	BaseCommentService service.BaseCommentService
+	ReactionService    service.ReactionService
 }
 
 func (*baseCommentAssembler) ClearSensitiveField(ctx context.Context, comments []*entity.Comment) []*entity.Comment {
@@ -86,6 +93,7 @@ func (b *baseCommentAssembler) ConvertToDTO(ctx context.Context, comment *entity
 		AuthorURL:         comment.AuthorURL,
 		GravatarMD5:       comment.GravatarMd5,
 		Content:           comment.Content,
+		ContentHTML:       b.BaseCommentService.RenderContent(ctx, comment.Content),
 		Status:            comment.Status,
 		UserAgent:         comment.UserAgent,
 		ParentID:          comment.ParentID,
@@ -93,6 +101,8 @@ func (b *baseCommentAssembler) ConvertToDTO(ctx context.Context, comment *entity
 		AllowNotification: comment.AllowNotification,
 		CreateTime:        comment.CreateTime.UnixMilli(),
 		Likes:             comment.Likes,
+		Kind:              comment.Kind,
+		SourceURL:         comment.SourceURL,
 	}
 	avatarURL, err := b.BaseCommentService.BuildAvatarURL(ctx, comment.GravatarMd5, nil, nil)
 	if err != nil {
@@ -111,6 +121,14 @@ func (b *baseCommentAssembler) ConvertToDTOList(ctx context.Context, comments []
 	if err != nil {
 		return nil, err
 	}
+	commentIDs := make([]int32, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	reactionMap, err := b.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetComment, commentIDs)
+	if err != nil {
+		return nil, err
+	}
 	result := make([]*dto.Comment, 0, len(comments))
 	for _, comment := range comments {
 		commentDTO := &dto.Comment{
@@ -121,6 +139,7 @@ func (b *baseCommentAssembler) ConvertToDTOList(ctx context.Context, comments []
 			AuthorURL:         comment.AuthorURL,
 			GravatarMD5:       comment.GravatarMd5,
 			Content:           comment.Content,
+			ContentHTML:       b.BaseCommentService.RenderContent(ctx, comment.Content),
 			Status:            comment.Status,
 			UserAgent:         comment.UserAgent,
 			ParentID:          comment.ParentID,
@@ -128,6 +147,9 @@ func (b *baseCommentAssembler) ConvertToDTOList(ctx context.Context, comments []
 			AllowNotification: comment.AllowNotification,
 			CreateTime:        comment.CreateTime.UnixMilli(),
 			Likes:             comment.Likes,
+			Reactions:         reactionMap[comment.ID],
+			Kind:              comment.Kind,
+			SourceURL:         comment.SourceURL,
 		}
 		avatarURL, err := b.BaseCommentService.BuildAvatarURL(ctx, comment.GravatarMd5, util.StringPtr(gravatarSource.(string)), util.StringPtr(gravatarDefault.(string)))
 		if err != nil {
@@ -139,7 +161,7 @@ func (b *baseCommentAssembler) ConvertToDTOList(ctx context.Context, comments []
 	return result, nil
 }
 
-func (b *baseCommentAssembler) buildCommentTree(ctx context.Context, comments []*entity.Comment) ([]*vo.Comment, error) {
+func (b *baseCommentAssembler) buildCommentTree(ctx context.Context, comments []*entity.Comment, maxDepth int) ([]*vo.Comment, error) {
 	commentIDMap := make(map[int32]*vo.Comment)
 	commentDTOs, err := b.ConvertToDTOList(ctx, comments)
 	if err != nil {
@@ -152,6 +174,21 @@ func (b *baseCommentAssembler) buildCommentTree(ctx context.Context, comments []
 		}
 		commentIDMap[commentDTO.ID] = commentVO
 	}
+	depthMap := make(map[int32]int)
+	var depthOf func(commentID int32) int
+	depthOf = func(commentID int32) int {
+		if depth, ok := depthMap[commentID]; ok {
+			return depth
+		}
+		// guards against a cycle of parents
+		depthMap[commentID] = 0
+		depth := 0
+		if commentVO, ok := commentIDMap[commentID]; ok && commentVO.ParentID != 0 {
+			depth = depthOf(commentVO.ParentID) + 1
+		}
+		depthMap[commentID] = depth
+		return depth
+	}
 	topComments := make([]*vo.Comment, 0)
 	for _, comment := range comments {
 		if comment.ParentID != 0 {
@@ -160,6 +197,9 @@ func (b *baseCommentAssembler) buildCommentTree(ctx context.Context, comments []
 				log.CtxWarn(ctx, "parent comment does not exist", zap.Int32("postID", comment.PostID), zap.Int32("parentID", comment.ParentID))
 				continue
 			}
+			for maxDepth > 0 && depthOf(parentComment.ID) >= maxDepth {
+				parentComment = commentIDMap[parentComment.ParentID]
+			}
 			parentComment.Children = append(parentComment.Children, commentIDMap[comment.ID])
 		} else {
 			topComments = append(topComments, commentIDMap[comment.ID])
@@ -168,8 +208,8 @@ func (b *baseCommentAssembler) buildCommentTree(ctx context.Context, comments []
 	return topComments, nil
 }
 
-func (b *baseCommentAssembler) PageConvertToVOs(ctx context.Context, allComments []*entity.Comment, page param.Page) ([]*vo.Comment, int64, error) {
-	topComments, err := b.buildCommentTree(ctx, allComments)
+func (b *baseCommentAssembler) PageConvertToVOs(ctx context.Context, allComments []*entity.Comment, page param.Page, maxDepth int) ([]*vo.Comment, int64, error) {
+	topComments, err := b.buildCommentTree(ctx, allComments, maxDepth)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -184,6 +224,27 @@ func (b *baseCommentAssembler) PageConvertToVOs(ctx context.Context, allComments
 	return topComments[startIndex:endIndex], int64(len(topComments)), nil
 }
 
+func (b *baseCommentAssembler) ConvertToCursorPage(ctx context.Context, comments []*entity.Comment, nextCursor string, lastLevel bool) (*vo.CommentCursorPage, error) {
+	commentVOs, err := b.ConvertToWithHasChildren(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+	if lastLevel {
+		for _, commentVO := range commentVOs {
+			commentVO.HasChildren = false
+			commentVO.ChildrenCount = 0
+		}
+	}
+	page := &vo.CommentCursorPage{
+		Content: commentVOs,
+		HasMore: nextCursor != "",
+	}
+	if nextCursor != "" {
+		page.NextCursor = &nextCursor
+	}
+	return page, nil
+}
+
 func (b *baseCommentAssembler) ConvertToWithParentVO(ctx context.Context, comments []*entity.Comment) ([]*vo.CommentWithParent, error) {
 	parentIDs := make([]int32, 0)
 	for _, comment := range comments {
@@ -30,6 +30,7 @@ func NewPostAssembler(basePostService service.BasePostService,
 	postCommentService service.PostCommentService,
 	metaService service.MetaService,
 	basePostAssembler BasePostAssembler,
+	reactionService service.ReactionService,
 ) PostAssembler {
 	return &postAssembler{
 		BasePostAssembler:   basePostAssembler,
@@ -41,6 +42,7 @@ func NewPostAssembler(basePostService service.BasePostService,
 		TagService:          tagService,
 		CategoryService:     categoryService,
 		MetaService:         metaService,
+		ReactionService:     reactionService,
 	}
 }
 
@@ -54,6 +56,7 @@ type postAssembler struct {
 	CategoryService     service.CategoryService
 	PostCommentService  service.PostCommentService
 	MetaService         service.MetaService
+	ReactionService     service.ReactionService
 }
 
 func (p *postAssembler) ConvertToListVO(ctx context.Context, posts []*entity.Post) ([]*vo.Post, error) {
@@ -104,8 +107,13 @@ func (p *postAssembler) ConvertToListVO(ctx context.Context, posts []*entity.Pos
 	if err != nil {
 		return nil, err
 	}
+	reactionMap, err := p.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetPost, postIDs)
+	if err != nil {
+		return nil, err
+	}
 	for _, post := range posts {
 		postVO := &vo.Post{}
+		postVO.Reactions = reactionMap[post.ID]
 		if commentCount, ok := commentCountMap[post.ID]; ok {
 			postVO.CommentCount = commentCount
 		}
@@ -202,6 +210,11 @@ func (p *postAssembler) ConvertToDetailVO(ctx context.Context, post *entity.Post
 	postDetailVO.MetaIDs = metaIDs
 	postDetailVO.Metas = metaDTOs
 
+	reactionMap, err := p.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetPost, []int32{post.ID})
+	if err != nil {
+		return nil, err
+	}
+	postDetailVO.Reactions = reactionMap[post.ID]
 	return postDetailVO, nil
 }
 
@@ -248,8 +261,13 @@ func (p *postAssembler) ConvertToDetailVOs(ctx context.Context, posts []*entity.
 	if err != nil {
 		return nil, err
 	}
+	reactionMap, err := p.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetPost, postIDs)
+	if err != nil {
+		return nil, err
+	}
 	for _, post := range posts {
 		postDetailVO := &vo.PostDetailVO{}
+		postDetailVO.Reactions = reactionMap[post.ID]
 		if categories, ok := categoryMap[post.ID]; ok {
 			categoryDTOs := make([]*dto.CategoryDTO, 0)
 			categoryIDs := make([]int32, 0)
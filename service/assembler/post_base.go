@@ -18,16 +18,19 @@ type BasePostAssembler interface {
 func NewBasePostAssembler(
 	basePostService service.BasePostService,
 	baseCommentService service.BaseCommentService,
+	shortcodeService service.ShortcodeService,
 ) BasePostAssembler {
 	return &basePostAssembler{
 		BasePostService:    basePostService,
 		BaseCommentService: baseCommentService,
+		ShortcodeService:   shortcodeService,
 	}
 }
 
 type basePostAssembler struct {
 	BasePostService    service.BasePostService
 	BaseCommentService service.BaseCommentService
+	ShortcodeService   service.ShortcodeService
 }
 
 func (p *basePostAssembler) ConvertToSimpleDTO(ctx context.Context, post *entity.Post) (*dto.Post, error) {
@@ -50,7 +53,11 @@ func (p *basePostAssembler) ConvertToSimpleDTO(ctx context.Context, post *entity
 	postDTO.PostMinimal = *postMinimal
 
 	if post.Summary == "" {
-		postDTO.Summary = p.BasePostService.GenerateSummary(ctx, post.FormatContent)
+		content, err := p.ShortcodeService.Render(ctx, post.FormatContent)
+		if err != nil {
+			return nil, err
+		}
+		postDTO.Summary = p.BasePostService.GenerateSummary(ctx, content)
 	}
 	return postDTO, nil
 }
@@ -90,10 +97,15 @@ func (p *basePostAssembler) ConvertToDetailDTO(ctx context.Context, post *entity
 	if err != nil {
 		return nil, err
 	}
+	// the shortcodes are rendered here, so that the pages, the feeds and the content api show the same content
+	content, err := p.ShortcodeService.Render(ctx, post.FormatContent)
+	if err != nil {
+		return nil, err
+	}
 	postDetailDTO := &dto.PostDetail{
 		Post:            *postSimple,
 		OriginalContent: post.OriginalContent,
-		Content:         post.FormatContent,
+		Content:         content,
 	}
 	commentCount, err := p.BaseCommentService.CountByContentID(ctx, post.ID, consts.CommentTypePost, consts.CommentStatusPublished)
 	if err != nil {
@@ -21,11 +21,13 @@ func NewSheetAssembler(
 	metaService service.MetaService,
 	basePostAssembler BasePostAssembler,
 	sheetCommentService service.SheetCommentService,
+	reactionService service.ReactionService,
 ) SheetAssembler {
 	return &sheetAssembler{
 		BasePostAssembler:   basePostAssembler,
 		MetaService:         metaService,
 		SheetCommentService: sheetCommentService,
+		ReactionService:     reactionService,
 	}
 }
 
@@ -33,6 +35,7 @@ type sheetAssembler struct {
 	BasePostAssembler
 	SheetCommentService service.SheetCommentService
 	MetaService         service.MetaService
+	ReactionService     service.ReactionService
 }
 
 func (s *sheetAssembler) ConvertToDetailVO(ctx context.Context, sheet *entity.Post) (*vo.SheetDetail, error) {
@@ -52,15 +55,28 @@ func (s *sheetAssembler) ConvertToDetailVO(ctx context.Context, sheet *entity.Po
 		metaIDs = append(metaIDs, meta.ID)
 		metaDTOs = append(metaDTOs, s.MetaService.ConvertToMetaDTO(meta))
 	}
+	reactionMap, err := s.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetPost, []int32{sheet.ID})
+	if err != nil {
+		return nil, err
+	}
 
 	sheetDetailVO.PostDetail = *detailDTO
 	sheetDetailVO.MetaIDs = metaIDs
 	sheetDetailVO.Metas = metaDTOs
+	sheetDetailVO.Reactions = reactionMap[sheet.ID]
 	return &sheetDetailVO, nil
 }
 
 func (s *sheetAssembler) ConvertToListVO(ctx context.Context, sheets []*entity.Post) ([]*vo.SheetList, error) {
 	sheetListVOs := make([]*vo.SheetList, 0, len(sheets))
+	sheetIDs := make([]int32, 0, len(sheets))
+	for _, sheet := range sheets {
+		sheetIDs = append(sheetIDs, sheet.ID)
+	}
+	reactionMap, err := s.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetPost, sheetIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, sheet := range sheets {
 		var sheetListVO vo.SheetList
@@ -73,6 +89,7 @@ func (s *sheetAssembler) ConvertToListVO(ctx context.Context, sheets []*entity.P
 			return nil, err
 		}
 		sheetListVO.CommentCount = commentCount
+		sheetListVO.Reactions = reactionMap[sheet.ID]
 		sheetListVO.Post = *postDTO
 		sheetListVOs = append(sheetListVOs, &sheetListVO)
 	}
@@ -5,6 +5,7 @@ import (
 	"mime/multipart"
 
 	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/param"
 )
 
 type BackupService interface {
@@ -22,8 +23,22 @@ type BackupService interface {
 	ExportData(ctx context.Context) (*dto.BackupDTO, error)
 	// ImportMarkdown import markdown file as post
 	ImportMarkdown(ctx context.Context, fileHeader *multipart.FileHeader) error
+	// ImportGhost import the JSON file exported by Ghost
+	ImportGhost(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error)
+	// ImportHalo import the JSON file exported by Halo 1.x
+	ImportHalo(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error)
+	// ImportDisqus import the comments of the XML file exported by Disqus, the threads are matched to posts and sheets by URL
+	ImportDisqus(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error)
+	// ImportTwikoo import the comments of the JSON file exported by Twikoo
+	ImportTwikoo(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error)
+	// ImportWaline import the comments of the JSON file exported by Waline
+	ImportWaline(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error)
 	// ExportMarkdown export posts to markdown files
 	ExportMarkdown(ctx context.Context, needFrontMatter bool) (*dto.BackupDTO, error)
+	// ExportEpub export posts to an EPUB e-book
+	ExportEpub(ctx context.Context, exportParam *param.ExportEpub) (*dto.BackupDTO, error)
+	// ExportComments export the comments of all contents or of one content as CSV, JSON or WXR
+	ExportComments(ctx context.Context, exportParam *param.ExportComment) (*dto.BackupDTO, error)
 	ListToBackupItems(ctx context.Context) ([]string, error)
 }
 
@@ -33,4 +48,6 @@ const (
 	WholeSite BackupType = "/api/admin/backups/work-dir"
 	JSONData  BackupType = "/api/admin/backups/data"
 	Markdown  BackupType = "/api/admin/backups/markdown/export"
+	Epub      BackupType = "/api/admin/backups/epub/export"
+	Comment   BackupType = "/api/admin/backups/comments/export"
 )
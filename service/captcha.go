@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/dto"
+)
+
+type CaptchaService interface {
+	// Generate creates a challenge, its answer is kept until it is verified or expired.
+	Generate(ctx context.Context) (*dto.Captcha, error)
+	// Verify checks the answer of the challenge, a challenge can only be verified once.
+	Verify(ctx context.Context, id, answer string) bool
+	CommentRequired(ctx context.Context) (bool, error)
+	AuthenticationRequired(ctx context.Context, ip string) (bool, error)
+	AddAuthenticationFailure(ctx context.Context, ip string)
+	ResetAuthenticationFailure(ctx context.Context, ip string)
+}
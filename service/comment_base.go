@@ -11,9 +11,16 @@ import (
 type BaseCommentService interface {
 	CreateBy(ctx context.Context, commentParam *param.Comment) (*entity.Comment, error)
 	Page(ctx context.Context, commentQuery param.CommentQuery, commentType consts.CommentType) ([]*entity.Comment, int64, error)
+	// PageByStatus lists the comments of all types with the status, the latest first
+	PageByStatus(ctx context.Context, status consts.CommentStatus, page param.Page) ([]*entity.Comment, int64, error)
+	// ListLatestPublic lists the published comments on the published posts and sheets and on the public journals, the latest first
+	ListLatestPublic(ctx context.Context, limit int) ([]*entity.Comment, error)
 	GetByID(ctx context.Context, commentID int32) (*entity.Comment, error)
 	LGetByIDs(ctx context.Context, commentIDs []int32) ([]*entity.Comment, error)
-	GetByContentID(ctx context.Context, contentID int32, contentType consts.CommentType, sort *param.Sort) ([]*entity.Comment, error)
+	// GetByContentID lists the comments of the content, a nil status lists the comments of all status
+	GetByContentID(ctx context.Context, contentID int32, contentType consts.CommentType, sort *param.Sort, status *consts.CommentStatus) ([]*entity.Comment, error)
+	// PageByCursor lists the published comments after the cursor and returns the cursor of the next page, which is empty if there are no more, and whether the comments are on the last level allowed by the max depth, the replies on the last level include all the deeper replies
+	PageByCursor(ctx context.Context, query *param.CommentCursorQuery, commentType consts.CommentType) ([]*entity.Comment, string, bool, error)
 	Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
 	UpdateStatus(ctx context.Context, commentID int32, commentStatus consts.CommentStatus) (*entity.Comment, error)
 	UpdateStatusBatch(ctx context.Context, commentIDs []int32, commentStatus consts.CommentStatus) ([]*entity.Comment, error)
@@ -26,5 +33,12 @@ type BaseCommentService interface {
 	CountByStatusAndContentIDs(ctx context.Context, status consts.CommentStatus, contentIDs []int32) (map[int32]int64, error)
 	CountChildren(ctx context.Context, parentCommentIDs []int32) (map[int32]int64, error)
 	GetChildren(ctx context.Context, parentCommentID int32, contentID int32, commentType consts.CommentType) ([]*entity.Comment, error)
-	IncreaseLike(ctx context.Context, commentID int32) error
+	// BuildEditToken signs the token for the author to edit or delete the comment, it is empty when the author editing is disabled
+	BuildEditToken(ctx context.Context, comment *entity.Comment) (string, error)
+	// UpdateByAuthor changes the content of the comment and keeps the previous content as a revision
+	UpdateByAuthor(ctx context.Context, commentID int32, editParam *param.CommentAuthorEdit) (*entity.Comment, error)
+	// DeleteByAuthor moves the comment to the recycle bin
+	DeleteByAuthor(ctx context.Context, commentID int32, token string) error
+	// RenderContent renders the markdown of the comment to HTML that only keeps the allowed tags
+	RenderContent(ctx context.Context, content string) string
 }
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+)
+
+type CommentBlackService interface {
+	Page(ctx context.Context, page param.Page) ([]*entity.CommentBlack, int64, error)
+	Create(ctx context.Context, commentBlackParam *param.CommentBlack) (*entity.CommentBlack, error)
+	Update(ctx context.Context, id int32, commentBlackParam *param.CommentBlack) (*entity.CommentBlack, error)
+	Delete(ctx context.Context, id int32) error
+	// Check returns an error if the author of the comment is banned, the IP sending too many comments within the ban time is banned automatically.
+	// The IP of the webmentions and the fediverse replies is not banned for the frequency.
+	Check(ctx context.Context, comment *entity.Comment) error
+	ConvertToDTO(commentBlack *entity.CommentBlack) *dto.CommentBlack
+	ConvertToDTOs(commentBlacks []*entity.CommentBlack) []*dto.CommentBlack
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+)
+
+// CommentRevisionService keeps the contents of the comments before they are edited by the authors.
+type CommentRevisionService interface {
+	Create(ctx context.Context, revision *entity.CommentRevision) error
+	// ListByCommentID lists the revisions of the comment, the latest first
+	ListByCommentID(ctx context.Context, commentID int32) ([]*entity.CommentRevision, error)
+	DeleteByCommentIDs(ctx context.Context, commentIDs []int32) error
+	ConvertToDTOs(revisions []*entity.CommentRevision) []*dto.CommentRevision
+}
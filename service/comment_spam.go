@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/entity"
+)
+
+// SpamCandidate is a new comment to be checked, with the fields of the comment form which are not saved.
+type SpamCandidate struct {
+	Comment *entity.Comment
+	// Honeypot is the value of the hidden field of the comment form, which is only filled by bots
+	Honeypot string
+	Referer  string
+}
+
+type SpamChecker interface {
+	Name() string
+	IsSpam(ctx context.Context, candidate *SpamCandidate) (bool, error)
+}
+
+type CommentSpamService interface {
+	// Check runs the spam checkers in order, it returns the name of the checker which regards the comment as spam, or an empty string.
+	Check(ctx context.Context, candidate *SpamCandidate) (string, error)
+	// RegisterChecker appends a checker to the end of the chain
+	RegisterChecker(checker SpamChecker)
+	// Train teaches the classifier that the comments are spam or not
+	Train(ctx context.Context, comments []*entity.Comment, spam bool) error
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/dto"
+)
+
+// CommentSubscriptionService keeps the mail subscriptions of the commenters, the content 0 means all the contents.
+type CommentSubscriptionService interface {
+	// Subscribe receives all the comments of the content, it is called once the email is confirmed by the link of BuildSubscribeURL
+	Subscribe(ctx context.Context, email string, commentType consts.CommentType, contentID int32) error
+	// Unsubscribe stops all the mails of the content
+	Unsubscribe(ctx context.Context, email string, commentType consts.CommentType, contentID int32) error
+	IsUnsubscribed(ctx context.Context, email string, commentType consts.CommentType, contentID int32) (bool, error)
+	ListThreadSubscribers(ctx context.Context, commentType consts.CommentType, contentID int32) ([]string, error)
+	BuildUnsubscribeURL(ctx context.Context, email string, commentType consts.CommentType, contentID int32) (string, error)
+	// ParseUnsubscribeToken verifies the signed token of the unsubscribe url
+	ParseUnsubscribeToken(ctx context.Context, token string) (*dto.SubscriptionToken, error)
+	// BuildSubscribeURL signs the link mailed to the commenter to confirm the subscription of the thread, the link expires
+	BuildSubscribeURL(ctx context.Context, email string, commentType consts.CommentType, contentID int32) (string, error)
+	// ParseSubscribeToken verifies the signed token of the subscribe url, an unsubscribe token is refused
+	ParseSubscribeToken(ctx context.Context, token string) (*dto.SubscriptionToken, error)
+}
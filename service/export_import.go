@@ -4,10 +4,19 @@ import (
 	"context"
 	"io"
 
+	"github.com/go-sonic/sonic/model/dto"
 	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
 )
 
 type ExportImport interface {
 	CreateByMarkdown(ctx context.Context, filename string, reader io.Reader) (*entity.Post, error)
 	ExportMarkdown(ctx context.Context, needFrontMatter bool) (string, error)
+	ExportEpub(ctx context.Context, exportParam *param.ExportEpub) (string, error)
+	ExportComments(ctx context.Context, exportParam *param.ExportComment) (string, error)
+	ImportGhost(ctx context.Context, reader io.Reader) (*dto.ImportReport, error)
+	ImportHalo(ctx context.Context, reader io.Reader) (*dto.ImportReport, error)
+	ImportDisqus(ctx context.Context, reader io.Reader) (*dto.ImportReport, error)
+	ImportTwikoo(ctx context.Context, reader io.Reader) (*dto.ImportReport, error)
+	ImportWaline(ctx context.Context, reader io.Reader) (*dto.ImportReport, error)
 }
@@ -0,0 +1,8 @@
+package service
+
+import "net/http"
+
+// HTTPClient sends requests to other sites, it can be replaced by a local stand-in in tests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
@@ -0,0 +1,865 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+
+	"github.com/go-sonic/sonic/cache"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/model/vo"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	activityStreamsPublic  = "https://www.w3.org/ns/activitystreams#Public"
+	securityContext        = "https://w3id.org/security/v1"
+	activityPubAccept      = `application/activity+json, application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+	activityPubPageSize    = 20
+	activityPubTimeout     = 15 * time.Second
+	// activityPubMaxBody is the max size of the documents fetched from other servers
+	activityPubMaxBody = 1 << 20
+	// activityPubActorTTL is how long the documents of the remote actors are cached
+	activityPubActorTTL = time.Hour
+)
+
+type activityPubServiceImpl struct {
+	OptionService       service.OptionService
+	BasePostService     service.BasePostService
+	PostTagService      service.PostTagService
+	TagService          service.TagService
+	CommentBlackService service.CommentBlackService
+	CommentSpamService  service.CommentSpamService
+	ReactionService     service.ReactionService
+	ShortcodeService    service.ShortcodeService
+	HTTPClient          service.HTTPClient
+	Cache               cache.Cache
+	Event               event.Bus
+	// keyMutex keeps the key of the actor from being generated twice
+	keyMutex sync.Mutex
+	// sender sends the deliveries in the background, so that a post sent to many inboxes does not open a connection for each at once
+	sender *util.WorkerPool
+}
+
+func NewActivityPubService(optionService service.OptionService,
+	basePostService service.BasePostService,
+	postTagService service.PostTagService,
+	tagService service.TagService,
+	commentBlackService service.CommentBlackService,
+	commentSpamService service.CommentSpamService,
+	reactionService service.ReactionService,
+	shortcodeService service.ShortcodeService,
+	httpClient service.HTTPClient,
+	cache cache.Cache,
+	event event.Bus,
+	lifecycle fx.Lifecycle,
+) service.ActivityPubService {
+	a := &activityPubServiceImpl{
+		OptionService:       optionService,
+		BasePostService:     basePostService,
+		PostTagService:      postTagService,
+		TagService:          tagService,
+		CommentBlackService: commentBlackService,
+		CommentSpamService:  commentSpamService,
+		ReactionService:     reactionService,
+		ShortcodeService:    shortcodeService,
+		HTTPClient:          httpClient,
+		Cache:               cache,
+		Event:               event,
+		sender:              util.NewWorkerPool(activityPubWorkers, activityPubQueueSize),
+	}
+	runPeriodically(lifecycle, activityPubRetryInterval, a.retryDueDeliveries)
+	return a
+}
+
+// activityPubURLs are the ids of the documents of the blog
+type activityPubURLs struct {
+	base      *url.URL
+	actor     string
+	keyID     string
+	inbox     string
+	outbox    string
+	followers string
+	following string
+}
+
+func (u *activityPubURLs) article(postID int32) string {
+	return u.base.String() + "activitypub/posts/" + strconv.Itoa(int(postID))
+}
+
+// articlePostID returns the ID of the post of the article id, or 0 if the id is not an article of the blog.
+func (u *activityPubURLs) articlePostID(id string) int32 {
+	rawID, ok := strings.CutPrefix(id, u.base.String()+"activitypub/posts/")
+	if !ok {
+		return 0
+	}
+	postID, err := strconv.Atoi(rawID)
+	if err != nil {
+		return 0
+	}
+	return int32(postID)
+}
+
+func (u *activityPubURLs) absolute(ref string) string {
+	if ref == "" {
+		return ""
+	}
+	absoluteURL, err := u.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return absoluteURL.String()
+}
+
+func (a *activityPubServiceImpl) isEnabled(ctx context.Context) (bool, error) {
+	enabled, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.ActivityPubEnabled, false)
+	if err != nil {
+		return false, err
+	}
+	return enabled.(bool), nil
+}
+
+func (a *activityPubServiceImpl) getURLs(ctx context.Context) (*activityPubURLs, error) {
+	enabled, err := a.isEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, xerr.NoRecord.New("").WithMsg("ActivityPub is disabled").WithStatus(xerr.StatusNotFound)
+	}
+	blogBaseURL, err := a.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(blogBaseURL + "/")
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid blog url")
+	}
+	prefix := base.String() + "activitypub/"
+	return &activityPubURLs{
+		base:      base,
+		actor:     prefix + "actor",
+		keyID:     prefix + "actor#main-key",
+		inbox:     prefix + "inbox",
+		outbox:    prefix + "outbox",
+		followers: prefix + "followers",
+		following: prefix + "following",
+	}, nil
+}
+
+func (a *activityPubServiceImpl) getUsername(ctx context.Context) string {
+	return a.OptionService.GetOrByDefault(ctx, property.ActivityPubUsername).(string)
+}
+
+// getActorKey returns the key pair of the actor, it is generated and saved if there is none.
+func (a *activityPubServiceImpl) getActorKey(ctx context.Context) (*rsa.PrivateKey, string, error) {
+	a.keyMutex.Lock()
+	defer a.keyMutex.Unlock()
+	privateKeyPem, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.ActivityPubPrivateKey, "")
+	if err != nil {
+		return nil, "", err
+	}
+	publicKeyPem, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.ActivityPubPublicKey, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if privateKeyPem.(string) != "" && publicKeyPem.(string) != "" {
+		key, err := parsePrivateKeyPem(privateKeyPem.(string))
+		return key, publicKeyPem.(string), err
+	}
+	newPrivateKeyPem, newPublicKeyPem, err := generateActorKey()
+	if err != nil {
+		return nil, "", xerr.WithStatus(err, xerr.StatusInternalServerError)
+	}
+	err = a.OptionService.Save(ctx, map[string]string{
+		property.ActivityPubPrivateKey.KeyValue: newPrivateKeyPem,
+		property.ActivityPubPublicKey.KeyValue:  newPublicKeyPem,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := parsePrivateKeyPem(newPrivateKeyPem)
+	return key, newPublicKeyPem, err
+}
+
+func (a *activityPubServiceImpl) WebFinger(ctx context.Context, resource string) (*vo.WebFinger, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subject := "acct:" + a.getUsername(ctx) + "@" + urls.base.Host
+	if !strings.EqualFold(resource, subject) && resource != urls.actor {
+		return nil, xerr.NoRecord.New("resource=%v", resource).WithMsg("The resource does not exist").WithStatus(xerr.StatusNotFound)
+	}
+	return &vo.WebFinger{
+		Subject: subject,
+		Aliases: []string{urls.actor, urls.base.String()},
+		Links: []vo.WebFingerLink{
+			{Rel: "self", Type: "application/activity+json", Href: urls.actor},
+			{Rel: "http://webfinger.net/rel/profile-page", Type: "text/html", Href: urls.base.String()},
+		},
+	}, nil
+}
+
+func (a *activityPubServiceImpl) GetActor(ctx context.Context) (*vo.ActivityPubActor, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, publicKeyPem, err := a.getActorKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actor := &vo.ActivityPubActor{
+		Context:           []string{activityStreamsContext, securityContext},
+		ID:                urls.actor,
+		Type:              "Person",
+		PreferredUsername: a.getUsername(ctx),
+		Name:              a.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string),
+		Summary:           a.OptionService.GetOrByDefault(ctx, property.SeoDescription).(string),
+		URL:               urls.base.String(),
+		Inbox:             urls.inbox,
+		Outbox:            urls.outbox,
+		Followers:         urls.followers,
+		Following:         urls.following,
+		Endpoints:         map[string]string{"sharedInbox": urls.inbox},
+		PublicKey: vo.ActivityPubPublicKey{
+			ID:           urls.keyID,
+			Owner:        urls.actor,
+			PublicKeyPem: publicKeyPem,
+		},
+		Discoverable: true,
+	}
+	if logo := a.OptionService.GetOrByDefault(ctx, property.BlogLogo).(string); logo != "" {
+		actor.Icon = &vo.ActivityPubImage{Type: "Image", URL: urls.absolute(logo)}
+	}
+	return actor, nil
+}
+
+func (a *activityPubServiceImpl) GetOutbox(ctx context.Context, page int) (*vo.ActivityPubCollection, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	postDO := postDAL.WithContext(ctx).Where(postDAL.Type.Eq(consts.PostTypePost), postDAL.Status.Eq(consts.PostStatusPublished))
+	if page <= 0 {
+		totalCount, err := postDO.Count()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		lastPage := (totalCount + activityPubPageSize - 1) / activityPubPageSize
+		return &vo.ActivityPubCollection{
+			Context:    activityStreamsContext,
+			ID:         urls.outbox,
+			Type:       "OrderedCollection",
+			TotalItems: &totalCount,
+			First:      urls.outbox + "?page=1",
+			Last:       urls.outbox + "?page=" + strconv.Itoa(int(util.IfElse(lastPage > 0, lastPage, int64(1)).(int64))),
+		}, nil
+	}
+	posts, err := postDO.Order(postDAL.CreateTime.Desc(), postDAL.ID.Desc()).Offset((page - 1) * activityPubPageSize).Limit(activityPubPageSize + 1).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	collectionPage := &vo.ActivityPubCollection{
+		Context:      activityStreamsContext,
+		ID:           urls.outbox + "?page=" + strconv.Itoa(page),
+		Type:         "OrderedCollectionPage",
+		PartOf:       urls.outbox,
+		OrderedItems: make([]interface{}, 0, activityPubPageSize),
+	}
+	if len(posts) > activityPubPageSize {
+		posts = posts[:activityPubPageSize]
+		collectionPage.Next = urls.outbox + "?page=" + strconv.Itoa(page+1)
+	}
+	if page > 1 {
+		collectionPage.Prev = urls.outbox + "?page=" + strconv.Itoa(page-1)
+	}
+	postIDs := make([]int32, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	tagMap, err := a.PostTagService.ListTagMapByPostID(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		article, err := a.buildArticle(ctx, urls, post, tagMap[post.ID])
+		if err != nil {
+			return nil, err
+		}
+		collectionPage.OrderedItems = append(collectionPage.OrderedItems, buildActivity(urls, "Create", article.ID+"#create", article))
+	}
+	return collectionPage, nil
+}
+
+// GetFollowers only tells the number of the followers, the followers are not listed to others.
+func (a *activityPubServiceImpl) GetFollowers(ctx context.Context) (*vo.ActivityPubCollection, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+	totalCount, err := followerDAL.WithContext(ctx).Count()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return &vo.ActivityPubCollection{
+		Context:    activityStreamsContext,
+		ID:         urls.followers,
+		Type:       "OrderedCollection",
+		TotalItems: &totalCount,
+	}, nil
+}
+
+func (a *activityPubServiceImpl) GetFollowing(ctx context.Context) (*vo.ActivityPubCollection, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var totalCount int64
+	return &vo.ActivityPubCollection{
+		Context:    activityStreamsContext,
+		ID:         urls.following,
+		Type:       "OrderedCollection",
+		TotalItems: &totalCount,
+	}, nil
+}
+
+func (a *activityPubServiceImpl) GetArticle(ctx context.Context, postID int32) (*vo.ActivityPubObject, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	post, err := a.getPublishedPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := a.PostTagService.ListTagByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	article, err := a.buildArticle(ctx, urls, post, tags)
+	if err != nil {
+		return nil, err
+	}
+	article.Context = activityStreamsContext
+	return article, nil
+}
+
+func (a *activityPubServiceImpl) getPublishedPost(ctx context.Context, postID int32) (*entity.Post, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	post, err := postDAL.WithContext(ctx).Where(
+		postDAL.ID.Eq(postID),
+		postDAL.Type.Eq(consts.PostTypePost),
+		postDAL.Status.Eq(consts.PostStatusPublished),
+	).First()
+	return post, WrapDBErr(err)
+}
+
+func (a *activityPubServiceImpl) buildArticle(ctx context.Context, urls *activityPubURLs, post *entity.Post, tags []*entity.Tag) (*vo.ActivityPubObject, error) {
+	fullPath, err := a.BasePostService.BuildFullPath(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	content, err := a.ShortcodeService.Render(ctx, post.FormatContent)
+	if err != nil {
+		return nil, err
+	}
+	article := &vo.ActivityPubObject{
+		ID:           urls.article(post.ID),
+		Type:         "Article",
+		AttributedTo: urls.actor,
+		Name:         post.Title,
+		Content:      content,
+		URL:          urls.absolute(fullPath),
+		Published:    post.CreateTime.UTC().Format(time.RFC3339),
+		To:           []string{activityStreamsPublic},
+		Cc:           []string{urls.followers},
+	}
+	if post.EditTime != nil {
+		article.Updated = post.EditTime.UTC().Format(time.RFC3339)
+	}
+	if post.Thumbnail != "" {
+		article.Image = &vo.ActivityPubImage{Type: "Image", URL: urls.absolute(post.Thumbnail)}
+	}
+	tagDTOs, err := a.TagService.ConvertToDTOs(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tagDTOs {
+		article.Tag = append(article.Tag, &vo.ActivityPubTag{
+			Type: "Hashtag",
+			Href: urls.absolute(tag.FullPath),
+			Name: "#" + strings.Join(strings.Fields(tag.Name), ""),
+		})
+	}
+	return article, nil
+}
+
+func buildActivity(urls *activityPubURLs, activityType, id string, object interface{}) *vo.ActivityPubObject {
+	return &vo.ActivityPubObject{
+		ID:     id,
+		Type:   activityType,
+		Actor:  urls.actor,
+		To:     []string{activityStreamsPublic},
+		Cc:     []string{urls.followers},
+		Object: object,
+	}
+}
+
+// remoteActor is the part of the actor document of another server which the blog needs
+type remoteActor struct {
+	ID                string          `json:"id"`
+	Type              string          `json:"type"`
+	PreferredUsername string          `json:"preferredUsername"`
+	Name              string          `json:"name"`
+	URL               json.RawMessage `json:"url"`
+	Inbox             string          `json:"inbox"`
+	Endpoints         struct {
+		SharedInbox string `json:"sharedInbox"`
+	} `json:"endpoints"`
+	PublicKey struct {
+		ID           string `json:"id"`
+		Owner        string `json:"owner"`
+		PublicKeyPem string `json:"publicKeyPem"`
+	} `json:"publicKey"`
+}
+
+type inboxActivity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Actor  json.RawMessage `json:"actor"`
+	Object json.RawMessage `json:"object"`
+}
+
+type inboxNote struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`
+	AttributedTo json.RawMessage `json:"attributedTo"`
+	InReplyTo    json.RawMessage `json:"inReplyTo"`
+	Content      string          `json:"content"`
+	To           json.RawMessage `json:"to"`
+	Cc           json.RawMessage `json:"cc"`
+}
+
+// jsonID returns the id of a property which may be a link, an object or a list of them.
+func jsonID(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var object struct {
+		ID   string `json:"id"`
+		Href string `json:"href"`
+	}
+	if json.Unmarshal(raw, &object) == nil {
+		return util.IfElse(object.ID != "", object.ID, object.Href).(string)
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil && len(list) > 0 {
+		return jsonID(list[0])
+	}
+	return ""
+}
+
+func jsonIDs(raw json.RawMessage) []string {
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil {
+		list = []json.RawMessage{raw}
+	}
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		if id := jsonID(item); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (a *activityPubServiceImpl) HandleInbox(ctx context.Context, req *http.Request, body []byte) error {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return err
+	}
+	var activity inboxActivity
+	if err := json.Unmarshal(body, &activity); err != nil || activity.Type == "" {
+		return xerr.BadParam.New("").WithMsg("invalid activity").WithStatus(xerr.StatusBadRequest)
+	}
+	signer, err := a.verifySignature(ctx, urls, req, body, jsonID(activity.Actor))
+	if err != nil {
+		return err
+	}
+	if jsonID(activity.Actor) != signer.ID {
+		return xerr.Forbidden.New("actor=%s signer=%s", jsonID(activity.Actor), signer.ID).WithMsg("The activity is not sent by its actor").WithStatus(xerr.StatusUnauthorized)
+	}
+	switch activity.Type {
+	case "Follow":
+		return a.handleFollow(ctx, urls, signer, &activity, body)
+	case "Undo":
+		return a.handleUndo(ctx, urls, signer, &activity)
+	case "Create":
+		return a.handleCreate(ctx, urls, signer, &activity)
+	case "Like":
+		return a.handleLike(ctx, urls, signer, jsonID(activity.Object), false)
+	case "Delete":
+		return a.handleDelete(ctx, signer, jsonID(activity.Object))
+	}
+	return nil
+}
+
+// verifySignature returns the actor which signs the request, the actor is fetched again in case its key has been changed.
+// The key has to be on the server of the actor of the activity, so that only the servers sending activities are fetched.
+func (a *activityPubServiceImpl) verifySignature(ctx context.Context, urls *activityPubURLs, req *http.Request, body []byte, activityActor string) (*remoteActor, error) {
+	signature, err := parseSignatureHeader(req.Header.Get("Signature"))
+	if err != nil {
+		return nil, err
+	}
+	keyURL, err := url.Parse(signature.KeyID)
+	if err != nil || (keyURL.Scheme != "https" && keyURL.Scheme != "http") || keyURL.Host == "" || keyURL.User != nil {
+		return nil, xerr.Forbidden.New("keyId=%s", signature.KeyID).WithMsg("invalid key id").WithStatus(xerr.StatusUnauthorized)
+	}
+	if !sameHost(signature.KeyID, activityActor) {
+		return nil, xerr.Forbidden.New("keyId=%s actor=%s", signature.KeyID, activityActor).WithMsg("The key is not on the server of the actor").WithStatus(xerr.StatusUnauthorized)
+	}
+	actorID, _, _ := strings.Cut(signature.KeyID, "#")
+	for _, refresh := range []bool{false, true} {
+		actor, err := a.fetchActor(ctx, urls, actorID, refresh)
+		if err != nil {
+			return nil, xerr.Forbidden.Wrap(err).WithMsg("The signer could not be fetched").WithStatus(xerr.StatusUnauthorized)
+		}
+		if actor.PublicKey.ID != signature.KeyID {
+			continue
+		}
+		publicKey, err := parsePublicKeyPem(actor.PublicKey.PublicKeyPem)
+		if err != nil {
+			continue
+		}
+		err = verifyRequest(req, urls.base.Host, signature, publicKey, body)
+		if err == nil {
+			return actor, nil
+		}
+		if refresh {
+			return nil, err
+		}
+	}
+	return nil, xerr.Forbidden.New("keyId=%s", signature.KeyID).WithMsg("The key of the signer is not found").WithStatus(xerr.StatusUnauthorized)
+}
+
+func (a *activityPubServiceImpl) fetchActor(ctx context.Context, urls *activityPubURLs, actorID string, refresh bool) (*remoteActor, error) {
+	cacheKey := "activitypub_actor:" + actorID
+	if cached, ok := a.Cache.Get(cacheKey); ok && !refresh {
+		return cached.(*remoteActor), nil
+	}
+	actor := &remoteActor{}
+	if err := a.fetchDocument(ctx, urls, actorID, actor); err != nil {
+		return nil, err
+	}
+	if actor.ID != actorID || actor.Inbox == "" {
+		return nil, xerr.BadParam.New("actor=%s", actorID).WithMsg("invalid actor")
+	}
+	a.Cache.Set(cacheKey, actor, activityPubActorTTL)
+	return actor, nil
+}
+
+// fetchDocument gets the document with a signed request, as some servers only serve the signed requests.
+func (a *activityPubServiceImpl) fetchDocument(ctx context.Context, urls *activityPubURLs, rawURL string, document interface{}) error {
+	key, _, err := a.getActorKey(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, activityPubTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", activityPubAccept)
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion+" activitypub")
+	if err := signRequest(req, urls.keyID, key, nil); err != nil {
+		return err
+	}
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return xerr.NoType.New("url=%s status=%d", rawURL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, activityPubMaxBody))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, document)
+}
+
+// handleFollow keeps the follower and accepts the follow, a follow of an existing follower is accepted again.
+func (a *activityPubServiceImpl) handleFollow(ctx context.Context, urls *activityPubURLs, actor *remoteActor, activity *inboxActivity, body []byte) error {
+	if jsonID(activity.Object) != urls.actor {
+		return nil
+	}
+	username := actor.PreferredUsername
+	if actorURL, err := url.Parse(actor.ID); err == nil {
+		username += "@" + actorURL.Host
+	}
+	follower := &entity.ActivityPubFollower{
+		ActorID:     actor.ID,
+		Username:    truncateRunes(username, 255),
+		Name:        truncateRunes(actor.Name, 255),
+		URL:         truncateRunes(util.IfElse(jsonID(actor.URL) != "", jsonID(actor.URL), actor.ID).(string), 1023),
+		Inbox:       actor.Inbox,
+		SharedInbox: actor.Endpoints.SharedInbox,
+	}
+	followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+	existing, err := followerDAL.WithContext(ctx).Where(followerDAL.ActorID.Eq(actor.ID)).First()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		err = followerDAL.WithContext(ctx).Create(follower)
+	case err == nil:
+		_, err = followerDAL.WithContext(ctx).Where(followerDAL.ID.Eq(existing.ID)).UpdateSimple(
+			followerDAL.Username.Value(follower.Username),
+			followerDAL.Name.Value(follower.Name),
+			followerDAL.URL.Value(follower.URL),
+			followerDAL.Inbox.Value(follower.Inbox),
+			followerDAL.SharedInbox.Value(follower.SharedInbox),
+			followerDAL.UpdateTime.Value(time.Now()),
+		)
+	}
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	accept := buildActivity(urls, "Accept", urls.actor+"#accepts/"+util.GenUUIDWithOutDash(), json.RawMessage(body))
+	accept.Context = activityStreamsContext
+	accept.To = []string{actor.ID}
+	accept.Cc = nil
+	return a.deliver(ctx, "Accept", 0, accept, []string{actor.Inbox})
+}
+
+func (a *activityPubServiceImpl) handleUndo(ctx context.Context, urls *activityPubURLs, actor *remoteActor, activity *inboxActivity) error {
+	var undone inboxActivity
+	if err := json.Unmarshal(activity.Object, &undone); err != nil {
+		// the undone activity is only given by its id, which is not kept
+		return nil
+	}
+	if len(undone.Actor) > 0 && jsonID(undone.Actor) != actor.ID {
+		return xerr.Forbidden.New("").WithMsg("The undone activity is not sent by the actor").WithStatus(xerr.StatusForbidden)
+	}
+	switch undone.Type {
+	case "Follow":
+		followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+		_, err := followerDAL.WithContext(ctx).Where(followerDAL.ActorID.Eq(actor.ID)).Delete()
+		return WrapDBErr(err)
+	case "Like":
+		return a.handleLike(ctx, urls, actor, jsonID(undone.Object), true)
+	}
+	return nil
+}
+
+// handleCreate keeps the public replies to the articles, and the replies to these replies, as comments of the posts.
+func (a *activityPubServiceImpl) handleCreate(ctx context.Context, urls *activityPubURLs, actor *remoteActor, activity *inboxActivity) error {
+	var note inboxNote
+	if err := json.Unmarshal(activity.Object, &note); err != nil || note.Type != "Note" || note.ID == "" {
+		return nil
+	}
+	if jsonID(note.AttributedTo) != actor.ID {
+		return xerr.Forbidden.New("").WithMsg("The note is not attributed to the actor").WithStatus(xerr.StatusForbidden)
+	}
+	// the id of a note on another server could be taken before the real note arrives
+	if !sameHost(note.ID, actor.ID) {
+		return xerr.Forbidden.New("note=%s actor=%s", note.ID, actor.ID).WithMsg("The note is not on the server of the actor").WithStatus(xerr.StatusForbidden)
+	}
+	public := false
+	for _, audience := range append(jsonIDs(note.To), jsonIDs(note.Cc)...) {
+		public = public || audience == activityStreamsPublic || audience == "as:Public" || audience == "Public"
+	}
+	if !public {
+		return nil
+	}
+
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	inReplyTo := jsonID(note.InReplyTo)
+	postID := urls.articlePostID(inReplyTo)
+	var parentID int32
+	if postID == 0 {
+		parent, err := a.getFediverseComment(ctx, inReplyTo)
+		if err != nil || parent == nil {
+			return err
+		}
+		postID, parentID = parent.PostID, parent.ID
+	}
+	post, err := a.getPublishedPost(ctx, postID)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if post.DisallowComment {
+		return nil
+	}
+	existing, err := a.getFediverseComment(ctx, note.ID)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	content := htmlToText(note.Content)
+	if content == "" {
+		return nil
+	}
+	needCheck, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.CommentNewNeedCheck, true)
+	if err != nil {
+		return err
+	}
+	author := util.IfElse(actor.Name != "", actor.Name, actor.PreferredUsername).(string)
+	authorURL := jsonID(actor.URL)
+	if util.Validate.Var(authorURL, "http_url") != nil {
+		authorURL = actor.ID
+	}
+	// the fields are escaped like the comments posted by visitors,
+	// the IP and the user agent are the ones of the server of the actor, so they are not kept
+	comment := &entity.Comment{
+		Type:      consts.CommentTypePost,
+		PostID:    postID,
+		ParentID:  parentID,
+		Kind:      consts.CommentKindActivityPub,
+		SourceURL: truncateRunes(note.ID, 1023),
+		ActorID:   truncateRunes(actor.ID, 1023),
+		Author:    escapeCommentContent(util.IfElse(author != "", author, actor.ID).(string), 50),
+		AuthorURL: escapeCommentContent(authorURL, 511),
+		Content:   escapeCommentContent(content, 1023),
+		Status:    util.IfElse(needCheck.(bool), consts.CommentStatusAuditing, consts.CommentStatusPublished).(consts.CommentStatus),
+	}
+	comment.GravatarMd5 = util.Md5Hex(comment.Email)
+	if err := moderateRemoteComment(ctx, a.CommentBlackService, a.CommentSpamService, comment); err != nil {
+		return err
+	}
+	err = commentDAL.WithContext(ctx).Select(field.Star).Omit(commentDAL.UpdateTime).Create(comment)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if comment.Status == consts.CommentStatusSpam {
+		return nil
+	}
+	go func() {
+		a.Event.Publish(context.TODO(), &event.CommentCreatedEvent{
+			Comment: comment,
+		})
+	}()
+	return nil
+}
+
+func (a *activityPubServiceImpl) getFediverseComment(ctx context.Context, noteID string) (*entity.Comment, error) {
+	if noteID == "" {
+		return nil, nil
+	}
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	comment, err := commentDAL.WithContext(ctx).Where(
+		commentDAL.Kind.Eq(consts.CommentKindActivityPub),
+		commentDAL.SourceURL.Eq(noteID),
+	).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return comment, WrapDBErr(err)
+}
+
+// handleLike gives the like reaction of the actor to the post, or takes it back.
+func (a *activityPubServiceImpl) handleLike(ctx context.Context, urls *activityPubURLs, actor *remoteActor, objectID string, undo bool) error {
+	postID := urls.articlePostID(objectID)
+	if postID == 0 {
+		return nil
+	}
+	sum := sha256.Sum256([]byte("activitypub|" + actor.ID))
+	fingerprint := hex.EncodeToString(sum[:])
+	if !undo {
+		return a.ReactionService.Like(ctx, consts.ReactionTargetPost, postID, fingerprint)
+	}
+	summary, err := a.ReactionService.GetSummary(ctx, consts.ReactionTargetPost, postID, fingerprint)
+	if err != nil || summary.Current != consts.ReactionLike {
+		return err
+	}
+	_, err = a.ReactionService.Toggle(ctx, consts.ReactionTargetPost, postID, fingerprint, consts.ReactionLike)
+	return err
+}
+
+// handleDelete removes a deleted actor from the followers, or the comment of a deleted note.
+// A note can only be deleted by the actor it is attributed to.
+func (a *activityPubServiceImpl) handleDelete(ctx context.Context, actor *remoteActor, objectID string) error {
+	if objectID == actor.ID {
+		followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+		_, err := followerDAL.WithContext(ctx).Where(followerDAL.ActorID.Eq(actor.ID)).Delete()
+		return WrapDBErr(err)
+	}
+	comment, err := a.getFediverseComment(ctx, objectID)
+	if err != nil || comment == nil {
+		return err
+	}
+	if comment.ActorID != actor.ID {
+		return xerr.Forbidden.New("note=%s actor=%s", objectID, actor.ID).WithMsg("The note is not attributed to the actor").WithStatus(xerr.StatusForbidden)
+	}
+	return deleteRemoteComment(ctx, comment)
+}
+
+// sameHost tells whether the two urls are on the same server.
+func sameHost(rawURL1, rawURL2 string) bool {
+	url1, err := url.Parse(rawURL1)
+	if err != nil || url1.Host == "" {
+		return false
+	}
+	url2, err := url.Parse(rawURL2)
+	return err == nil && strings.EqualFold(url1.Host, url2.Host)
+}
+
+// htmlToText keeps the text of the paragraphs and the line breaks of the html content of a note.
+func htmlToText(content string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			buf.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			buf.WriteString("\n")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if n.Type == html.ElementNode && n.Data == "p" {
+			buf.WriteString("\n\n")
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,341 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/vo"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	activityPubMaxAttempts    = 8
+	activityPubRetryBaseDelay = time.Minute
+	activityPubRetryInterval  = time.Second * 30
+	// activityPubLease keeps the retry loop from sending a delivery which is being sent
+	activityPubLease         = time.Minute * 2
+	activityPubMaxTextLength = 1023
+	activityPubWorkers       = 4
+	activityPubQueueSize     = 1024
+)
+
+func (a *activityPubServiceImpl) PublishPost(ctx context.Context, post *entity.Post, activityType string) error {
+	enabled, err := a.isEnabled(ctx)
+	if err != nil || !enabled || post.Type != consts.PostTypePost {
+		return err
+	}
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return err
+	}
+	created, err := a.isCreated(ctx, post.ID)
+	if err != nil {
+		return err
+	}
+	switch activityType {
+	case "Create", "Update":
+		switch {
+		case post.Status != consts.PostStatusPublished || post.Password != "":
+			if !created {
+				return nil
+			}
+			// a post which is no longer public is deleted from the fediverse
+			activityType = "Delete"
+		case created:
+			activityType = "Update"
+		case activityType == "Update":
+			return nil
+		}
+	case "Delete":
+		if !created {
+			return nil
+		}
+	default:
+		return xerr.BadParam.New("type=%s", activityType).WithMsg("unsupported activity type")
+	}
+
+	var activity *vo.ActivityPubObject
+	if activityType == "Delete" {
+		activity = buildActivity(urls, "Delete", urls.article(post.ID)+"#delete/"+util.GenUUIDWithOutDash(), &vo.ActivityPubObject{
+			ID:         urls.article(post.ID),
+			Type:       "Tombstone",
+			FormerType: "Article",
+		})
+	} else {
+		tags, err := a.PostTagService.ListTagByPostID(ctx, post.ID)
+		if err != nil {
+			return err
+		}
+		article, err := a.buildArticle(ctx, urls, post, tags)
+		if err != nil {
+			return err
+		}
+		id := article.ID + "#create"
+		if activityType == "Update" {
+			id = article.ID + "#update/" + util.GenUUIDWithOutDash()
+		}
+		activity = buildActivity(urls, activityType, id, article)
+	}
+	if activityType != "Update" {
+		if err := a.setCreated(ctx, post.ID, activityType == "Create"); err != nil {
+			return err
+		}
+	}
+	inboxes, err := a.listFollowerInboxes(ctx)
+	if err != nil {
+		return err
+	}
+	return a.deliver(ctx, activityType, post.ID, activity, inboxes)
+}
+
+// isCreated tells whether the article of the post has been created in the fediverse and not deleted since.
+func (a *activityPubServiceImpl) isCreated(ctx context.Context, postID int32) (bool, error) {
+	articleDAL := dal.GetQueryByCtx(ctx).ActivityPubArticle
+	count, err := articleDAL.WithContext(ctx).Where(articleDAL.PostID.Eq(postID)).Count()
+	if err != nil {
+		return false, WrapDBErr(err)
+	}
+	return count > 0, nil
+}
+
+// setCreated records whether the article of the post is in the fediverse.
+// It is kept apart from the deliveries, as nothing is delivered while the blog has no followers.
+func (a *activityPubServiceImpl) setCreated(ctx context.Context, postID int32, created bool) error {
+	articleDAL := dal.GetQueryByCtx(ctx).ActivityPubArticle
+	if !created {
+		_, err := articleDAL.WithContext(ctx).Where(articleDAL.PostID.Eq(postID)).Delete()
+		return WrapDBErr(err)
+	}
+	return WrapDBErr(articleDAL.WithContext(ctx).Create(&entity.ActivityPubArticle{PostID: postID}))
+}
+
+// listFollowerInboxes returns the inboxes of the followers, the followers on the same server share their shared inbox.
+func (a *activityPubServiceImpl) listFollowerInboxes(ctx context.Context) ([]string, error) {
+	followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+	followers, err := followerDAL.WithContext(ctx).Order(followerDAL.ID).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	inboxes := make([]string, 0, len(followers))
+	seen := make(map[string]struct{}, len(followers))
+	for _, follower := range followers {
+		inbox := util.IfElse(follower.SharedInbox != "", follower.SharedInbox, follower.Inbox).(string)
+		if _, ok := seen[inbox]; ok {
+			continue
+		}
+		seen[inbox] = struct{}{}
+		inboxes = append(inboxes, inbox)
+	}
+	return inboxes, nil
+}
+
+// deliver records a delivery of the activity for every inbox, and sends them in background.
+// The deliveries left out when the queue is full are sent by the retry loop once their lease ends.
+func (a *activityPubServiceImpl) deliver(ctx context.Context, activityType string, postID int32, activity *vo.ActivityPubObject, inboxes []string) error {
+	activity.Context = activityStreamsContext
+	payload, err := json.Marshal(activity)
+	if err != nil {
+		return xerr.WithStatus(err, xerr.StatusInternalServerError)
+	}
+	for _, inbox := range inboxes {
+		// the delivery is sent right away, the lease keeps the retry loop from sending it at the same time
+		nextRetryTime := time.Now().Add(activityPubLease)
+		delivery := &entity.ActivityPubDelivery{
+			Inbox:         inbox,
+			ActivityType:  activityType,
+			PostID:        postID,
+			Payload:       string(payload),
+			Status:        consts.ActivityPubDeliveryStatusPending,
+			NextRetryTime: &nextRetryTime,
+		}
+		deliveryDAL := dal.GetQueryByCtx(ctx).ActivityPubDelivery
+		if err := deliveryDAL.WithContext(ctx).Create(delivery); err != nil {
+			return WrapDBErr(err)
+		}
+		queued := a.sender.TrySubmit(func() {
+			a.send(context.Background(), delivery)
+		})
+		if !queued {
+			log.CtxWarn(ctx, "activitypub queue is full, the delivery is left to the retry", zap.Int32("deliveryID", delivery.ID))
+		}
+	}
+	return nil
+}
+
+// send posts the activity and records the result, the delivery is retried later if it fails,
+// unless the inbox refuses it for good.
+func (a *activityPubServiceImpl) send(ctx context.Context, delivery *entity.ActivityPubDelivery) {
+	responseStatus, err := a.post(ctx, delivery)
+	delivery.Attempts++
+	delivery.ResponseStatus = int32(responseStatus)
+	delivery.Error = ""
+	delivery.UpdateTime = util.TimePtr(time.Now())
+	if err == nil && (responseStatus < http.StatusOK || responseStatus >= http.StatusMultipleChoices) {
+		err = xerr.NoType.New("unexpected response status %d", responseStatus)
+	}
+	refused := responseStatus >= http.StatusBadRequest && responseStatus < http.StatusInternalServerError &&
+		responseStatus != http.StatusRequestTimeout && responseStatus != http.StatusTooManyRequests
+	switch {
+	case err == nil:
+		delivery.Status = consts.ActivityPubDeliveryStatusSuccess
+		delivery.NextRetryTime = nil
+	case refused || delivery.Attempts >= activityPubMaxAttempts:
+		delivery.Status = consts.ActivityPubDeliveryStatusFailed
+		delivery.NextRetryTime = nil
+	default:
+		nextRetryTime := time.Now().Add(activityPubRetryBaseDelay << (delivery.Attempts - 1))
+		delivery.Status = consts.ActivityPubDeliveryStatusPending
+		delivery.NextRetryTime = &nextRetryTime
+	}
+	if err != nil {
+		delivery.Error = truncateRunes(err.Error(), activityPubMaxTextLength)
+		log.CtxWarn(ctx, "activitypub delivery failed", zap.Int32("deliveryID", delivery.ID), zap.String("inbox", delivery.Inbox), zap.Error(err))
+	}
+	deliveryDAL := dal.GetQueryByCtx(ctx).ActivityPubDelivery
+	if err := deliveryDAL.WithContext(ctx).Save(delivery); err != nil {
+		log.CtxError(ctx, "save activitypub delivery err", zap.Int32("deliveryID", delivery.ID), zap.Error(err))
+	}
+}
+
+func (a *activityPubServiceImpl) post(ctx context.Context, delivery *entity.ActivityPubDelivery) (int, error) {
+	urls, err := a.getURLs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	key, _, err := a.getActorKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, activityPubTimeout)
+	defer cancel()
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Inbox, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/activity+json")
+	req.Header.Set("Accept", activityPubAccept)
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion+" activitypub")
+	if err := signRequest(req, urls.keyID, key, body); err != nil {
+		return 0, err
+	}
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	return resp.StatusCode, nil
+}
+
+// retryDueDeliveries sends the pending deliveries whose retry time has come, they survive restarts as they are kept in the database.
+func (a *activityPubServiceImpl) retryDueDeliveries(ctx context.Context) {
+	now := time.Now()
+	deliveryDAL := dal.GetQueryByCtx(ctx).ActivityPubDelivery
+	deliveries, err := deliveryDAL.WithContext(ctx).Where(
+		deliveryDAL.Status.Eq(consts.ActivityPubDeliveryStatusPending),
+		deliveryDAL.NextRetryTime.Lte(now),
+	).Order(deliveryDAL.NextRetryTime).Limit(100).Find()
+	if err != nil {
+		log.CtxError(ctx, "list due activitypub deliveries err", zap.Error(err))
+		return
+	}
+	for _, delivery := range deliveries {
+		// claim the delivery so that it is not sent twice
+		updateResult, err := deliveryDAL.WithContext(ctx).Where(
+			deliveryDAL.ID.Eq(delivery.ID),
+			deliveryDAL.Status.Eq(consts.ActivityPubDeliveryStatusPending),
+			deliveryDAL.NextRetryTime.Lte(now),
+		).UpdateSimple(deliveryDAL.NextRetryTime.Value(now.Add(activityPubLease)))
+		if err != nil || updateResult.RowsAffected != 1 {
+			continue
+		}
+		a.send(ctx, delivery)
+	}
+}
+
+func (a *activityPubServiceImpl) PageFollowers(ctx context.Context, page param.Page) ([]*entity.ActivityPubFollower, int64, error) {
+	followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+	followers, totalCount, err := followerDAL.WithContext(ctx).Order(followerDAL.ID.Desc()).FindByPage(page.PageNum*page.PageSize, page.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return followers, totalCount, nil
+}
+
+func (a *activityPubServiceImpl) DeleteFollower(ctx context.Context, id int32) error {
+	followerDAL := dal.GetQueryByCtx(ctx).ActivityPubFollower
+	deleteResult, err := followerDAL.WithContext(ctx).Where(followerDAL.ID.Eq(id)).Delete()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if deleteResult.RowsAffected != 1 {
+		return xerr.NoRecord.New("follower id=%d", id).WithMsg("follower does not exist").WithStatus(xerr.StatusNotFound)
+	}
+	return nil
+}
+
+func (a *activityPubServiceImpl) PageDeliveries(ctx context.Context, page param.Page) ([]*entity.ActivityPubDelivery, int64, error) {
+	deliveryDAL := dal.GetQueryByCtx(ctx).ActivityPubDelivery
+	deliveries, totalCount, err := deliveryDAL.WithContext(ctx).Order(deliveryDAL.ID.Desc()).FindByPage(page.PageNum*page.PageSize, page.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return deliveries, totalCount, nil
+}
+
+func (a *activityPubServiceImpl) ConvertToFollowerDTOs(followers []*entity.ActivityPubFollower) []*dto.ActivityPubFollower {
+	result := make([]*dto.ActivityPubFollower, 0, len(followers))
+	for _, follower := range followers {
+		result = append(result, &dto.ActivityPubFollower{
+			ID:         follower.ID,
+			ActorID:    follower.ActorID,
+			Username:   follower.Username,
+			Name:       follower.Name,
+			URL:        follower.URL,
+			CreateTime: follower.CreateTime.UnixMilli(),
+		})
+	}
+	return result
+}
+
+func (a *activityPubServiceImpl) ConvertToDeliveryDTOs(deliveries []*entity.ActivityPubDelivery) []*dto.ActivityPubDelivery {
+	result := make([]*dto.ActivityPubDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryDTO := &dto.ActivityPubDelivery{
+			ID:             delivery.ID,
+			Inbox:          delivery.Inbox,
+			ActivityType:   delivery.ActivityType,
+			PostID:         delivery.PostID,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			Error:          delivery.Error,
+			CreateTime:     delivery.CreateTime.UnixMilli(),
+		}
+		if delivery.NextRetryTime != nil && delivery.Status == consts.ActivityPubDeliveryStatusPending {
+			nextRetryTime := delivery.NextRetryTime.UnixMilli()
+			deliveryDTO.NextRetryTime = &nextRetryTime
+		}
+		if delivery.UpdateTime != nil {
+			updateTime := delivery.UpdateTime.UnixMilli()
+			deliveryDTO.UpdateTime = &updateTime
+		}
+		result = append(result, deliveryDTO)
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package impl
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// the HTTP signatures follow https://datatracker.ietf.org/doc/html/draft-cavage-http-signatures-12 as Mastodon does
+const (
+	httpSignatureAlgorithm = "rsa-sha256"
+	// httpSignatureMaxSkew is how far the date of a signed request may be from now
+	httpSignatureMaxSkew = 12 * time.Hour
+)
+
+func generateActorKey() (privateKeyPem string, publicKeyPem string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", err
+	}
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", err
+	}
+	privateKeyPem = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey}))
+	publicKeyPem = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+	return privateKeyPem, publicKeyPem, nil
+}
+
+func parsePrivateKeyPem(privateKeyPem string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, xerr.NoType.New("").WithMsg("invalid private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("invalid private key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, xerr.NoType.New("").WithMsg("the private key is not a RSA key")
+	}
+	return rsaKey, nil
+}
+
+// parsePublicKeyPem accepts the PKIX keys and the PKCS #1 keys which some servers publish.
+func parsePublicKeyPem(publicKeyPem string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	if block == nil {
+		return nil, xerr.BadParam.New("").WithMsg("invalid public key")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid public key")
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, xerr.BadParam.New("").WithMsg("the public key is not a RSA key")
+	}
+	return rsaKey, nil
+}
+
+func bodyDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// signRequest signs the request with the key of the blog actor, a request with a body is signed with its digest.
+func signRequest(req *http.Request, keyID string, key *rsa.PrivateKey, body []byte) error {
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	headers := []string{"(request-target)", "host", "date"}
+	if body != nil {
+		req.Header.Set("Digest", bodyDigest(body))
+		headers = append(headers, "digest")
+	}
+	hashed := sha256.Sum256([]byte(buildSigningString(req, req.Host, headers)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Signature", `keyId="`+keyID+`",algorithm="`+httpSignatureAlgorithm+`",headers="`+strings.Join(headers, " ")+
+		`",signature="`+base64.StdEncoding.EncodeToString(signature)+`"`)
+	return nil
+}
+
+// buildSigningString builds the string to sign, the host is given as the one of a received request may be changed by the proxies.
+func buildSigningString(req *http.Request, host string, headers []string) string {
+	lines := make([]string, 0, len(headers))
+	for _, header := range headers {
+		var value string
+		switch header {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = host
+		default:
+			value = strings.Join(req.Header.Values(header), ", ")
+		}
+		lines = append(lines, header+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+type httpSignature struct {
+	KeyID     string
+	Algorithm string
+	Headers   []string
+	Signature []byte
+}
+
+func parseSignatureHeader(header string) (*httpSignature, error) {
+	invalid := xerr.Forbidden.New("signature=%v", header).WithMsg("invalid signature").WithStatus(xerr.StatusUnauthorized)
+	signature := &httpSignature{Headers: []string{"date"}}
+	for _, param := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			return nil, invalid
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "keyId":
+			signature.KeyID = value
+		case "algorithm":
+			signature.Algorithm = value
+		case "headers":
+			signature.Headers = strings.Fields(strings.ToLower(value))
+		case "signature":
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, invalid
+			}
+			signature.Signature = decoded
+		}
+	}
+	if signature.KeyID == "" || len(signature.Signature) == 0 {
+		return nil, invalid
+	}
+	return signature, nil
+}
+
+// verifyRequest checks the signature of the received request with the public key of the signer.
+// The request target, the host, the date and the digest of the body have to be signed, and the date has to be recent.
+// The host is the one of the blog url, which the request is sent to, rather than the Host header which the sender chooses.
+func verifyRequest(req *http.Request, host string, signature *httpSignature, publicKey *rsa.PublicKey, body []byte) error {
+	invalid := func(msg string) error {
+		return xerr.Forbidden.New("keyId=%v", signature.KeyID).WithMsg(msg).WithStatus(xerr.StatusUnauthorized)
+	}
+	if signature.Algorithm != "" && signature.Algorithm != httpSignatureAlgorithm && signature.Algorithm != "hs2019" {
+		return invalid("unsupported signature algorithm")
+	}
+	signed := make(map[string]bool, len(signature.Headers))
+	for _, header := range signature.Headers {
+		signed[header] = true
+	}
+	if !signed["(request-target)"] || !signed["host"] || !signed["date"] || (body != nil && !signed["digest"]) {
+		return invalid("the request target, host, date and digest have to be signed")
+	}
+	date, err := http.ParseTime(req.Header.Get("Date"))
+	if err != nil {
+		return invalid("invalid date")
+	}
+	if skew := time.Since(date); skew > httpSignatureMaxSkew || skew < -httpSignatureMaxSkew {
+		return invalid("the date of the request is out of range")
+	}
+	if body != nil && !digestMatches(req.Header.Get("Digest"), body) {
+		return invalid("the digest does not match the body")
+	}
+	hashed := sha256.Sum256([]byte(buildSigningString(req, host, signature.Headers)))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature.Signature); err != nil {
+		return invalid("invalid signature")
+	}
+	return nil
+}
+
+// digestMatches checks the SHA-256 digest, the header may list the digests of other algorithms as well.
+func digestMatches(header string, body []byte) bool {
+	expected := bodyDigest(body)
+	for _, digest := range strings.Split(header, ",") {
+		algorithm, value, ok := strings.Cut(strings.TrimSpace(digest), "=")
+		if ok && strings.EqualFold(algorithm, "SHA-256") && "SHA-256="+value == expected {
+			return true
+		}
+	}
+	return false
+}
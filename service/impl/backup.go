@@ -15,6 +15,7 @@ import (
 	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
 	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/param"
 	"github.com/go-sonic/sonic/service"
 	"github.com/go-sonic/sonic/util"
 	"github.com/go-sonic/sonic/util/xerr"
@@ -93,6 +94,10 @@ func (b *backupServiceImpl) ListFiles(ctx context.Context, path string, backupTy
 		prefix = consts.SonicDataExportPrefix
 	case service.Markdown:
 		prefix = consts.SonicBackupMarkdownPrefix
+	case service.Epub:
+		prefix = consts.SonicBackupEpubPrefix
+	case service.Comment:
+		prefix = consts.SonicBackupCommentPrefix
 	}
 	err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -149,6 +154,51 @@ func (b *backupServiceImpl) ImportMarkdown(ctx context.Context, fileHeader *mult
 	return err
 }
 
+func (b *backupServiceImpl) ImportGhost(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("upload file error")
+	}
+	defer file.Close()
+	return b.ExportImportService.ImportGhost(ctx, file)
+}
+
+func (b *backupServiceImpl) ImportHalo(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("upload file error")
+	}
+	defer file.Close()
+	return b.ExportImportService.ImportHalo(ctx, file)
+}
+
+func (b *backupServiceImpl) ImportDisqus(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("upload file error")
+	}
+	defer file.Close()
+	return b.ExportImportService.ImportDisqus(ctx, file)
+}
+
+func (b *backupServiceImpl) ImportTwikoo(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("upload file error")
+	}
+	defer file.Close()
+	return b.ExportImportService.ImportTwikoo(ctx, file)
+}
+
+func (b *backupServiceImpl) ImportWaline(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.ImportReport, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("upload file error")
+	}
+	defer file.Close()
+	return b.ExportImportService.ImportWaline(ctx, file)
+}
+
 func (b *backupServiceImpl) ExportData(ctx context.Context) (*dto.BackupDTO, error) {
 	data := make(map[string]interface{})
 	data["version"] = consts.SonicVersion
@@ -210,6 +260,22 @@ func (b *backupServiceImpl) ExportMarkdown(ctx context.Context, needFrontMatter
 	return b.buildBackupDTO(ctx, string(service.Markdown), fileName)
 }
 
+func (b *backupServiceImpl) ExportEpub(ctx context.Context, exportParam *param.ExportEpub) (*dto.BackupDTO, error) {
+	fileName, err := b.ExportImportService.ExportEpub(ctx, exportParam)
+	if err != nil {
+		return nil, err
+	}
+	return b.buildBackupDTO(ctx, string(service.Epub), fileName)
+}
+
+func (b *backupServiceImpl) ExportComments(ctx context.Context, exportParam *param.ExportComment) (*dto.BackupDTO, error) {
+	fileName, err := b.ExportImportService.ExportComments(ctx, exportParam)
+	if err != nil {
+		return nil, err
+	}
+	return b.buildBackupDTO(ctx, string(service.Comment), fileName)
+}
+
 func (b *backupServiceImpl) buildBackupDTO(ctx context.Context, baseBackupURL string, backupFilePath string) (*dto.BackupDTO, error) {
 	backupDTO := &dto.BackupDTO{}
 	backupFilename := filepath.Base(backupFilePath)
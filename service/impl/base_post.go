@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/event"
 	"github.com/go-sonic/sonic/log"
 	"github.com/go-sonic/sonic/model/entity"
 	"github.com/go-sonic/sonic/model/param"
@@ -24,10 +25,12 @@ import (
 type basePostServiceImpl struct {
 	OptionService      service.OptionService
 	BaseCommentService service.BaseCommentService
+	MetaService        service.MetaService
+	Event              event.Bus
 	CounterCache       *util.CounterCache[int32]
 }
 
-func NewBasePostService(optionService service.OptionService, baseCommentService service.BaseCommentService) service.BasePostService {
+func NewBasePostService(optionService service.OptionService, baseCommentService service.BaseCommentService, metaService service.MetaService, event event.Bus) service.BasePostService {
 	counterCache := util.NewCounterCache(time.Second*5, nil, func(postID int32, count int64) {
 		ctx := context.Background()
 		postDAL := dal.GetQueryByCtx(ctx).Post
@@ -40,6 +43,8 @@ func NewBasePostService(optionService service.OptionService, baseCommentService
 		CounterCache:       counterCache,
 		OptionService:      optionService,
 		BaseCommentService: baseCommentService,
+		MetaService:        metaService,
+		Event:              event,
 	}
 	return b
 }
@@ -224,7 +229,11 @@ func (b basePostServiceImpl) GenerateSummary(ctx context.Context, htmlContent st
 }
 
 func (b basePostServiceImpl) Delete(ctx context.Context, postID int32) error {
-	err := dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
+	post, err := b.GetByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	err = dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
 		postDAL := tx.Post
 		postTagDAL := tx.PostTag
 		postCategoryDAL := tx.PostCategory
@@ -256,7 +265,13 @@ func (b basePostServiceImpl) Delete(ctx context.Context, postID int32) error {
 		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	b.Event.Publish(ctx, &event.PostDeleteEvent{
+		Post: post,
+	})
+	return nil
 }
 
 func (b basePostServiceImpl) UpdateStatus(ctx context.Context, postID int32, status consts.PostStatus) (*entity.Post, error) {
@@ -276,12 +291,28 @@ func (b basePostServiceImpl) UpdateStatus(ctx context.Context, postID int32, sta
 	if updateResult.RowsAffected != 1 {
 		return nil, xerr.NoType.New("update post status failed postID=%v", postID).WithMsg("update post status failed")
 	}
+	if post.Status != status {
+		b.Event.Publish(ctx, &event.PostStatusUpdateEvent{
+			PostID:    postID,
+			OldStatus: post.Status,
+			Status:    status,
+		})
+	}
+	if post.Status != consts.PostStatusPublished && status == consts.PostStatusPublished {
+		b.Event.Publish(ctx, &event.PostPublishedEvent{
+			PostID: postID,
+		})
+	}
 	post.Status = status
 	return post, nil
 }
 
 func (b basePostServiceImpl) DeleteBatch(ctx context.Context, postIDs []int32) error {
-	err := dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
+	posts, err := b.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+	err = dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
 		postDAL := tx.Post
 		postTagDAL := tx.PostTag
 		postCategoryDAL := tx.PostCategory
@@ -313,11 +344,23 @@ func (b basePostServiceImpl) DeleteBatch(ctx context.Context, postIDs []int32) e
 		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		b.Event.Publish(ctx, &event.PostDeleteEvent{
+			Post: post,
+		})
+	}
+	return nil
 }
 
 func (b basePostServiceImpl) CreateOrUpdate(ctx context.Context, post *entity.Post, categoryIDs, tagIDs []int32, metas []param.Meta) (*entity.Post, error) {
-	err := dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
+	metas, err := b.MetaService.ValidateMetas(ctx, post.Type, metas)
+	if err != nil {
+		return nil, err
+	}
+	err = dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
 		postDAL := tx.Post
 		postCategoryDAL := tx.PostCategory
 		postTagDAL := tx.PostTag
@@ -464,7 +507,11 @@ func (b basePostServiceImpl) UpdateStatusBatch(ctx context.Context, status const
 	for postID := range uniquePostIDMap {
 		uniqueIDs = append(uniqueIDs, postID)
 	}
-	err := dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
+	oldPosts, err := b.GetByPostIDs(ctx, uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+	err = dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
 		postDAL := tx.Post
 		updateResult, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(uniqueIDs...)).UpdateColumnSimple(postDAL.Status.Value(status))
 		if err != nil {
@@ -478,6 +525,24 @@ func (b basePostServiceImpl) UpdateStatusBatch(ctx context.Context, status const
 	if err != nil {
 		return nil, err
 	}
+	for _, post := range oldPosts {
+		if post.Status != status {
+			b.Event.Publish(ctx, &event.PostStatusUpdateEvent{
+				PostID:    post.ID,
+				OldStatus: post.Status,
+				Status:    status,
+			})
+		}
+	}
+	if status == consts.PostStatusPublished {
+		for _, post := range oldPosts {
+			if post.Status != consts.PostStatusPublished {
+				b.Event.Publish(ctx, &event.PostPublishedEvent{
+					PostID: post.ID,
+				})
+			}
+		}
+	}
 	postDAL := dal.GetQueryByCtx(ctx).Post
 	posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(uniqueIDs...)).Find()
 	if err != nil {
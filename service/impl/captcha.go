@@ -0,0 +1,248 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/go-sonic/sonic/cache"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+)
+
+const (
+	captchaExpiration        = time.Minute * 5
+	captchaFailureExpiration = time.Hour
+	captchaLength            = 5
+	// the characters easy to confuse such as 0 and O are left out
+	captchaCharacters = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	captchaScale      = 3
+)
+
+type captchaServiceImpl struct {
+	OptionService service.OptionService
+	Cache         cache.Cache
+	failureMutex  sync.Mutex
+	// answerMutex takes the answer and removes it at once, so that a captcha cannot be used by two requests
+	answerMutex sync.Mutex
+}
+
+func NewCaptchaService(optionService service.OptionService, cache cache.Cache) service.CaptchaService {
+	return &captchaServiceImpl{
+		OptionService: optionService,
+		Cache:         cache,
+	}
+}
+
+func (c *captchaServiceImpl) Generate(ctx context.Context) (*dto.Captcha, error) {
+	captchaType, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CaptchaType, property.CaptchaType.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	var text, answer string
+	if captchaType.(string) == consts.CaptchaTypeArithmetic {
+		text, answer = arithmeticChallenge()
+	} else {
+		captchaType = consts.CaptchaTypeImage
+		text = randomCaptchaText()
+		answer = text
+	}
+	img, err := drawCaptcha(text)
+	if err != nil {
+		return nil, err
+	}
+	id := util.GenUUIDWithOutDash()
+	c.Cache.Set(consts.CaptchaKeyPrefix+id, answer, captchaExpiration)
+	return &dto.Captcha{
+		ID:    id,
+		Type:  captchaType.(string),
+		Image: "data:image/png;base64," + base64.StdEncoding.EncodeToString(img),
+	}, nil
+}
+
+func (c *captchaServiceImpl) Verify(ctx context.Context, id, answer string) bool {
+	if id == "" || answer == "" {
+		return false
+	}
+	c.answerMutex.Lock()
+	value, ok := c.Cache.Get(consts.CaptchaKeyPrefix + id)
+	if ok {
+		c.Cache.Delete(consts.CaptchaKeyPrefix + id)
+	}
+	c.answerMutex.Unlock()
+	if !ok {
+		return false
+	}
+	return strings.EqualFold(value.(string), strings.TrimSpace(answer))
+}
+
+func (c *captchaServiceImpl) CommentRequired(ctx context.Context) (bool, error) {
+	enabled, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CaptchaCommentEnabled, false)
+	if err != nil {
+		return false, err
+	}
+	return enabled.(bool), nil
+}
+
+func (c *captchaServiceImpl) AuthenticationRequired(ctx context.Context, ip string) (bool, error) {
+	enabled, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CaptchaAuthenticationEnabled, false)
+	if err != nil || !enabled.(bool) {
+		return false, err
+	}
+	failures, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CaptchaAuthenticationFailures, property.CaptchaAuthenticationFailures.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	if failures.(int) <= 0 {
+		return true, nil
+	}
+	count, ok := c.Cache.Get(consts.CaptchaFailureKeyPrefix + ip)
+	return ok && count.(int) >= failures.(int), nil
+}
+
+func (c *captchaServiceImpl) AddAuthenticationFailure(ctx context.Context, ip string) {
+	c.failureMutex.Lock()
+	defer c.failureMutex.Unlock()
+	count := 0
+	if value, ok := c.Cache.Get(consts.CaptchaFailureKeyPrefix + ip); ok {
+		count = value.(int)
+	}
+	c.Cache.Set(consts.CaptchaFailureKeyPrefix+ip, count+1, captchaFailureExpiration)
+}
+
+func (c *captchaServiceImpl) ResetAuthenticationFailure(ctx context.Context, ip string) {
+	c.Cache.Delete(consts.CaptchaFailureKeyPrefix + ip)
+}
+
+func randomCaptchaText() string {
+	text := make([]byte, captchaLength)
+	for i := range text {
+		text[i] = captchaCharacters[randomInt(len(captchaCharacters))]
+	}
+	return string(text)
+}
+
+func arithmeticChallenge() (text string, answer string) {
+	a, b := randomInt(20)+1, randomInt(20)+1
+	switch randomInt(3) {
+	case 0:
+		return strconv.Itoa(a) + "+" + strconv.Itoa(b) + "=?", strconv.Itoa(a + b)
+	case 1:
+		if a < b {
+			a, b = b, a
+		}
+		return strconv.Itoa(a) + "-" + strconv.Itoa(b) + "=?", strconv.Itoa(a - b)
+	default:
+		a, b = a%10+1, b%10+1
+		return strconv.Itoa(a) + "x" + strconv.Itoa(b) + "=?", strconv.Itoa(a * b)
+	}
+}
+
+// drawCaptcha draws the text with a wave distortion and noise, and encodes it as PNG.
+func drawCaptcha(text string) ([]byte, error) {
+	face := basicfont.Face7x13
+	src := image.NewRGBA(image.Rect(0, 0, len(text)*(face.Advance+2)+4, face.Height+6))
+	for i, ch := range text {
+		drawer := &font.Drawer{
+			Dst:  src,
+			Src:  image.NewUniform(randomDarkColor()),
+			Face: face,
+			Dot:  fixed.P(2+i*(face.Advance+2), face.Ascent+2+randomInt(4)),
+		}
+		drawer.DrawString(string(ch))
+	}
+
+	bounds := image.Rect(0, 0, src.Bounds().Dx()*captchaScale, src.Bounds().Dy()*captchaScale)
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, image.NewUniform(color.RGBA{R: 245, G: 245, B: 240, A: 255}), image.Point{}, draw.Src)
+
+	amplitude := float64(captchaScale * 2)
+	period := float64(bounds.Dx()) / (1 + float64(randomInt(3)))
+	phase := float64(randomInt(628)) / 100
+	for x := 0; x < bounds.Dx(); x++ {
+		offset := int(amplitude * math.Sin(2*math.Pi*float64(x)/period+phase))
+		for y := 0; y < bounds.Dy(); y++ {
+			pixel := src.RGBAAt(x/captchaScale, (y+offset)/captchaScale)
+			if y+offset >= 0 && pixel.A > 0 {
+				dst.SetRGBA(x, y, pixel)
+			}
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		drawLine(dst, randomInt(bounds.Dx()), randomInt(bounds.Dy()), randomInt(bounds.Dx()), randomInt(bounds.Dy()), randomDarkColor())
+	}
+	for i := 0; i < bounds.Dx()*bounds.Dy()/60; i++ {
+		dst.SetRGBA(randomInt(bounds.Dx()), randomInt(bounds.Dy()), randomDarkColor())
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.SetRGBA(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func randomDarkColor() color.RGBA {
+	return color.RGBA{R: uint8(randomInt(150)), G: uint8(randomInt(150)), B: uint8(randomInt(150)), A: 255}
+}
+
+func randomInt(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(v.Int64())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/event"
 	"github.com/go-sonic/sonic/model/dto"
 	"github.com/go-sonic/sonic/model/entity"
 	"github.com/go-sonic/sonic/model/param"
@@ -21,11 +22,13 @@ import (
 
 type categoryServiceImpl struct {
 	OptionService service.OptionService
+	Event         event.Bus
 }
 
-func NewCategoryService(optionService service.OptionService) service.CategoryService {
+func NewCategoryService(optionService service.OptionService, event event.Bus) service.CategoryService {
 	return &categoryServiceImpl{
 		OptionService: optionService,
+		Event:         event,
 	}
 }
 
@@ -275,6 +278,7 @@ func (c categoryServiceImpl) Create(ctx context.Context, categoryParam *param.Ca
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	c.Event.Publish(ctx, &event.CategoryUpdateEvent{})
 	return category, nil
 }
 
@@ -283,6 +287,7 @@ func (c *categoryServiceImpl) Update(ctx context.Context, categoryParam *param.C
 	if err := executor.Update(ctx, categoryParam); err != nil {
 		return nil, err
 	}
+	c.Event.Publish(ctx, &event.CategoryUpdateEvent{})
 
 	categoryDAL := dal.GetQueryByCtx(ctx).Category
 	category, err := categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Eq(categoryParam.ID)).First()
@@ -294,6 +299,7 @@ func (c categoryServiceImpl) UpdateBatch(ctx context.Context, categoryParams []*
 	if err := executor.UpdateBatch(ctx, categoryParams); err != nil {
 		return nil, err
 	}
+	c.Event.Publish(ctx, &event.CategoryUpdateEvent{})
 
 	categoryDAL := dal.GetQueryByCtx(ctx).Category
 	categoryIDs := make([]int32, 0)
@@ -308,7 +314,11 @@ func (c categoryServiceImpl) UpdateBatch(ctx context.Context, categoryParams []*
 }
 
 func (c categoryServiceImpl) Delete(ctx context.Context, categoryID int32) (err error) {
-	return newCategoryUpdateExecutor(ctx).Delete(ctx, categoryID)
+	if err := newCategoryUpdateExecutor(ctx).Delete(ctx, categoryID); err != nil {
+		return err
+	}
+	c.Event.Publish(ctx, &event.CategoryUpdateEvent{})
+	return nil
 }
 
 func (c categoryServiceImpl) ListByIDs(ctx context.Context, categoryIDs []int32) ([]*entity.Category, error) {
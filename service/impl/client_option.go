@@ -102,6 +102,8 @@ func (c *clientOptionServiceImpl) getPrivateOption() map[string]struct{} {
 		property.UpOssStyleRule,
 		property.UpOssThumbnailStyleRule,
 		property.JWTSecret,
+		property.CommentAkismetKey,
+		property.ActivityPubPrivateKey,
 	}
 	for _, p := range privateProperty {
 		privateOption[p.KeyValue] = struct{}{}
@@ -0,0 +1,185 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"go.uber.org/zap"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const commentEditTokenSubject = "comment_edit"
+
+// commentEditClaims binds the token to the creation time as well, because the ID of a deleted comment may be reused.
+type commentEditClaims struct {
+	CommentID  int32 `json:"comment_id"`
+	CreateTime int64 `json:"create_time"`
+	jwt.StandardClaims
+}
+
+func (b baseCommentServiceImpl) BuildEditToken(ctx context.Context, comment *entity.Comment) (string, error) {
+	window := b.getEditWindow(ctx)
+	if window <= 0 {
+		return "", nil
+	}
+	secret, err := b.getEditTokenSecret(ctx)
+	if err != nil {
+		return "", err
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &commentEditClaims{
+		CommentID:  comment.ID,
+		CreateTime: comment.CreateTime.Unix(),
+		StandardClaims: jwt.StandardClaims{
+			Subject:   commentEditTokenSubject,
+			ExpiresAt: comment.CreateTime.Add(window).Unix(),
+		},
+	}).SignedString(secret)
+	if err != nil {
+		return "", xerr.WithStatus(err, xerr.StatusInternalServerError)
+	}
+	return token, nil
+}
+
+func (b baseCommentServiceImpl) UpdateByAuthor(ctx context.Context, commentID int32, editParam *param.CommentAuthorEdit) (*entity.Comment, error) {
+	comment, err := b.authorizeAuthor(ctx, commentID, editParam.Token)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Content == editParam.Content {
+		return comment, nil
+	}
+
+	edited := *comment
+	edited.Content = editParam.Content
+	if err := b.CommentBlackService.Check(ctx, &edited); err != nil {
+		return nil, err
+	}
+	checker, err := b.CommentSpamService.Check(ctx, &service.SpamCandidate{
+		Comment: &edited,
+		Referer: util.GetReferer(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if checker != "" {
+		log.CtxInfo(ctx, "edited comment is regarded as spam", zap.String("checker", checker), zap.Int32("commentID", commentID))
+		edited.Status = consts.CommentStatusSpam
+	} else if !comment.IsAdmin {
+		// the edited content is checked again, the approval of the previous content does not apply to it
+		needCheck, err := b.OptionService.GetOrByDefaultWithErr(ctx, property.CommentNewNeedCheck, true)
+		if err != nil {
+			return nil, err
+		}
+		if needCheck.(bool) {
+			edited.Status = consts.CommentStatusAuditing
+		}
+	}
+
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		err := b.RevisionService.Create(txCtx, &entity.CommentRevision{
+			CommentID: comment.ID,
+			Content:   comment.Content,
+			IPAddress: util.GetClientIP(ctx),
+			UserAgent: util.GetUserAgent(ctx),
+		})
+		if err != nil {
+			return err
+		}
+		commentDAL := dal.GetQueryByCtx(txCtx).Comment
+		_, err = commentDAL.WithContext(txCtx).Where(commentDAL.ID.Eq(comment.ID)).UpdateSimple(
+			commentDAL.Content.Value(edited.Content),
+			commentDAL.Status.Value(edited.Status),
+			commentDAL.UpdateTime.Value(time.Now()),
+		)
+		return WrapDBErr(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.GetByID(ctx, comment.ID)
+}
+
+func (b baseCommentServiceImpl) DeleteByAuthor(ctx context.Context, commentID int32, token string) error {
+	comment, err := b.authorizeAuthor(ctx, commentID, token)
+	if err != nil {
+		return err
+	}
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	_, err = commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(comment.ID)).UpdateSimple(
+		commentDAL.Status.Value(consts.CommentStatusRecycle),
+		commentDAL.UpdateTime.Value(time.Now()),
+	)
+	return WrapDBErr(err)
+}
+
+// authorizeAuthor verifies the edit token and the edit window, which is checked again in case the option is changed.
+func (b baseCommentServiceImpl) authorizeAuthor(ctx context.Context, commentID int32, tokenStr string) (*entity.Comment, error) {
+	window := b.getEditWindow(ctx)
+	if window <= 0 {
+		return nil, xerr.Forbidden.New("").WithMsg("Editing comments is disabled").WithStatus(xerr.StatusForbidden)
+	}
+	secret, err := b.getEditTokenSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseWithClaims(tokenStr, &commentEditClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	})
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, xerr.Forbidden.Wrap(err).WithMsg("The comment can no longer be edited").WithStatus(xerr.StatusForbidden)
+		}
+		return nil, xerr.Forbidden.Wrap(err).WithMsg("Invalid edit token").WithStatus(xerr.StatusForbidden)
+	}
+	claims, ok := token.Claims.(*commentEditClaims)
+	if !ok || !token.Valid || claims.Subject != commentEditTokenSubject || claims.CommentID != commentID {
+		return nil, xerr.Forbidden.New("").WithMsg("Invalid edit token").WithStatus(xerr.StatusForbidden)
+	}
+	comment, err := b.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	// the database may round the time to seconds
+	if diff := comment.CreateTime.Unix() - claims.CreateTime; diff < -1 || diff > 1 {
+		return nil, xerr.Forbidden.New("").WithMsg("Invalid edit token").WithStatus(xerr.StatusForbidden)
+	}
+	if time.Since(comment.CreateTime) > window {
+		return nil, xerr.Forbidden.New("").WithMsg("The comment can no longer be edited").WithStatus(xerr.StatusForbidden)
+	}
+	if comment.Status == consts.CommentStatusRecycle || comment.Status == consts.CommentStatusSpam {
+		return nil, xerr.Forbidden.New("").WithMsg("The comment can no longer be edited").WithStatus(xerr.StatusForbidden)
+	}
+	return comment, nil
+}
+
+func (b baseCommentServiceImpl) getEditWindow(ctx context.Context) time.Duration {
+	minutes := b.OptionService.GetOrByDefault(ctx, property.CommentEditWindow).(int)
+	return time.Duration(minutes) * time.Minute
+}
+
+func (b baseCommentServiceImpl) getEditTokenSecret(ctx context.Context) ([]byte, error) {
+	secret, err := b.OptionService.GetOrByDefaultWithErr(ctx, property.JWTSecret, "")
+	if err != nil {
+		return nil, err
+	}
+	if secret.(string) == "" {
+		return nil, xerr.WithMsg(nil, "jwt secret is nil").WithStatus(xerr.StatusInternalServerError)
+	}
+	return []byte(secret.(string)), nil
+}
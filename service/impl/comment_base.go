@@ -3,11 +3,13 @@ package impl
 import (
 	"context"
 
+	"go.uber.org/zap"
 	"gorm.io/gen/field"
 
 	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
 	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/log"
 	"github.com/go-sonic/sonic/model/entity"
 	"github.com/go-sonic/sonic/model/param"
 	"github.com/go-sonic/sonic/model/property"
@@ -17,9 +19,13 @@ import (
 )
 
 type baseCommentServiceImpl struct {
-	UserService   service.UserService
-	OptionService service.OptionService
-	Event         event.Bus
+	UserService         service.UserService
+	OptionService       service.OptionService
+	CommentBlackService service.CommentBlackService
+	CommentSpamService  service.CommentSpamService
+	CaptchaService      service.CaptchaService
+	RevisionService     service.CommentRevisionService
+	Event               event.Bus
 }
 
 func (b baseCommentServiceImpl) LGetByIDs(ctx context.Context, commentIDs []int32) ([]*entity.Comment, error) {
@@ -28,11 +34,23 @@ func (b baseCommentServiceImpl) LGetByIDs(ctx context.Context, commentIDs []int3
 	return comments, WrapDBErr(err)
 }
 
-func NewBaseCommentService(userService service.UserService, optionService service.OptionService, event event.Bus) service.BaseCommentService {
+func NewBaseCommentService(
+	userService service.UserService,
+	optionService service.OptionService,
+	commentBlackService service.CommentBlackService,
+	commentSpamService service.CommentSpamService,
+	captchaService service.CaptchaService,
+	revisionService service.CommentRevisionService,
+	event event.Bus,
+) service.BaseCommentService {
 	return &baseCommentServiceImpl{
-		UserService:   userService,
-		OptionService: optionService,
-		Event:         event,
+		UserService:         userService,
+		OptionService:       optionService,
+		CommentBlackService: commentBlackService,
+		CommentSpamService:  commentSpamService,
+		CaptchaService:      captchaService,
+		RevisionService:     revisionService,
+		Event:               event,
 	}
 }
 
@@ -62,6 +80,35 @@ func (b baseCommentServiceImpl) Page(ctx context.Context, commentQuery param.Com
 	return comments, totalCount, nil
 }
 
+func (b baseCommentServiceImpl) PageByStatus(ctx context.Context, status consts.CommentStatus, page param.Page) ([]*entity.Comment, int64, error) {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	comments, totalCount, err := commentDAL.WithContext(ctx).Where(commentDAL.Status.Eq(status)).Order(commentDAL.CreateTime.Desc()).FindByPage(page.PageNum*page.PageSize, page.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return comments, totalCount, nil
+}
+
+func (b baseCommentServiceImpl) ListLatestPublic(ctx context.Context, limit int) ([]*entity.Comment, error) {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	journalDAL := dal.GetQueryByCtx(ctx).Journal
+
+	publishedPostIDs := postDAL.WithContext(ctx).Where(postDAL.Status.Eq(consts.PostStatusPublished)).Select(postDAL.ID)
+	publicJournalIDs := journalDAL.WithContext(ctx).Where(journalDAL.Type.Eq(consts.JournalTypePublic)).Select(journalDAL.ID)
+	comments, err := commentDAL.WithContext(ctx).Where(
+		commentDAL.Status.Eq(consts.CommentStatusPublished),
+		commentDAL.WithContext(ctx).Where(
+			commentDAL.Type.In(consts.CommentTypePost, consts.CommentTypeSheet),
+			commentDAL.WithContext(ctx).Columns(commentDAL.PostID).In(publishedPostIDs),
+		).Or(
+			commentDAL.Type.Eq(consts.CommentTypeJournal),
+			commentDAL.WithContext(ctx).Columns(commentDAL.PostID).In(publicJournalIDs),
+		),
+	).Order(commentDAL.CreateTime.Desc()).Limit(limit).Find()
+	return comments, WrapDBErr(err)
+}
+
 func (b baseCommentServiceImpl) Update(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
 	if comment.ID == 0 {
 		return nil, nil
@@ -90,9 +137,12 @@ func (b baseCommentServiceImpl) GetByID(ctx context.Context, commentID int32) (*
 	return comment, nil
 }
 
-func (b baseCommentServiceImpl) GetByContentID(ctx context.Context, contentID int32, commentType consts.CommentType, sort *param.Sort) ([]*entity.Comment, error) {
+func (b baseCommentServiceImpl) GetByContentID(ctx context.Context, contentID int32, commentType consts.CommentType, sort *param.Sort, status *consts.CommentStatus) ([]*entity.Comment, error) {
 	commentDAL := dal.GetQueryByCtx(ctx).Comment
 	commentDO := commentDAL.WithContext(ctx).Where(commentDAL.PostID.Eq(contentID), commentDAL.Type.Eq(commentType))
+	if status != nil {
+		commentDO = commentDO.Where(commentDAL.Status.Eq(*status))
+	}
 
 	err := BuildSort(sort, &commentDAL, &commentDO)
 	if err != nil {
@@ -104,6 +154,10 @@ func (b baseCommentServiceImpl) GetByContentID(ctx context.Context, contentID in
 
 func (b baseCommentServiceImpl) DeleteBatch(ctx context.Context, commentIDs []int32) error {
 	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	comments, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.In(commentIDs...)).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
 	deleteResult, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.In(commentIDs...)).Delete()
 	if err != nil {
 		return WrapDBErr(err)
@@ -111,11 +165,19 @@ func (b baseCommentServiceImpl) DeleteBatch(ctx context.Context, commentIDs []in
 	if deleteResult.RowsAffected != int64(len(commentIDs)) {
 		return xerr.NoType.New("").WithMsg("delete comment failed")
 	}
+	if err := b.RevisionService.DeleteByCommentIDs(ctx, commentIDs); err != nil {
+		return err
+	}
+	b.trainSpam(ctx, comments, consts.CommentStatusRecycle)
 	return nil
 }
 
 func (b baseCommentServiceImpl) Delete(ctx context.Context, commentID int32) error {
 	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	comment, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(commentID)).First()
+	if err != nil {
+		return WrapDBErr(err)
+	}
 	deleteResult, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(commentID)).Delete()
 	if err != nil {
 		return WrapDBErr(err)
@@ -123,12 +185,20 @@ func (b baseCommentServiceImpl) Delete(ctx context.Context, commentID int32) err
 	if deleteResult.RowsAffected != 1 {
 		return xerr.NoType.New("").WithMsg("delete comment failed")
 	}
+	if err := b.RevisionService.DeleteByCommentIDs(ctx, []int32{commentID}); err != nil {
+		return err
+	}
+	b.trainSpam(ctx, []*entity.Comment{comment}, consts.CommentStatusRecycle)
 	return nil
 }
 
 func (b baseCommentServiceImpl) UpdateStatusBatch(ctx context.Context, commentIDs []int32, commentStatus consts.CommentStatus) ([]*entity.Comment, error) {
 	commentDAL := dal.GetQueryByCtx(ctx).Comment
-	_, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.In(commentIDs...)).UpdateSimple(commentDAL.Status.Value(commentStatus))
+	previousComments, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.In(commentIDs...)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	_, err = commentDAL.WithContext(ctx).Where(commentDAL.ID.In(commentIDs...)).UpdateSimple(commentDAL.Status.Value(commentStatus))
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
@@ -136,6 +206,18 @@ func (b baseCommentServiceImpl) UpdateStatusBatch(ctx context.Context, commentID
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	b.trainSpam(ctx, previousComments, commentStatus)
+	approvedIDs := make(map[int32]struct{})
+	for _, comment := range previousComments {
+		if isCommentApproved(comment.Status, commentStatus) {
+			approvedIDs[comment.ID] = struct{}{}
+		}
+	}
+	for _, comment := range comments {
+		if _, ok := approvedIDs[comment.ID]; ok {
+			b.publishApproved(comment)
+		}
+	}
 	return comments, nil
 }
 
@@ -152,7 +234,12 @@ func (b baseCommentServiceImpl) UpdateStatus(ctx context.Context, commentID int3
 	if updateResult.RowsAffected != 1 {
 		return nil, xerr.NoType.New("").WithMsg("update comment status failed")
 	}
+	b.trainSpam(ctx, []*entity.Comment{comment}, commentStatus)
+	approved := isCommentApproved(comment.Status, commentStatus)
 	comment.Status = commentStatus
+	if approved {
+		b.publishApproved(comment)
+	}
 	if comment.ParentID != 0 {
 		go func() {
 			b.Event.Publish(context.TODO(), &event.CommentReplyEvent{
@@ -164,6 +251,10 @@ func (b baseCommentServiceImpl) UpdateStatus(ctx context.Context, commentID int3
 }
 
 func (b baseCommentServiceImpl) Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	return b.create(ctx, comment, &param.Comment{})
+}
+
+func (b baseCommentServiceImpl) create(ctx context.Context, comment *entity.Comment, commentParam *param.Comment) (*entity.Comment, error) {
 	if comment == nil {
 		return nil, xerr.BadParam.New("comment can not be empty")
 	}
@@ -188,6 +279,16 @@ func (b baseCommentServiceImpl) Create(ctx context.Context, comment *entity.Comm
 	authentication, _ := GetAuthorizedUser(ctx)
 	if authentication == nil {
 		comment.IsAdmin = false
+		if err := b.CommentBlackService.Check(ctx, comment); err != nil {
+			return nil, err
+		}
+		captchaRequired, err := b.CaptchaService.CommentRequired(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if captchaRequired && !b.CaptchaService.Verify(ctx, commentParam.CaptchaID, commentParam.CaptchaAnswer) {
+			return nil, xerr.BadParam.New("").WithMsg("Wrong captcha").WithStatus(xerr.StatusBadRequest)
+		}
 		needCheck, err := b.OptionService.GetOrByDefaultWithErr(ctx, property.CommentNewNeedCheck, true)
 		if err != nil {
 			return nil, err
@@ -197,6 +298,18 @@ func (b baseCommentServiceImpl) Create(ctx context.Context, comment *entity.Comm
 		} else {
 			comment.Status = consts.CommentStatusPublished
 		}
+		checker, err := b.CommentSpamService.Check(ctx, &service.SpamCandidate{
+			Comment:  comment,
+			Honeypot: commentParam.Honeypot,
+			Referer:  util.GetReferer(ctx),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if checker != "" {
+			log.CtxInfo(ctx, "comment is regarded as spam", zap.String("checker", checker), zap.String("ip", comment.IPAddress))
+			comment.Status = consts.CommentStatusSpam
+		}
 	} else {
 		comment.Email = authentication.Email
 		comment.Author = util.IfElse(authentication.Nickname == "", authentication.Username, authentication.Nickname).(string)
@@ -213,6 +326,22 @@ func (b baseCommentServiceImpl) Create(ctx context.Context, comment *entity.Comm
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	if comment.Status == consts.CommentStatusSpam {
+		return comment, nil
+	}
+	if commentParam.SubscribeThread && comment.Email != "" {
+		// the email typed by a visitor may not be the own one, so the subscription is confirmed by a mail first
+		go func() {
+			b.Event.Publish(context.TODO(), &event.CommentSubscribeEvent{
+				Comment: comment,
+			})
+		}()
+	}
+	go func() {
+		b.Event.Publish(context.TODO(), &event.CommentCreatedEvent{
+			Comment: comment,
+		})
+	}()
 	if comment.ParentID != 0 {
 		go func() {
 			b.Event.Publish(context.TODO(), &event.CommentReplyEvent{
@@ -229,6 +358,34 @@ func (b baseCommentServiceImpl) Create(ctx context.Context, comment *entity.Comm
 	return comment, nil
 }
 
+// moderateRemoteComment runs the checks of the comments posted by visitors on a comment received from another site,
+// a banned author is refused and a spam goes to the spam queue. The source of the comment is given to the checkers as the referer.
+func moderateRemoteComment(ctx context.Context, commentBlackService service.CommentBlackService, commentSpamService service.CommentSpamService, comment *entity.Comment) error {
+	if err := commentBlackService.Check(ctx, comment); err != nil {
+		return err
+	}
+	checker, err := commentSpamService.Check(ctx, &service.SpamCandidate{
+		Comment: comment,
+		Referer: comment.SourceURL,
+	})
+	if err != nil {
+		return err
+	}
+	if checker != "" {
+		log.CtxInfo(ctx, "remote comment is regarded as spam", zap.String("checker", checker), zap.String("source", comment.SourceURL))
+		comment.Status = consts.CommentStatusSpam
+	}
+	return nil
+}
+
+// deleteRemoteComment removes a comment deleted on the site it is received from,
+// the spam classifier is not trained by it as the comment is not deleted by the administrator.
+func deleteRemoteComment(ctx context.Context, comment *entity.Comment) error {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	_, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(comment.ID)).Delete()
+	return WrapDBErr(err)
+}
+
 func (b baseCommentServiceImpl) BuildAvatarURL(ctx context.Context, gravatarMD5 string, gravatarSource, gravatarDefault *string) (string, error) {
 	var gs, gd string
 	if gravatarSource == nil {
@@ -297,7 +454,38 @@ func (b baseCommentServiceImpl) CountByStatusAndContentIDs(ctx context.Context,
 
 func (b baseCommentServiceImpl) CreateBy(ctx context.Context, commentParam *param.Comment) (*entity.Comment, error) {
 	comment := b.ConvertParam(commentParam)
-	return b.Create(ctx, comment)
+	return b.create(ctx, comment, commentParam)
+}
+
+func isCommentApproved(previous, current consts.CommentStatus) bool {
+	return current == consts.CommentStatusPublished && (previous == consts.CommentStatusAuditing || previous == consts.CommentStatusSpam)
+}
+
+func (b baseCommentServiceImpl) publishApproved(comment *entity.Comment) {
+	go func() {
+		b.Event.Publish(context.TODO(), &event.CommentApprovedEvent{
+			Comment: comment,
+		})
+	}()
+}
+
+// trainSpam teaches the spam classifier by the comments approved or deleted by the administrator,
+// the comments must have the status before the change.
+func (b baseCommentServiceImpl) trainSpam(ctx context.Context, comments []*entity.Comment, status consts.CommentStatus) {
+	if status != consts.CommentStatusPublished && status != consts.CommentStatusRecycle {
+		return
+	}
+	// the comments restored from the recycle bin or deleted after being published are not judged by the administrator
+	trainedComments := make([]*entity.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.IsAdmin && (comment.Status == consts.CommentStatusAuditing || comment.Status == consts.CommentStatusSpam) {
+			trainedComments = append(trainedComments, comment)
+		}
+	}
+	err := b.CommentSpamService.Train(ctx, trainedComments, status == consts.CommentStatusRecycle)
+	if err != nil {
+		log.CtxWarn(ctx, "train comment spam classifier err", zap.Error(err))
+	}
 }
 
 func (*baseCommentServiceImpl) CountChildren(ctx context.Context, parentCommentIDs []int32) (map[int32]int64, error) {
@@ -319,16 +507,14 @@ func (*baseCommentServiceImpl) CountChildren(ctx context.Context, parentCommentI
 }
 
 func (b *baseCommentServiceImpl) GetChildren(ctx context.Context, parentCommentID int32, contentID int32, commentType consts.CommentType) ([]*entity.Comment, error) {
-	allComments, err := b.GetByContentID(ctx, contentID, commentType, nil)
+	allComments, err := b.GetByContentID(ctx, contentID, commentType, nil, consts.CommentStatusPublished.Ptr())
 	if err != nil {
 		return nil, err
 	}
 	children := make([]*entity.Comment, 0)
 	parentIDMap := make(map[int32][]*entity.Comment, 0)
 	for _, comment := range allComments {
-		if comment.Status == consts.CommentStatusPublished {
-			parentIDMap[comment.ParentID] = append(parentIDMap[comment.ParentID], comment)
-		}
+		parentIDMap[comment.ParentID] = append(parentIDMap[comment.ParentID], comment)
 	}
 	queue := util.NewQueue[int32]()
 	queue.Push(parentCommentID)
@@ -341,15 +527,3 @@ func (b *baseCommentServiceImpl) GetChildren(ctx context.Context, parentCommentI
 	}
 	return children, nil
 }
-
-func (b baseCommentServiceImpl) IncreaseLike(ctx context.Context, commentID int32) error {
-	commentDAL := dal.GetQueryByCtx(ctx).Comment
-	info, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(commentID)).UpdateSimple(commentDAL.Likes.Add(1))
-	if err != nil {
-		return WrapDBErr(err)
-	}
-	if info.RowsAffected != 1 {
-		return xerr.NoType.New("increase comment like failed postID=%v", commentID).WithStatus(xerr.StatusBadRequest).WithMsg("failed to like comment")
-	}
-	return nil
-}
@@ -0,0 +1,214 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gen/field"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// permanentBanTime is the expiry of the bans without an end
+var permanentBanTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+const autoBanReason = "comment too frequently"
+
+type commentBlackServiceImpl struct {
+	OptionService service.OptionService
+}
+
+func NewCommentBlackService(optionService service.OptionService) service.CommentBlackService {
+	return &commentBlackServiceImpl{
+		OptionService: optionService,
+	}
+}
+
+func (c *commentBlackServiceImpl) Page(ctx context.Context, page param.Page) ([]*entity.CommentBlack, int64, error) {
+	commentBlackDAL := dal.GetQueryByCtx(ctx).CommentBlack
+	commentBlacks, totalCount, err := commentBlackDAL.WithContext(ctx).Order(commentBlackDAL.CreateTime.Desc()).FindByPage(page.PageNum*page.PageSize, page.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return commentBlacks, totalCount, nil
+}
+
+func (c *commentBlackServiceImpl) Create(ctx context.Context, commentBlackParam *param.CommentBlack) (*entity.CommentBlack, error) {
+	commentBlack, err := c.convertParam(commentBlackParam)
+	if err != nil {
+		return nil, err
+	}
+	commentBlackDAL := dal.GetQueryByCtx(ctx).CommentBlack
+	err = commentBlackDAL.WithContext(ctx).Create(commentBlack)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return commentBlack, nil
+}
+
+func (c *commentBlackServiceImpl) Update(ctx context.Context, id int32, commentBlackParam *param.CommentBlack) (*entity.CommentBlack, error) {
+	commentBlack, err := c.convertParam(commentBlackParam)
+	if err != nil {
+		return nil, err
+	}
+	commentBlackDAL := dal.GetQueryByCtx(ctx).CommentBlack
+	updateResult, err := commentBlackDAL.WithContext(ctx).Where(commentBlackDAL.ID.Eq(id)).UpdateSimple(
+		commentBlackDAL.IPAddress.Value(commentBlack.IPAddress),
+		commentBlackDAL.Email.Value(commentBlack.Email),
+		commentBlackDAL.Author.Value(commentBlack.Author),
+		commentBlackDAL.Reason.Value(commentBlack.Reason),
+		commentBlackDAL.BanTime.Value(commentBlack.BanTime),
+		commentBlackDAL.UpdateTime.Value(time.Now()),
+	)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	if updateResult.RowsAffected != 1 {
+		return nil, xerr.NoRecord.New("comment black id=%d", id).WithMsg("comment black does not exist").WithStatus(xerr.StatusNotFound)
+	}
+	commentBlack, err = commentBlackDAL.WithContext(ctx).Where(commentBlackDAL.ID.Eq(id)).First()
+	return commentBlack, WrapDBErr(err)
+}
+
+func (c *commentBlackServiceImpl) Delete(ctx context.Context, id int32) error {
+	commentBlackDAL := dal.GetQueryByCtx(ctx).CommentBlack
+	deleteResult, err := commentBlackDAL.WithContext(ctx).Where(commentBlackDAL.ID.Eq(id)).Delete()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if deleteResult.RowsAffected != 1 {
+		return xerr.NoRecord.New("comment black id=%d", id).WithMsg("comment black does not exist").WithStatus(xerr.StatusNotFound)
+	}
+	return nil
+}
+
+func (c *commentBlackServiceImpl) Check(ctx context.Context, comment *entity.Comment) error {
+	// the webmentions and the fediverse replies come from the servers and the relays, whose IP is not the one of a commenter
+	if comment.Kind == consts.CommentKindComment {
+		banned, err := c.banFrequentIP(ctx, comment.IPAddress)
+		if err != nil {
+			return err
+		}
+		if banned {
+			return xerr.Forbidden.New("ip %s comments too frequently", comment.IPAddress).WithMsg("You comment too frequently, please try again later").WithStatus(xerr.StatusForbidden)
+		}
+	}
+
+	commentBlackDAL := dal.GetQueryByCtx(ctx).CommentBlack
+	conditions := make([]field.Expr, 0, 3)
+	if comment.IPAddress != "" {
+		conditions = append(conditions, commentBlackDAL.IPAddress.Eq(comment.IPAddress))
+	}
+	if comment.Email != "" {
+		conditions = append(conditions, commentBlackDAL.Email.Eq(strings.ToLower(comment.Email)))
+	}
+	if comment.Author != "" {
+		conditions = append(conditions, commentBlackDAL.Author.Eq(comment.Author))
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+	count, err := commentBlackDAL.WithContext(ctx).Where(commentBlackDAL.BanTime.Gt(time.Now()), field.Or(conditions...)).Count()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if count > 0 {
+		return xerr.Forbidden.New("commenter is banned").WithMsg("You have been banned from commenting").WithStatus(xerr.StatusForbidden)
+	}
+	return nil
+}
+
+// banFrequentIP bans the IP for the ban time, if it sends too many comments within the ban time.
+// Only the comments posted by visitors are counted.
+func (c *commentBlackServiceImpl) banFrequentIP(ctx context.Context, ipAddress string) (bool, error) {
+	if ipAddress == "" {
+		return false, nil
+	}
+	banTime, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CommentBanTime, property.CommentBanTime.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	commentRange, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CommentRange, property.CommentRange.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	if banTime.(int) <= 0 || commentRange.(int) <= 0 {
+		return false, nil
+	}
+
+	now := time.Now()
+	banDuration := time.Duration(banTime.(int)) * time.Minute
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	count, err := commentDAL.WithContext(ctx).Where(commentDAL.IPAddress.Eq(ipAddress), commentDAL.Kind.Eq(consts.CommentKindComment), commentDAL.CreateTime.Gt(now.Add(-banDuration))).Count()
+	if err != nil {
+		return false, WrapDBErr(err)
+	}
+	if count < int64(commentRange.(int)) {
+		return false, nil
+	}
+
+	commentBlackDAL := dal.GetQueryByCtx(ctx).CommentBlack
+	updateResult, err := commentBlackDAL.WithContext(ctx).
+		Where(commentBlackDAL.IPAddress.Eq(ipAddress), commentBlackDAL.Reason.Eq(autoBanReason)).
+		UpdateSimple(commentBlackDAL.BanTime.Value(now.Add(banDuration)), commentBlackDAL.UpdateTime.Value(now))
+	if err != nil {
+		return false, WrapDBErr(err)
+	}
+	if updateResult.RowsAffected == 0 {
+		err = commentBlackDAL.WithContext(ctx).Create(&entity.CommentBlack{
+			IPAddress: ipAddress,
+			Reason:    autoBanReason,
+			BanTime:   now.Add(banDuration),
+		})
+		if err != nil {
+			return false, WrapDBErr(err)
+		}
+	}
+	return true, nil
+}
+
+func (c *commentBlackServiceImpl) convertParam(commentBlackParam *param.CommentBlack) (*entity.CommentBlack, error) {
+	commentBlack := &entity.CommentBlack{
+		IPAddress: strings.TrimSpace(commentBlackParam.IPAddress),
+		Email:     strings.ToLower(strings.TrimSpace(commentBlackParam.Email)),
+		Author:    strings.TrimSpace(commentBlackParam.Author),
+		Reason:    commentBlackParam.Reason,
+		BanTime:   permanentBanTime,
+	}
+	if commentBlack.IPAddress == "" && commentBlack.Email == "" && commentBlack.Author == "" {
+		return nil, xerr.BadParam.New("").WithMsg("one of ip address, email and author is required").WithStatus(xerr.StatusBadRequest)
+	}
+	if commentBlackParam.BanTime != nil {
+		commentBlack.BanTime = time.UnixMilli(*commentBlackParam.BanTime)
+	}
+	return commentBlack, nil
+}
+
+func (c *commentBlackServiceImpl) ConvertToDTO(commentBlack *entity.CommentBlack) *dto.CommentBlack {
+	return &dto.CommentBlack{
+		ID:         commentBlack.ID,
+		IPAddress:  commentBlack.IPAddress,
+		Email:      commentBlack.Email,
+		Author:     commentBlack.Author,
+		Reason:     commentBlack.Reason,
+		BanTime:    commentBlack.BanTime.UnixMilli(),
+		Permanent:  !commentBlack.BanTime.Before(permanentBanTime),
+		CreateTime: commentBlack.CreateTime.UnixMilli(),
+	}
+}
+
+func (c *commentBlackServiceImpl) ConvertToDTOs(commentBlacks []*entity.CommentBlack) []*dto.CommentBlack {
+	result := make([]*dto.CommentBlack, 0, len(commentBlacks))
+	for _, commentBlack := range commentBlacks {
+		result = append(result, c.ConvertToDTO(commentBlack))
+	}
+	return result
+}
@@ -0,0 +1,275 @@
+package impl
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"gorm.io/gen/field"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// commentSortKey is a column of the comment order, the ID is always the last key so the order is total.
+type commentSortKey struct {
+	column string
+	desc   bool
+	value  func(comment *entity.Comment) int64
+	// setValue restores the value of the key from the cursor
+	setValue func(comment *entity.Comment, value int64)
+	// cursorConds returns the conditions of the comments with the same value as the cursor and of the comments after the cursor
+	cursorConds func(tableName string, cursor *entity.Comment) (field.Expr, field.Expr)
+}
+
+func newInt32CommentSortKey(column string, desc bool, get func(comment *entity.Comment) int32, set func(comment *entity.Comment, value int32)) commentSortKey {
+	return commentSortKey{
+		column:   column,
+		desc:     desc,
+		value:    func(comment *entity.Comment) int64 { return int64(get(comment)) },
+		setValue: func(comment *entity.Comment, value int64) { set(comment, int32(value)) },
+		cursorConds: func(tableName string, cursor *entity.Comment) (field.Expr, field.Expr) {
+			column := field.NewInt32(tableName, column)
+			if desc {
+				return column.Eq(get(cursor)), column.Lt(get(cursor))
+			}
+			return column.Eq(get(cursor)), column.Gt(get(cursor))
+		},
+	}
+}
+
+func newCreateTimeCommentSortKey(desc bool) commentSortKey {
+	return commentSortKey{
+		column:   "create_time",
+		desc:     desc,
+		value:    func(comment *entity.Comment) int64 { return comment.CreateTime.UnixNano() },
+		setValue: func(comment *entity.Comment, value int64) { comment.CreateTime = time.Unix(0, value) },
+		cursorConds: func(tableName string, cursor *entity.Comment) (field.Expr, field.Expr) {
+			column := field.NewTime(tableName, "create_time")
+			if desc {
+				return column.Eq(cursor.CreateTime), column.Lt(cursor.CreateTime)
+			}
+			return column.Eq(cursor.CreateTime), column.Gt(cursor.CreateTime)
+		},
+	}
+}
+
+func commentSortKeys(sortName string) []commentSortKey {
+	topPriorityKey := newInt32CommentSortKey("top_priority", true,
+		func(comment *entity.Comment) int32 { return comment.TopPriority },
+		func(comment *entity.Comment, value int32) { comment.TopPriority = value })
+	likesKey := newInt32CommentSortKey("likes", true,
+		func(comment *entity.Comment) int32 { return comment.Likes },
+		func(comment *entity.Comment, value int32) { comment.Likes = value })
+	idKey := func(desc bool) commentSortKey {
+		return newInt32CommentSortKey("id", desc,
+			func(comment *entity.Comment) int32 { return comment.ID },
+			func(comment *entity.Comment, value int32) { comment.ID = value })
+	}
+	switch sortName {
+	case "oldest":
+		return []commentSortKey{newCreateTimeCommentSortKey(false), idKey(false)}
+	case "likes":
+		return []commentSortKey{likesKey, newCreateTimeCommentSortKey(true), idKey(true)}
+	case "top":
+		return []commentSortKey{topPriorityKey, newCreateTimeCommentSortKey(true), idKey(true)}
+	default:
+		return []commentSortKey{newCreateTimeCommentSortKey(true), idKey(true)}
+	}
+}
+
+// commentCursor keeps the values of the sort keys of the last comment of a page,
+// so that the next page does not depend on the comment, which may have been changed or deleted since.
+type commentCursor struct {
+	Sort   string  `json:"s"`
+	Values []int64 `json:"v"`
+}
+
+func encodeCommentCursor(sortName string, keys []commentSortKey, comment *entity.Comment) string {
+	cursor := commentCursor{
+		Sort:   sortName,
+		Values: make([]int64, 0, len(keys)),
+	}
+	for _, key := range keys {
+		cursor.Values = append(cursor.Values, key.value(comment))
+	}
+	data, _ := json.Marshal(&cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCommentCursor returns a comment holding the values of the sort keys of the cursor.
+func decodeCommentCursor(sortName string, keys []commentSortKey, encoded string) (*entity.Comment, error) {
+	invalidErr := xerr.BadParam.New("cursor=%s", encoded).WithMsg("invalid cursor").WithStatus(xerr.StatusBadRequest)
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalidErr
+	}
+	var cursor commentCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sortName || len(cursor.Values) != len(keys) {
+		return nil, invalidErr
+	}
+	comment := &entity.Comment{}
+	for i, key := range keys {
+		key.setValue(comment, cursor.Values[i])
+	}
+	return comment, nil
+}
+
+func (b baseCommentServiceImpl) PageByCursor(ctx context.Context, query *param.CommentCursorQuery, commentType consts.CommentType) ([]*entity.Comment, string, bool, error) {
+	size := query.Size
+	if size == 0 {
+		size = b.OptionService.GetOrByDefault(ctx, property.CommentPageSize).(int)
+	}
+	maxDepth := b.OptionService.GetOrByDefault(ctx, property.CommentMaxDepth).(int)
+
+	lastLevel := false
+	if query.ParentID != 0 {
+		parent, err := b.getPublishedComment(ctx, query.ParentID, query.ContentID, commentType)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if maxDepth > 0 {
+			depth, err := b.commentDepth(ctx, parent, maxDepth)
+			if err != nil {
+				return nil, "", false, err
+			}
+			lastLevel = depth+1 >= maxDepth
+		}
+	}
+	keys := commentSortKeys(query.Sort)
+	var cursor *entity.Comment
+	if query.Cursor != "" {
+		var err error
+		cursor, err = decodeCommentCursor(query.Sort, keys, query.Cursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+	}
+
+	var (
+		comments []*entity.Comment
+		err      error
+	)
+	if lastLevel {
+		comments, err = b.pageDescendants(ctx, query, commentType, keys, cursor, size)
+	} else {
+		comments, err = b.pageChildren(ctx, query, commentType, keys, cursor, size)
+	}
+	if err != nil {
+		return nil, "", false, err
+	}
+	nextCursor := ""
+	if len(comments) > size {
+		comments = comments[:size]
+		nextCursor = encodeCommentCursor(query.Sort, keys, comments[len(comments)-1])
+	}
+	return comments, nextCursor, lastLevel, nil
+}
+
+func (b baseCommentServiceImpl) getPublishedComment(ctx context.Context, commentID, contentID int32, commentType consts.CommentType) (*entity.Comment, error) {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	comment, err := commentDAL.WithContext(ctx).Where(
+		commentDAL.ID.Eq(commentID),
+		commentDAL.PostID.Eq(contentID),
+		commentDAL.Type.Eq(commentType),
+		commentDAL.Status.Eq(consts.CommentStatusPublished),
+	).First()
+	return comment, WrapDBErr(err)
+}
+
+// commentDepth returns the level of the comment, 0 for a top-level comment, it stops counting at maxDepth.
+func (b baseCommentServiceImpl) commentDepth(ctx context.Context, comment *entity.Comment, maxDepth int) (int, error) {
+	depth := 0
+	for comment.ParentID != 0 && depth < maxDepth {
+		parent, err := b.GetByID(ctx, comment.ParentID)
+		if err != nil {
+			return 0, err
+		}
+		comment = parent
+		depth++
+	}
+	return depth, nil
+}
+
+// pageChildren queries one more comment than the size to tell whether there are more.
+func (b baseCommentServiceImpl) pageChildren(ctx context.Context, query *param.CommentCursorQuery, commentType consts.CommentType, keys []commentSortKey, cursor *entity.Comment, size int) ([]*entity.Comment, error) {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	commentDO := commentDAL.WithContext(ctx).Where(
+		commentDAL.PostID.Eq(query.ContentID),
+		commentDAL.Type.Eq(commentType),
+		commentDAL.ParentID.Eq(query.ParentID),
+		commentDAL.Status.Eq(consts.CommentStatusPublished),
+	)
+	if cursor != nil {
+		commentDO = commentDO.Where(commentCursorCond(commentDAL.TableName(), keys, cursor))
+	}
+	orders := make([]field.Expr, 0, len(keys))
+	for _, key := range keys {
+		orderExpr := field.NewField(commentDAL.TableName(), key.column)
+		if key.desc {
+			orders = append(orders, orderExpr.Desc())
+		} else {
+			orders = append(orders, orderExpr)
+		}
+	}
+	comments, err := commentDO.Order(orders...).Limit(size + 1).Find()
+	return comments, WrapDBErr(err)
+}
+
+// commentCursorCond selects the comments after the cursor: (k1 after) OR (k1 equal AND k2 after) OR ...
+func commentCursorCond(tableName string, keys []commentSortKey, cursor *entity.Comment) field.Expr {
+	ors := make([]field.Expr, 0, len(keys))
+	equals := make([]field.Expr, 0, len(keys))
+	for _, key := range keys {
+		equal, after := key.cursorConds(tableName, cursor)
+		ors = append(ors, field.And(append(equals[:len(equals):len(equals)], after)...))
+		equals = append(equals, equal)
+	}
+	return field.Or(ors...)
+}
+
+// pageDescendants pages all the replies under the parent as the replies on the last level.
+func (b baseCommentServiceImpl) pageDescendants(ctx context.Context, query *param.CommentCursorQuery, commentType consts.CommentType, keys []commentSortKey, cursor *entity.Comment, size int) ([]*entity.Comment, error) {
+	descendants, err := b.GetChildren(ctx, query.ParentID, query.ContentID, commentType)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(descendants, func(i, j int) bool {
+		return commentLess(keys, descendants[i], descendants[j])
+	})
+	start := 0
+	if cursor != nil {
+		start = len(descendants)
+		for i, comment := range descendants {
+			if commentLess(keys, cursor, comment) {
+				start = i
+				break
+			}
+		}
+	}
+	end := start + size + 1
+	if end > len(descendants) {
+		end = len(descendants)
+	}
+	return descendants[start:end], nil
+}
+
+func commentLess(keys []commentSortKey, a, b *entity.Comment) bool {
+	for _, key := range keys {
+		valueA, valueB := key.value(a), key.value(b)
+		if valueA == valueB {
+			continue
+		}
+		if key.desc {
+			return valueA > valueB
+		}
+		return valueA < valueB
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	goldmarkHTML "github.com/yuin/goldmark/renderer/html"
+	goldmarkUtil "github.com/yuin/goldmark/util"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/go-sonic/sonic/log"
+)
+
+// commentMarkdown only supports emphasis, code, links and quotes, the raw HTML is escaped as text
+var commentMarkdown = goldmark.New(
+	goldmark.WithParser(parser.NewParser(
+		parser.WithBlockParsers(
+			goldmarkUtil.Prioritized(parser.NewCodeBlockParser(), 500),
+			goldmarkUtil.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+			goldmarkUtil.Prioritized(parser.NewBlockquoteParser(), 800),
+			goldmarkUtil.Prioritized(parser.NewParagraphParser(), 1000),
+		),
+		parser.WithInlineParsers(
+			goldmarkUtil.Prioritized(parser.NewCodeSpanParser(), 100),
+			goldmarkUtil.Prioritized(parser.NewLinkParser(), 200),
+			goldmarkUtil.Prioritized(parser.NewAutoLinkParser(), 300),
+			goldmarkUtil.Prioritized(parser.NewEmphasisParser(), 500),
+		),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+	)),
+	goldmark.WithExtensions(extension.Linkify, extension.Strikethrough),
+	goldmark.WithRendererOptions(goldmarkHTML.WithHardWraps()),
+)
+
+var (
+	commentAllowedTags = map[atom.Atom]bool{
+		atom.P:          true,
+		atom.Br:         true,
+		atom.Em:         true,
+		atom.Strong:     true,
+		atom.Del:        true,
+		atom.Code:       true,
+		atom.Pre:        true,
+		atom.Blockquote: true,
+		atom.A:          true,
+	}
+	// the content of these tags is removed along with the tags
+	commentRemovedTags = map[atom.Atom]bool{
+		atom.Script:   true,
+		atom.Style:    true,
+		atom.Iframe:   true,
+		atom.Noscript: true,
+		atom.Template: true,
+		atom.Textarea: true,
+		atom.Title:    true,
+	}
+	commentAllowedSchemes = map[string]bool{
+		"http":   true,
+		"https":  true,
+		"mailto": true,
+	}
+	codeLanguageRegexp = regexp.MustCompile(`^language-[\w+#-]+$`)
+)
+
+func (b baseCommentServiceImpl) RenderContent(ctx context.Context, content string) string {
+	// the content of the visitors is escaped when the comment is created
+	buf := &bytes.Buffer{}
+	if err := commentMarkdown.Convert([]byte(html.UnescapeString(content)), buf); err != nil {
+		log.CtxWarn(ctx, "render comment markdown err", zap.Error(err))
+		return html.EscapeString(content)
+	}
+	return sanitizeCommentHTML(buf.String())
+}
+
+// sanitizeCommentHTML drops the tags and attributes out of the allow list and keeps their text.
+func sanitizeCommentHTML(content string) string {
+	result := &strings.Builder{}
+	openTags := make([]atom.Atom, 0)
+	removedDepth := 0
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for tokenType := tokenizer.Next(); tokenType != html.ErrorToken; tokenType = tokenizer.Next() {
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			if removedDepth == 0 {
+				result.WriteString(html.EscapeString(token.Data))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if commentRemovedTags[token.DataAtom] {
+				if tokenType == html.StartTagToken {
+					removedDepth++
+				}
+				continue
+			}
+			if removedDepth > 0 || !commentAllowedTags[token.DataAtom] {
+				continue
+			}
+			writeCommentTag(result, &token)
+			if token.DataAtom != atom.Br {
+				openTags = append(openTags, token.DataAtom)
+			}
+		case html.EndTagToken:
+			if commentRemovedTags[token.DataAtom] {
+				if removedDepth > 0 {
+					removedDepth--
+				}
+				continue
+			}
+			if removedDepth > 0 || !commentAllowedTags[token.DataAtom] {
+				continue
+			}
+			for i := len(openTags) - 1; i >= 0; i-- {
+				if openTags[i] != token.DataAtom {
+					continue
+				}
+				for j := len(openTags) - 1; j >= i; j-- {
+					result.WriteString("</" + openTags[j].String() + ">")
+				}
+				openTags = openTags[:i]
+				break
+			}
+		}
+	}
+	for i := len(openTags) - 1; i >= 0; i-- {
+		result.WriteString("</" + openTags[i].String() + ">")
+	}
+	return result.String()
+}
+
+func writeCommentTag(result *strings.Builder, token *html.Token) {
+	result.WriteString("<" + token.DataAtom.String())
+	switch token.DataAtom {
+	case atom.A:
+		if href := getAttr(token, "href"); isAllowedCommentURL(href) {
+			result.WriteString(` href="` + html.EscapeString(href) + `"`)
+		}
+		result.WriteString(` rel="nofollow ugc"`)
+	case atom.Code:
+		if class := getAttr(token, "class"); codeLanguageRegexp.MatchString(class) {
+			result.WriteString(` class="` + class + `"`)
+		}
+	}
+	result.WriteString(">")
+}
+
+func isAllowedCommentURL(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	return commentAllowedSchemes[strings.ToLower(u.Scheme)]
+}
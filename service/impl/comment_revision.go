@@ -0,0 +1,48 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/service"
+)
+
+type commentRevisionServiceImpl struct{}
+
+func NewCommentRevisionService() service.CommentRevisionService {
+	return &commentRevisionServiceImpl{}
+}
+
+func (c *commentRevisionServiceImpl) Create(ctx context.Context, revision *entity.CommentRevision) error {
+	revisionDAL := dal.GetQueryByCtx(ctx).CommentRevision
+	return WrapDBErr(revisionDAL.WithContext(ctx).Create(revision))
+}
+
+func (c *commentRevisionServiceImpl) ListByCommentID(ctx context.Context, commentID int32) ([]*entity.CommentRevision, error) {
+	revisionDAL := dal.GetQueryByCtx(ctx).CommentRevision
+	revisions, err := revisionDAL.WithContext(ctx).Where(revisionDAL.CommentID.Eq(commentID)).Order(revisionDAL.ID.Desc()).Find()
+	return revisions, WrapDBErr(err)
+}
+
+func (c *commentRevisionServiceImpl) DeleteByCommentIDs(ctx context.Context, commentIDs []int32) error {
+	revisionDAL := dal.GetQueryByCtx(ctx).CommentRevision
+	_, err := revisionDAL.WithContext(ctx).Where(revisionDAL.CommentID.In(commentIDs...)).Delete()
+	return WrapDBErr(err)
+}
+
+func (c *commentRevisionServiceImpl) ConvertToDTOs(revisions []*entity.CommentRevision) []*dto.CommentRevision {
+	revisionDTOs := make([]*dto.CommentRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionDTOs = append(revisionDTOs, &dto.CommentRevision{
+			ID:         revision.ID,
+			CommentID:  revision.CommentID,
+			Content:    revision.Content,
+			IPAddress:  revision.IPAddress,
+			UserAgent:  revision.UserAgent,
+			CreateTime: revision.CreateTime.UnixMilli(),
+		})
+	}
+	return revisionDTOs
+}
@@ -0,0 +1,196 @@
+package impl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const akismetTimeout = 10 * time.Second
+
+var commentLinkRegexp = regexp.MustCompile(`(?i)https?://|www\.`)
+
+type commentSpamServiceImpl struct {
+	OptionService service.OptionService
+	classifier    *bayesSpamChecker
+
+	mutex    sync.RWMutex
+	checkers []service.SpamChecker
+}
+
+func NewCommentSpamService(optionService service.OptionService, httpClient service.HTTPClient) service.CommentSpamService {
+	classifier := &bayesSpamChecker{OptionService: optionService}
+	return &commentSpamServiceImpl{
+		OptionService: optionService,
+		classifier:    classifier,
+		// the cheap checkers go first, the remote one goes last
+		checkers: []service.SpamChecker{
+			&honeypotSpamChecker{},
+			&linkSpamChecker{OptionService: optionService},
+			classifier,
+			&akismetSpamChecker{OptionService: optionService, HTTPClient: httpClient},
+		},
+	}
+}
+
+func (c *commentSpamServiceImpl) Check(ctx context.Context, candidate *service.SpamCandidate) (string, error) {
+	enabled, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.CommentSpamCheckEnabled, property.CommentSpamCheckEnabled.DefaultValue)
+	if err != nil {
+		return "", err
+	}
+	if !enabled.(bool) {
+		return "", nil
+	}
+	c.mutex.RLock()
+	checkers := c.checkers
+	c.mutex.RUnlock()
+
+	for _, checker := range checkers {
+		spam, err := checker.IsSpam(ctx, candidate)
+		if err != nil {
+			// a broken checker should not stop visitors from commenting
+			log.CtxWarn(ctx, "check comment spam err", zap.String("checker", checker.Name()), zap.Error(err))
+			continue
+		}
+		if spam {
+			return checker.Name(), nil
+		}
+	}
+	return "", nil
+}
+
+func (c *commentSpamServiceImpl) RegisterChecker(checker service.SpamChecker) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	checkers := make([]service.SpamChecker, 0, len(c.checkers)+1)
+	checkers = append(checkers, c.checkers...)
+	c.checkers = append(checkers, checker)
+}
+
+func (c *commentSpamServiceImpl) Train(ctx context.Context, comments []*entity.Comment, spam bool) error {
+	return c.classifier.train(ctx, comments, spam)
+}
+
+// honeypotSpamChecker catches the bots filling the hidden field of the comment form.
+type honeypotSpamChecker struct{}
+
+func (*honeypotSpamChecker) Name() string {
+	return "honeypot"
+}
+
+func (*honeypotSpamChecker) IsSpam(ctx context.Context, candidate *service.SpamCandidate) (bool, error) {
+	return strings.TrimSpace(candidate.Honeypot) != "", nil
+}
+
+// linkSpamChecker catches the comments with too many links.
+type linkSpamChecker struct {
+	OptionService service.OptionService
+}
+
+func (*linkSpamChecker) Name() string {
+	return "links"
+}
+
+func (l *linkSpamChecker) IsSpam(ctx context.Context, candidate *service.SpamCandidate) (bool, error) {
+	maxLinks, err := l.OptionService.GetOrByDefaultWithErr(ctx, property.CommentSpamMaxLinks, property.CommentSpamMaxLinks.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	// 0 means no limit
+	if maxLinks.(int) <= 0 {
+		return false, nil
+	}
+	return len(commentLinkRegexp.FindAllStringIndex(candidate.Comment.Content, -1)) > maxLinks.(int), nil
+}
+
+// akismetSpamChecker asks an Akismet compatible service, the endpoint can be pointed to a local stub.
+type akismetSpamChecker struct {
+	OptionService service.OptionService
+	HTTPClient    service.HTTPClient
+}
+
+func (*akismetSpamChecker) Name() string {
+	return "akismet"
+}
+
+func (a *akismetSpamChecker) IsSpam(ctx context.Context, candidate *service.SpamCandidate) (bool, error) {
+	enabled, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.CommentAkismetEnabled, property.CommentAkismetEnabled.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	key, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.CommentAkismetKey, property.CommentAkismetKey.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	if !enabled.(bool) || key.(string) == "" {
+		return false, nil
+	}
+	endpoint, err := a.OptionService.GetOrByDefaultWithErr(ctx, property.CommentAkismetEndpoint, property.CommentAkismetEndpoint.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	blogURL, err := a.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	comment := candidate.Comment
+	form := url.Values{}
+	form.Set("api_key", key.(string))
+	form.Set("blog", blogURL)
+	form.Set("user_ip", comment.IPAddress)
+	form.Set("user_agent", comment.UserAgent)
+	form.Set("referrer", candidate.Referer)
+	form.Set("comment_author", comment.Author)
+	form.Set("comment_author_email", comment.Email)
+	form.Set("comment_author_url", comment.AuthorURL)
+	form.Set("comment_content", comment.Content)
+	if comment.ParentID != 0 {
+		form.Set("comment_type", "reply")
+	} else {
+		form.Set("comment_type", "comment")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, akismetTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint.(string), "/")+"/comment-check", strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion)
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, xerr.NoType.New("akismet responded with status %d", resp.StatusCode)
+	}
+	switch strings.TrimSpace(string(body)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, xerr.NoType.New("unexpected akismet response %q, %s", body, resp.Header.Get("X-akismet-debug-help"))
+	}
+}
@@ -0,0 +1,208 @@
+package impl
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+)
+
+const (
+	// bayesDocumentToken holds the numbers of the trained comments, it can not be produced by the tokenizer
+	bayesDocumentToken = "#documents"
+	// bayesMinDocuments is the number of both spam and ham comments needed before the classifier takes effect
+	bayesMinDocuments = 10
+	// bayesInterestingTokens is the number of the tokens farthest from neutral that decide the probability
+	bayesInterestingTokens = 15
+	// bayesStrength is the weight of the neutral probability for the tokens seen a few times
+	bayesStrength      = 1.0
+	bayesMinTokenLen   = 2
+	bayesMaxTokenLen   = 40
+	bayesTokenBatchLen = 500
+)
+
+// bayesSpamChecker is a naive Bayesian classifier trained by the comments approved and deleted by the administrator.
+type bayesSpamChecker struct {
+	OptionService service.OptionService
+
+	mutex sync.Mutex
+}
+
+func (*bayesSpamChecker) Name() string {
+	return "bayes"
+}
+
+func (b *bayesSpamChecker) IsSpam(ctx context.Context, candidate *service.SpamCandidate) (bool, error) {
+	threshold, err := b.OptionService.GetOrByDefaultWithErr(ctx, property.CommentSpamBayesThreshold, property.CommentSpamBayesThreshold.DefaultValue)
+	if err != nil {
+		return false, err
+	}
+	if threshold.(int) <= 0 {
+		return false, nil
+	}
+	probability, err := b.spamProbability(ctx, candidate.Comment)
+	if err != nil {
+		return false, err
+	}
+	return probability*100 >= float64(threshold.(int)), nil
+}
+
+func (b *bayesSpamChecker) spamProbability(ctx context.Context, comment *entity.Comment) (float64, error) {
+	tokens := tokenizeComment(comment)
+	counts, err := b.findTokens(ctx, append(tokens, bayesDocumentToken))
+	if err != nil {
+		return 0, err
+	}
+	documents, ok := counts[bayesDocumentToken]
+	if !ok || documents.SpamCount < bayesMinDocuments || documents.HamCount < bayesMinDocuments {
+		return 0, nil
+	}
+
+	probabilities := make([]float64, 0, len(tokens))
+	for _, token := range tokens {
+		var spamCount, hamCount float64
+		if count, ok := counts[token]; ok {
+			spamCount, hamCount = float64(count.SpamCount), float64(count.HamCount)
+		}
+		probability := 0.5
+		if spamCount+hamCount > 0 {
+			spamRatio := spamCount / float64(documents.SpamCount)
+			hamRatio := hamCount / float64(documents.HamCount)
+			probability = spamRatio / (spamRatio + hamRatio)
+		}
+		// Robinson's smoothing, the rare tokens stay close to neutral
+		n := spamCount + hamCount
+		probability = (bayesStrength*0.5 + n*probability) / (bayesStrength + n)
+		probabilities = append(probabilities, math.Min(math.Max(probability, 0.01), 0.99))
+	}
+	sort.Slice(probabilities, func(i, j int) bool {
+		return math.Abs(probabilities[i]-0.5) > math.Abs(probabilities[j]-0.5)
+	})
+	if len(probabilities) > bayesInterestingTokens {
+		probabilities = probabilities[:bayesInterestingTokens]
+	}
+	// combine in log space to avoid underflow
+	var logSpam, logHam float64
+	for _, probability := range probabilities {
+		logSpam += math.Log(probability)
+		logHam += math.Log(1 - probability)
+	}
+	return 1 / (1 + math.Exp(logHam-logSpam)), nil
+}
+
+func (b *bayesSpamChecker) train(ctx context.Context, comments []*entity.Comment, spam bool) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	// a token counts once in a comment
+	deltas := map[string]int32{bayesDocumentToken: int32(len(comments))}
+	for _, comment := range comments {
+		for _, token := range tokenizeComment(comment) {
+			deltas[token]++
+		}
+	}
+	tokens := make([]string, 0, len(deltas))
+	for token := range deltas {
+		tokens = append(tokens, token)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return dal.Transaction(ctx, func(txCtx context.Context) error {
+		existing, err := b.findTokens(txCtx, tokens)
+		if err != nil {
+			return err
+		}
+		tokenDAL := dal.GetQueryByCtx(txCtx).CommentSpamToken
+		newTokens := make([]*entity.CommentSpamToken, 0)
+		for _, token := range tokens {
+			count, ok := existing[token]
+			if !ok {
+				count = &entity.CommentSpamToken{Token: token}
+				newTokens = append(newTokens, count)
+			}
+			if spam {
+				count.SpamCount += deltas[token]
+			} else {
+				count.HamCount += deltas[token]
+			}
+			if !ok {
+				continue
+			}
+			_, err = tokenDAL.WithContext(txCtx).Where(tokenDAL.ID.Eq(count.ID)).UpdateSimple(tokenDAL.SpamCount.Value(count.SpamCount), tokenDAL.HamCount.Value(count.HamCount))
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+		return WrapDBErr(tokenDAL.WithContext(txCtx).CreateInBatches(newTokens, 100))
+	})
+}
+
+func (b *bayesSpamChecker) findTokens(ctx context.Context, tokens []string) (map[string]*entity.CommentSpamToken, error) {
+	tokenDAL := dal.GetQueryByCtx(ctx).CommentSpamToken
+	result := make(map[string]*entity.CommentSpamToken, len(tokens))
+	for start := 0; start < len(tokens); start += bayesTokenBatchLen {
+		end := start + bayesTokenBatchLen
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		counts, err := tokenDAL.WithContext(ctx).Where(tokenDAL.Token.In(tokens[start:end]...)).Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, count := range counts {
+			result[count.Token] = count
+		}
+	}
+	return result, nil
+}
+
+// tokenizeComment splits the comment into distinct lowercase words, the Chinese characters and their bigrams are tokens too.
+func tokenizeComment(comment *entity.Comment) []string {
+	text := strings.ToLower(comment.Author + " " + comment.AuthorURL + " " + comment.Content)
+	tokenSet := make(map[string]struct{})
+	tokens := make([]string, 0)
+	addToken := func(token string) {
+		if _, ok := tokenSet[token]; ok {
+			return
+		}
+		tokenSet[token] = struct{}{}
+		tokens = append(tokens, token)
+	}
+
+	word := &strings.Builder{}
+	flushWord := func() {
+		token := strings.Trim(word.String(), ".-")
+		word.Reset()
+		if len(token) >= bayesMinTokenLen && len(token) <= bayesMaxTokenLen {
+			addToken(token)
+		}
+	}
+	var prevHan rune
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			flushWord()
+			addToken(string(r))
+			if prevHan != 0 {
+				addToken(string([]rune{prevHan, r}))
+			}
+			prevHan = r
+			continue
+		}
+		prevHan = 0
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '$' {
+			word.WriteRune(r)
+		} else {
+			flushWord()
+		}
+	}
+	flushWord()
+	return tokens
+}
@@ -0,0 +1,203 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"gorm.io/gen/field"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type commentSubscriptionServiceImpl struct {
+	OptionService service.OptionService
+}
+
+func NewCommentSubscriptionService(optionService service.OptionService) service.CommentSubscriptionService {
+	return &commentSubscriptionServiceImpl{
+		OptionService: optionService,
+	}
+}
+
+// subscribeConfirmTTL is how long the link confirming a subscription is valid
+const subscribeConfirmTTL = 7 * 24 * time.Hour
+
+type subscriptionClaims struct {
+	Email     string             `json:"email"`
+	Type      consts.CommentType `json:"type"`
+	ContentID int32              `json:"content_id"`
+	// Subscribe tells the token confirming a subscription from the token of an unsubscribe link
+	Subscribe bool `json:"subscribe,omitempty"`
+	jwt.StandardClaims
+}
+
+func (c *commentSubscriptionServiceImpl) Subscribe(ctx context.Context, email string, commentType consts.CommentType, contentID int32) error {
+	return c.save(ctx, email, commentType, contentID, consts.CommentSubscriptionStatusThread)
+}
+
+func (c *commentSubscriptionServiceImpl) Unsubscribe(ctx context.Context, email string, commentType consts.CommentType, contentID int32) error {
+	return c.save(ctx, email, commentType, contentID, consts.CommentSubscriptionStatusUnsubscribed)
+}
+
+func (c *commentSubscriptionServiceImpl) save(ctx context.Context, email string, commentType consts.CommentType, contentID int32, status consts.CommentSubscriptionStatus) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return xerr.BadParam.New("").WithMsg("email can not be empty").WithStatus(xerr.StatusBadRequest)
+	}
+	if contentID == 0 {
+		commentType = consts.CommentTypePost
+	}
+	subscriptionDAL := dal.GetQueryByCtx(ctx).CommentSubscription
+	subscription, err := subscriptionDAL.WithContext(ctx).Where(
+		subscriptionDAL.Email.Eq(email),
+		subscriptionDAL.Type.Eq(commentType),
+		subscriptionDAL.ContentID.Eq(contentID),
+	).First()
+	err = WrapDBErr(err)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return WrapDBErr(subscriptionDAL.WithContext(ctx).Create(&entity.CommentSubscription{
+			Email:     email,
+			Type:      commentType,
+			ContentID: contentID,
+			Status:    status,
+		}))
+	}
+	if err != nil {
+		return err
+	}
+	if subscription.Status == status {
+		return nil
+	}
+	_, err = subscriptionDAL.WithContext(ctx).Where(subscriptionDAL.ID.Eq(subscription.ID)).UpdateSimple(subscriptionDAL.Status.Value(status))
+	return WrapDBErr(err)
+}
+
+func (c *commentSubscriptionServiceImpl) IsUnsubscribed(ctx context.Context, email string, commentType consts.CommentType, contentID int32) (bool, error) {
+	subscriptionDAL := dal.GetQueryByCtx(ctx).CommentSubscription
+	subscriptions, err := subscriptionDAL.WithContext(ctx).Where(
+		subscriptionDAL.Email.Eq(strings.ToLower(strings.TrimSpace(email))),
+		field.Or(
+			field.And(subscriptionDAL.Type.Eq(commentType), subscriptionDAL.ContentID.Eq(contentID)),
+			subscriptionDAL.ContentID.Eq(0),
+		),
+	).Find()
+	if err != nil {
+		return false, WrapDBErr(err)
+	}
+	// the subscription of the content takes precedence over the one of all the contents
+	unsubscribed := false
+	for _, subscription := range subscriptions {
+		if subscription.ContentID == contentID && contentID != 0 {
+			return subscription.Status == consts.CommentSubscriptionStatusUnsubscribed, nil
+		}
+		unsubscribed = subscription.Status == consts.CommentSubscriptionStatusUnsubscribed
+	}
+	return unsubscribed, nil
+}
+
+func (c *commentSubscriptionServiceImpl) ListThreadSubscribers(ctx context.Context, commentType consts.CommentType, contentID int32) ([]string, error) {
+	subscriptionDAL := dal.GetQueryByCtx(ctx).CommentSubscription
+	subscriptions, err := subscriptionDAL.WithContext(ctx).Where(
+		subscriptionDAL.Type.Eq(commentType),
+		subscriptionDAL.ContentID.Eq(contentID),
+		subscriptionDAL.Status.Eq(consts.CommentSubscriptionStatusThread),
+	).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	emails := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		emails = append(emails, subscription.Email)
+	}
+	return emails, nil
+}
+
+func (c *commentSubscriptionServiceImpl) BuildUnsubscribeURL(ctx context.Context, email string, commentType consts.CommentType, contentID int32) (string, error) {
+	return c.buildURL(ctx, "/comments/unsubscribe", &subscriptionClaims{
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		Type:      commentType,
+		ContentID: contentID,
+	})
+}
+
+func (c *commentSubscriptionServiceImpl) BuildSubscribeURL(ctx context.Context, email string, commentType consts.CommentType, contentID int32) (string, error) {
+	return c.buildURL(ctx, "/comments/subscribe", &subscriptionClaims{
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		Type:      commentType,
+		ContentID: contentID,
+		Subscribe: true,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(subscribeConfirmTTL).Unix(),
+		},
+	})
+}
+
+func (c *commentSubscriptionServiceImpl) buildURL(ctx context.Context, urlPath string, claims *subscriptionClaims) (string, error) {
+	secret, err := c.getSecret(ctx)
+	if err != nil {
+		return "", err
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", xerr.WithStatus(err, xerr.StatusInternalServerError)
+	}
+	blogBaseURL, err := c.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", err
+	}
+	return blogBaseURL + urlPath + "?token=" + url.QueryEscape(token), nil
+}
+
+func (c *commentSubscriptionServiceImpl) ParseUnsubscribeToken(ctx context.Context, tokenStr string) (*dto.SubscriptionToken, error) {
+	return c.parseToken(ctx, tokenStr, false)
+}
+
+func (c *commentSubscriptionServiceImpl) ParseSubscribeToken(ctx context.Context, tokenStr string) (*dto.SubscriptionToken, error) {
+	return c.parseToken(ctx, tokenStr, true)
+}
+
+func (c *commentSubscriptionServiceImpl) parseToken(ctx context.Context, tokenStr string, subscribe bool) (*dto.SubscriptionToken, error) {
+	secret, err := c.getSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseWithClaims(tokenStr, &subscriptionClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid link").WithStatus(xerr.StatusBadRequest)
+	}
+	claims, ok := token.Claims.(*subscriptionClaims)
+	if !ok || !token.Valid || claims.Email == "" || claims.Subscribe != subscribe {
+		return nil, xerr.BadParam.New("").WithMsg("invalid link").WithStatus(xerr.StatusBadRequest)
+	}
+	return &dto.SubscriptionToken{
+		Email:     claims.Email,
+		Type:      claims.Type,
+		ContentID: claims.ContentID,
+	}, nil
+}
+
+func (c *commentSubscriptionServiceImpl) getSecret(ctx context.Context) ([]byte, error) {
+	secret, err := c.OptionService.GetOrByDefaultWithErr(ctx, property.JWTSecret, "")
+	if err != nil {
+		return nil, err
+	}
+	if secret.(string) == "" {
+		return nil, xerr.WithMsg(nil, "jwt secret is nil").WithStatus(xerr.StatusInternalServerError)
+	}
+	return []byte(secret.(string)), nil
+}
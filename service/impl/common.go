@@ -1,11 +1,14 @@
 package impl
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
+	"go.uber.org/fx"
 	"gorm.io/gen/field"
 	"gorm.io/gorm"
 
@@ -132,3 +135,36 @@ func UnderscoreName(name string) string {
 	}
 	return buffer.String()
 }
+
+// runPeriodically calls run at the interval from the start of the app until it stops,
+// the context of run is canceled when the app stops.
+func runPeriodically(lifecycle fx.Lifecycle, interval time.Duration, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						run(ctx)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,367 @@
+package impl
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sonic/sonic/config"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+var commentTypeNames = map[consts.CommentType]string{
+	consts.CommentTypePost:    "post",
+	consts.CommentTypeSheet:   "sheet",
+	consts.CommentTypeJournal: "journal",
+}
+
+// exportedThread is a post, sheet or journal with its comments in the order they were created.
+type exportedThread struct {
+	Type       string             `json:"type"`
+	ID         int32              `json:"id"`
+	Title      string             `json:"title"`
+	URL        string             `json:"url"`
+	CreateTime time.Time          `json:"createTime"`
+	Comments   []*exportedComment `json:"comments"`
+}
+
+type exportedComment struct {
+	ID          int32     `json:"id"`
+	ParentID    int32     `json:"parentId"`
+	Author      string    `json:"author"`
+	Email       string    `json:"email"`
+	AuthorURL   string    `json:"authorUrl"`
+	IPAddress   string    `json:"ipAddress"`
+	UserAgent   string    `json:"userAgent"`
+	Content     string    `json:"content"`
+	ContentHTML string    `json:"contentHtml"`
+	Status      string    `json:"status"`
+	IsAdmin     bool      `json:"isAdmin"`
+	TopPriority int32     `json:"topPriority"`
+	Likes       int32     `json:"likes"`
+	CreateTime  time.Time `json:"createTime"`
+}
+
+func (e *exportImport) ExportComments(ctx context.Context, exportParam *param.ExportComment) (string, error) {
+	if exportParam.ContentID > 0 && exportParam.Type == "" {
+		return "", xerr.BadParam.New("").WithMsg("type is required to export the comments of a content").WithStatus(xerr.StatusBadRequest)
+	}
+	threads, err := e.listExportedThreads(ctx, exportParam)
+	if err != nil {
+		return "", err
+	}
+	if len(threads) == 0 {
+		return "", xerr.BadParam.New("").WithMsg("no comment to export").WithStatus(xerr.StatusBadRequest)
+	}
+
+	backupFilePath := config.BackupCommentDir
+	if _, err := os.Stat(backupFilePath); os.IsNotExist(err) {
+		err = os.MkdirAll(backupFilePath, os.ModePerm)
+		if err != nil {
+			return "", xerr.NoType.Wrap(err).WithMsg("create dir err")
+		}
+	} else if err != nil {
+		return "", xerr.NoType.Wrap(err).WithMsg("get fileInfo")
+	}
+	ext := "." + exportParam.Format
+	if exportParam.Format == "wxr" {
+		ext = ".xml"
+	}
+	backupFilename := consts.SonicBackupCommentPrefix + time.Now().Format("2006-01-02-15-04-05") + util.GenUUIDWithOutDash() + ext
+	backupFile := filepath.Join(backupFilePath, backupFilename)
+
+	file, err := os.Create(backupFile)
+	if err != nil {
+		return "", xerr.WithStatus(err, xerr.StatusInternalServerError).WithMsg("create file err")
+	}
+	defer file.Close()
+	switch exportParam.Format {
+	case "csv":
+		err = writeCommentCSV(file, threads)
+	case "json":
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(threads)
+	case "wxr":
+		err = writeCommentWXR(file, threads)
+	}
+	if err != nil {
+		_ = os.Remove(backupFile)
+		return "", xerr.WithStatus(err, xerr.StatusInternalServerError).WithMsg("write to file err")
+	}
+	return backupFile, nil
+}
+
+// listExportedThreads groups the comments by content, the comments of deleted contents keep an empty title and URL.
+func (e *exportImport) listExportedThreads(ctx context.Context, exportParam *param.ExportComment) ([]*exportedThread, error) {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	commentDO := commentDAL.WithContext(ctx)
+	for commentType, name := range commentTypeNames {
+		if name == exportParam.Type {
+			commentDO = commentDO.Where(commentDAL.Type.Eq(commentType))
+		}
+	}
+	if exportParam.ContentID > 0 {
+		commentDO = commentDO.Where(commentDAL.PostID.Eq(exportParam.ContentID))
+	}
+	comments, err := commentDO.Order(commentDAL.ID).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+
+	type threadKey struct {
+		commentType consts.CommentType
+		contentID   int32
+	}
+	threadMap := make(map[threadKey]*exportedThread)
+	postIDs := make([]int32, 0)
+	journalIDs := make([]int32, 0)
+	for _, comment := range comments {
+		key := threadKey{commentType: comment.Type, contentID: comment.PostID}
+		thread, ok := threadMap[key]
+		if !ok {
+			thread = &exportedThread{Type: commentTypeNames[comment.Type], ID: comment.PostID}
+			threadMap[key] = thread
+			if comment.Type == consts.CommentTypeJournal {
+				journalIDs = append(journalIDs, comment.PostID)
+			} else {
+				postIDs = append(postIDs, comment.PostID)
+			}
+		}
+		thread.Comments = append(thread.Comments, e.convertExportedComment(ctx, comment))
+	}
+
+	blogBaseURL, err := e.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(postIDs...)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, post := range posts {
+		commentType := consts.CommentTypePost
+		if post.Type == consts.PostTypeSheet {
+			commentType = consts.CommentTypeSheet
+		}
+		thread, ok := threadMap[threadKey{commentType: commentType, contentID: post.ID}]
+		if !ok {
+			continue
+		}
+		fullPath, err := e.PostService.BuildFullPath(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		thread.Title = post.Title
+		// the full path is relative unless the absolute path option is enabled
+		if !strings.HasPrefix(fullPath, "http://") && !strings.HasPrefix(fullPath, "https://") {
+			fullPath = blogBaseURL + fullPath
+		}
+		thread.URL = fullPath
+		thread.CreateTime = post.CreateTime
+	}
+
+	if len(journalIDs) > 0 {
+		journalPrefix, err := e.OptionService.GetJournalPrefix(ctx)
+		if err != nil {
+			return nil, err
+		}
+		journalDAL := dal.GetQueryByCtx(ctx).Journal
+		journals, err := journalDAL.WithContext(ctx).Where(journalDAL.ID.In(journalIDs...)).Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, journal := range journals {
+			thread := threadMap[threadKey{commentType: consts.CommentTypeJournal, contentID: journal.ID}]
+			thread.Title = journal.CreateTime.Format("2006-01-02 15:04")
+			thread.URL = blogBaseURL + "/" + journalPrefix
+			thread.CreateTime = journal.CreateTime
+		}
+	}
+
+	threads := make([]*exportedThread, 0, len(threadMap))
+	for _, thread := range threadMap {
+		threads = append(threads, thread)
+	}
+	sort.Slice(threads, func(i, j int) bool {
+		if threads[i].Type != threads[j].Type {
+			return threads[i].Type < threads[j].Type
+		}
+		return threads[i].ID < threads[j].ID
+	})
+	return threads, nil
+}
+
+func (e *exportImport) convertExportedComment(ctx context.Context, comment *entity.Comment) *exportedComment {
+	return &exportedComment{
+		ID:          comment.ID,
+		ParentID:    comment.ParentID,
+		Author:      comment.Author,
+		Email:       comment.Email,
+		AuthorURL:   comment.AuthorURL,
+		IPAddress:   comment.IPAddress,
+		UserAgent:   comment.UserAgent,
+		Content:     html.UnescapeString(comment.Content),
+		ContentHTML: e.BaseCommentService.RenderContent(ctx, comment.Content),
+		Status:      commentStatusName(comment.Status),
+		IsAdmin:     comment.IsAdmin,
+		TopPriority: comment.TopPriority,
+		Likes:       comment.Likes,
+		CreateTime:  comment.CreateTime,
+	}
+}
+
+func commentStatusName(status consts.CommentStatus) string {
+	name, _ := status.MarshalJSON()
+	s, _ := strconv.Unquote(string(name))
+	return s
+}
+
+func writeCommentCSV(writer io.Writer, threads []*exportedThread) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{
+		"id", "parent_id", "content_type", "content_id", "content_title", "content_url",
+		"author", "email", "author_url", "ip_address", "user_agent", "content",
+		"status", "is_admin", "top_priority", "likes", "create_time",
+	})
+	if err != nil {
+		return err
+	}
+	for _, thread := range threads {
+		for _, comment := range thread.Comments {
+			err = csvWriter.Write([]string{
+				strconv.Itoa(int(comment.ID)),
+				strconv.Itoa(int(comment.ParentID)),
+				thread.Type,
+				strconv.Itoa(int(thread.ID)),
+				thread.Title,
+				thread.URL,
+				comment.Author,
+				comment.Email,
+				comment.AuthorURL,
+				comment.IPAddress,
+				comment.UserAgent,
+				comment.Content,
+				comment.Status,
+				strconv.FormatBool(comment.IsAdmin),
+				strconv.Itoa(int(comment.TopPriority)),
+				strconv.Itoa(int(comment.Likes)),
+				comment.CreateTime.Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// wxrRSS is the WordPress eXtended RSS accepted by the Disqus importer.
+type wxrRSS struct {
+	XMLName      xml.Name   `xml:"rss"`
+	Version      string     `xml:"version,attr"`
+	XMLNSContent string     `xml:"xmlns:content,attr"`
+	XMLNSDsq     string     `xml:"xmlns:dsq,attr"`
+	XMLNSDc      string     `xml:"xmlns:dc,attr"`
+	XMLNSWp      string     `xml:"xmlns:wp,attr"`
+	Items        []*wxrItem `xml:"channel>item"`
+}
+
+type wxrCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+type wxrItem struct {
+	Title            string        `xml:"title"`
+	Link             string        `xml:"link"`
+	Content          wxrCDATA      `xml:"content:encoded"`
+	ThreadIdentifier string        `xml:"dsq:thread_identifier"`
+	PostDateGMT      string        `xml:"wp:post_date_gmt"`
+	CommentStatus    string        `xml:"wp:comment_status"`
+	Comments         []*wxrComment `xml:"wp:comment"`
+}
+
+type wxrComment struct {
+	ID          int32    `xml:"wp:comment_id"`
+	Author      string   `xml:"wp:comment_author"`
+	AuthorEmail string   `xml:"wp:comment_author_email"`
+	AuthorURL   string   `xml:"wp:comment_author_url"`
+	AuthorIP    string   `xml:"wp:comment_author_IP"`
+	DateGMT     string   `xml:"wp:comment_date_gmt"`
+	Content     wxrCDATA `xml:"wp:comment_content"`
+	Approved    string   `xml:"wp:comment_approved"`
+	Parent      int32    `xml:"wp:comment_parent"`
+}
+
+// writeCommentWXR skips the comments of deleted contents because Disqus requires the link of the thread.
+func writeCommentWXR(writer io.Writer, threads []*exportedThread) error {
+	rss := &wxrRSS{
+		Version:      "2.0",
+		XMLNSContent: "http://purl.org/rss/1.0/modules/content/",
+		XMLNSDsq:     "http://www.disqus.com/",
+		XMLNSDc:      "http://purl.org/dc/elements/1.1/",
+		XMLNSWp:      "http://wordpress.org/export/1.0/",
+	}
+	for _, thread := range threads {
+		if thread.URL == "" {
+			continue
+		}
+		item := &wxrItem{
+			Title:            thread.Title,
+			Link:             thread.URL,
+			ThreadIdentifier: thread.Type + "-" + strconv.Itoa(int(thread.ID)),
+			PostDateGMT:      thread.CreateTime.UTC().Format(wxrTimeLayout),
+			CommentStatus:    "open",
+		}
+		for _, comment := range thread.Comments {
+			item.Comments = append(item.Comments, &wxrComment{
+				ID:          comment.ID,
+				Author:      comment.Author,
+				AuthorEmail: comment.Email,
+				AuthorURL:   comment.AuthorURL,
+				AuthorIP:    comment.IPAddress,
+				DateGMT:     comment.CreateTime.UTC().Format(wxrTimeLayout),
+				Content:     wxrCDATA{Value: comment.ContentHTML},
+				Approved:    wxrCommentApproved(comment.Status),
+				Parent:      comment.ParentID,
+			})
+		}
+		rss.Items = append(rss.Items, item)
+	}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	return encoder.Encode(rss)
+}
+
+func wxrCommentApproved(status string) string {
+	switch status {
+	case "PUBLISHED":
+		return "1"
+	case "SPAM":
+		return "spam"
+	case "RECYCLE":
+		return "trash"
+	}
+	return "0"
+}
@@ -0,0 +1,170 @@
+package impl
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-sonic/sonic/config"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/epub"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+func (e *exportImport) ExportEpub(ctx context.Context, exportParam *param.ExportEpub) (string, error) {
+	posts, title, err := e.listEpubPosts(ctx, exportParam)
+	if err != nil {
+		return "", err
+	}
+	if len(posts) == 0 {
+		return "", xerr.BadParam.New("").WithMsg("no post to export").WithStatus(xerr.StatusBadRequest)
+	}
+	if exportParam.Title != "" {
+		title = exportParam.Title
+	}
+
+	blogTitle := e.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string)
+	if title == "" {
+		title = blogTitle
+	}
+	blogBaseURL, err := e.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	book := epub.NewBook("urn:uuid:"+util.GenUUIDWithOutDash(), title)
+	book.Publisher = blogTitle
+	book.Description = exportParam.Description
+	book.BaseURL = blogBaseURL
+	book.Language = strings.ReplaceAll(e.OptionService.GetOrByDefault(ctx, property.BlogLocale).(string), "_", "-")
+	users, err := e.UserService.GetAllUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(users) > 0 {
+		book.Author = users[0].Nickname
+	}
+	book.ImageLoader = func(src string) ([]byte, bool) {
+		return e.loadLocalImage(ctx, blogBaseURL, src)
+	}
+
+	for _, post := range posts {
+		content, err := e.ShortcodeService.Render(ctx, post.FormatContent)
+		if err != nil {
+			return "", err
+		}
+		err = book.AddChapter(post.Title, content)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	backupFilePath := config.BackupEpubDir
+	if _, err := os.Stat(backupFilePath); os.IsNotExist(err) {
+		err = os.MkdirAll(backupFilePath, os.ModePerm)
+		if err != nil {
+			return "", xerr.NoType.Wrap(err).WithMsg("create dir err")
+		}
+	} else if err != nil {
+		return "", xerr.NoType.Wrap(err).WithMsg("get fileInfo")
+	}
+	backupFilename := consts.SonicBackupEpubPrefix + time.Now().Format("2006-01-02-15-04-05") + util.GenUUIDWithOutDash() + ".epub"
+	backupFile := filepath.Join(backupFilePath, backupFilename)
+
+	file, err := os.Create(backupFile)
+	if err != nil {
+		return "", xerr.WithStatus(err, xerr.StatusInternalServerError).WithMsg("create file err")
+	}
+	defer file.Close()
+	err = book.Write(file)
+	if err != nil {
+		_ = os.Remove(backupFile)
+		return "", err
+	}
+	return backupFile, nil
+}
+
+// listEpubPosts returns the posts of the e-book and the default title of the e-book.
+func (e *exportImport) listEpubPosts(ctx context.Context, exportParam *param.ExportEpub) ([]*entity.Post, string, error) {
+	switch {
+	case len(exportParam.PostIDs) > 0:
+		postMap, err := e.PostService.GetByPostIDs(ctx, exportParam.PostIDs)
+		if err != nil {
+			return nil, "", err
+		}
+		// keep the order picked by the user, drafts, recycled and private posts are left out like in the other selections
+		posts := make([]*entity.Post, 0, len(postMap))
+		for _, postID := range exportParam.PostIDs {
+			if post, ok := postMap[postID]; ok && post.Type == consts.PostTypePost && post.Status == consts.PostStatusPublished {
+				posts = append(posts, post)
+				delete(postMap, postID)
+			}
+		}
+		return posts, "", nil
+	case exportParam.CategoryID != nil:
+		category, err := e.CategoryService.GetByID(ctx, *exportParam.CategoryID)
+		if err != nil {
+			return nil, "", err
+		}
+		posts, err := e.PostCategoryService.ListByCategoryID(ctx, category.ID, consts.PostStatusPublished)
+		if err != nil {
+			return nil, "", err
+		}
+		sortPostsByCreateTime(posts)
+		return posts, category.Name, nil
+	case exportParam.TagID != nil:
+		tag, err := e.TagService.GetByID(ctx, *exportParam.TagID)
+		if err != nil {
+			return nil, "", err
+		}
+		posts, err := e.PostTagService.ListPostByTagID(ctx, tag.ID, consts.PostStatusPublished)
+		if err != nil {
+			return nil, "", err
+		}
+		sortPostsByCreateTime(posts)
+		return posts, tag.Name, nil
+	default:
+		return nil, "", xerr.BadParam.New("").WithMsg("categoryId, tagId or postIds is required").WithStatus(xerr.StatusBadRequest)
+	}
+}
+
+func sortPostsByCreateTime(posts []*entity.Post) {
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].CreateTime.Before(posts[j].CreateTime)
+	})
+}
+
+// loadLocalImage reads an image uploaded to the local storage, images stored elsewhere are not embedded.
+func (e *exportImport) loadLocalImage(ctx context.Context, blogBaseURL, src string) ([]byte, bool) {
+	if blogBaseURL != "" && strings.HasPrefix(src, blogBaseURL) {
+		src = strings.TrimPrefix(src, blogBaseURL)
+	}
+	u, err := url.Parse(src)
+	if err != nil || u.Host != "" || u.Scheme != "" {
+		return nil, false
+	}
+	relativePath := strings.TrimPrefix(u.Path, "/")
+	if !strings.HasPrefix(relativePath, consts.SonicUploadDir+"/") {
+		return nil, false
+	}
+	workDir := filepath.Clean(e.Config.Sonic.WorkDir)
+	fullPath := filepath.Join(workDir, filepath.FromSlash(relativePath))
+	if !strings.HasPrefix(fullPath, workDir+string(os.PathSeparator)) {
+		return nil, false
+	}
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		log.CtxWarnf(ctx, "ExportEpub read image err path=%v err=%v", fullPath, err)
+		return nil, false
+	}
+	return data, true
+}
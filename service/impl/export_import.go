@@ -28,25 +28,40 @@ import (
 )
 
 type exportImport struct {
+	Config              *config.Config
 	CategoryService     service.CategoryService
 	PostService         service.PostService
 	TagService          service.TagService
 	PostTagService      service.PostTagService
 	PostCategoryService service.PostCategoryService
+	OptionService       service.OptionService
+	UserService         service.UserService
+	BaseCommentService  service.BaseCommentService
+	ShortcodeService    service.ShortcodeService
 }
 
-func NewExportImport(categoryService service.CategoryService,
+func NewExportImport(config *config.Config,
+	categoryService service.CategoryService,
 	postService service.PostService,
 	tagService service.TagService,
 	postTagService service.PostTagService,
 	postCategoryService service.PostCategoryService,
+	optionService service.OptionService,
+	userService service.UserService,
+	baseCommentService service.BaseCommentService,
+	shortcodeService service.ShortcodeService,
 ) service.ExportImport {
 	return &exportImport{
+		Config:              config,
 		CategoryService:     categoryService,
 		PostService:         postService,
 		TagService:          tagService,
 		PostTagService:      postTagService,
 		PostCategoryService: postCategoryService,
+		OptionService:       optionService,
+		UserService:         userService,
+		BaseCommentService:  baseCommentService,
+		ShortcodeService:    shortcodeService,
 	}
 }
 
@@ -0,0 +1,49 @@
+package impl
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// NewHTTPClient returns the client of the requests to the urls given by others, such as the webmention sources and the ActivityPub actors.
+// It refuses to connect to the private, loopback and link-local addresses, including the ones redirected to,
+// so that the server cannot be used to reach the internal network.
+func NewHTTPClient() service.HTTPClient {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyPrivateAddress,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the address on behalf of the server, which is not checked
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+}
+
+// denyPrivateAddress is called with the resolved address before connecting, so the check cannot be bypassed by the DNS.
+func denyPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsUnspecified() || addr.IsMulticast() {
+		return xerr.Forbidden.New("address=%v", address).WithMsg("The address is not allowed")
+	}
+	return nil
+}
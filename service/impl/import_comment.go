@@ -0,0 +1,411 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"gorm.io/gorm"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/entity"
+)
+
+// foreignComment is a comment exported by a comment system such as Disqus or Twikoo.
+type foreignComment struct {
+	ID       string
+	ParentID string
+	// ThreadURLs are the URLs or the identifiers of the commented page, the first one matching a post or a sheet is used
+	ThreadURLs  []string
+	Author      string
+	Email       string
+	AuthorURL   string
+	IPAddress   string
+	UserAgent   string
+	Content     string
+	Status      consts.CommentStatus
+	IsAdmin     bool
+	TopPriority int32
+	CreateTime  time.Time
+}
+
+// commentThreadResolver finds the post or the sheet of a comment thread by the permalink, slug or ID.
+type commentThreadResolver struct {
+	pathSuffix string
+	paths      map[string]*entity.Post
+	slugs      map[string]*entity.Post
+	ids        map[string]*entity.Post
+}
+
+func (e *exportImport) newCommentThreadResolver(ctx context.Context) (*commentThreadResolver, error) {
+	pathSuffix, err := e.OptionService.GetPathSuffix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolver := &commentThreadResolver{
+		pathSuffix: pathSuffix,
+		paths:      make(map[string]*entity.Post),
+		slugs:      make(map[string]*entity.Post),
+		ids:        make(map[string]*entity.Post),
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, post := range posts {
+		fullPath, err := e.PostService.BuildFullPath(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		if u, err := url.Parse(fullPath); err == nil {
+			resolver.paths[strings.TrimSuffix(u.Path, "/")] = post
+		}
+		resolver.slugs[post.Slug] = post
+		resolver.ids[strconv.Itoa(int(post.ID))] = post
+	}
+	return resolver, nil
+}
+
+func (r *commentThreadResolver) resolve(threadURLs []string) *entity.Post {
+	for _, threadURL := range threadURLs {
+		threadURL = strings.TrimSpace(threadURL)
+		if threadURL == "" {
+			continue
+		}
+		u, err := url.Parse(threadURL)
+		if err != nil {
+			continue
+		}
+		if id := u.Query().Get("p"); id != "" {
+			if post, ok := r.ids[id]; ok {
+				return post
+			}
+		}
+		urlPath := strings.TrimSuffix(u.Path, "/")
+		if post, ok := r.paths[urlPath]; ok {
+			return post
+		}
+		if r.pathSuffix != "" {
+			if post, ok := r.paths[strings.TrimSuffix(urlPath, r.pathSuffix)]; ok {
+				return post
+			}
+		}
+		// the blog may have used another permalink format, the slug is usually the last segment
+		slug := path.Base(urlPath)
+		slug = strings.TrimSuffix(slug, path.Ext(slug))
+		if post, ok := r.slugs[slug]; ok && slug != "" {
+			return post
+		}
+	}
+	return nil
+}
+
+// importForeignComments imports the comments of the resolved threads, parents are imported before their replies.
+// The comments imported before are matched by the author, email and create time, so the import can be run again.
+func (c *contentImporter) importForeignComments(ctx context.Context, resolver *commentThreadResolver, comments []*foreignComment) error {
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreateTime.Before(comments[j].CreateTime)
+	})
+	byID := make(map[string]*foreignComment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+	commentIDs := make(map[string]int32, len(comments))
+	unresolvedThreads := make(map[string]int)
+	orphans, untimed := 0, 0
+
+	var importOne func(foreign *foreignComment) (int32, error)
+	importOne = func(foreign *foreignComment) (int32, error) {
+		if commentID, ok := commentIDs[foreign.ID]; ok {
+			return commentID, nil
+		}
+		// guards against the cycles of broken exports
+		commentIDs[foreign.ID] = 0
+
+		// the comments without a time would be imported again on every run
+		if foreign.CreateTime.IsZero() {
+			untimed++
+			return 0, nil
+		}
+		post := resolver.resolve(foreign.ThreadURLs)
+		if post == nil {
+			if len(foreign.ThreadURLs) > 0 {
+				unresolvedThreads[foreign.ThreadURLs[0]]++
+			}
+			return 0, nil
+		}
+		var parentID int32
+		if foreign.ParentID != "" {
+			if parent, ok := byID[foreign.ParentID]; ok {
+				id, err := importOne(parent)
+				if err != nil {
+					return 0, err
+				}
+				parentID = id
+			}
+			if parentID == 0 {
+				orphans++
+			}
+		}
+		comment := &entity.Comment{
+			Type:        consts.CommentTypePost,
+			PostID:      post.ID,
+			ParentID:    parentID,
+			Author:      truncateRunes(strings.TrimSpace(foreign.Author), 50),
+			Email:       strings.TrimSpace(foreign.Email),
+			AuthorURL:   truncateRunes(foreign.AuthorURL, 511),
+			IPAddress:   truncateRunes(foreign.IPAddress, 127),
+			UserAgent:   truncateRunes(foreign.UserAgent, 511),
+			Content:     escapeCommentContent(foreign.Content, 1023),
+			Status:      foreign.Status,
+			IsAdmin:     foreign.IsAdmin,
+			TopPriority: foreign.TopPriority,
+			// the database may keep whole seconds only, the imported comments are matched by the time stored
+			CreateTime: foreign.CreateTime.Truncate(time.Second),
+		}
+		if post.Type == consts.PostTypeSheet {
+			comment.Type = consts.CommentTypeSheet
+		}
+		if comment.Author == "" {
+			comment.Author = "Anonymous"
+		}
+
+		commentDAL := dal.GetQueryByCtx(ctx).Comment
+		existed, err := commentDAL.WithContext(ctx).Where(
+			commentDAL.Type.Eq(comment.Type),
+			commentDAL.PostID.Eq(comment.PostID),
+			commentDAL.Author.Eq(comment.Author),
+			commentDAL.Email.Eq(comment.Email),
+			commentDAL.CreateTime.Eq(comment.CreateTime),
+		).Take()
+		if err == nil {
+			commentIDs[foreign.ID] = existed.ID
+			return existed.ID, nil
+		} else if err != gorm.ErrRecordNotFound {
+			return 0, WrapDBErr(err)
+		}
+		// the commenters have not agreed to receive the mails of sonic
+		comment.AllowNotification = false
+		if err := c.importComment(ctx, comment); err != nil {
+			return 0, err
+		}
+		commentIDs[foreign.ID] = comment.ID
+		return comment.ID, nil
+	}
+
+	for _, comment := range comments {
+		if _, err := importOne(comment); err != nil {
+			return err
+		}
+	}
+	for threadURL, count := range unresolvedThreads {
+		c.unmapped("%d comments of %q: no post or sheet matches the thread", count, threadURL)
+	}
+	if untimed > 0 {
+		c.unmapped("%d comments: the create time is missing or cannot be parsed", untimed)
+	}
+	if orphans > 0 {
+		c.unmapped("%d replies: the parent comment is not imported, imported as top level comments", orphans)
+	}
+	return nil
+}
+
+// escapeCommentContent escapes the content like the comments posted by visitors, and keeps the escaped content within the limit.
+func escapeCommentContent(content string, limit int) string {
+	content = strings.TrimSpace(content)
+	escaped := template.HTMLEscapeString(content)
+	if len([]rune(escaped)) <= limit {
+		return escaped
+	}
+	result := &strings.Builder{}
+	length := 0
+	for _, r := range content {
+		escapedRune := template.HTMLEscapeString(string(r))
+		if length+len([]rune(escapedRune)) > limit {
+			break
+		}
+		length += len([]rune(escapedRune))
+		result.WriteString(escapedRune)
+	}
+	return result.String()
+}
+
+// commentHTMLToMarkdown converts the HTML of the exported comments to the markdown subset supported by sonic comments.
+func commentHTMLToMarkdown(content string) string {
+	result := &strings.Builder{}
+	hrefs := make([]string, 0)
+	inPre := false
+	removedDepth := 0
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for tokenType := tokenizer.Next(); tokenType != html.ErrorToken; tokenType = tokenizer.Next() {
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			switch {
+			case removedDepth > 0:
+			case inPre:
+				result.WriteString(token.Data)
+			default:
+				text := strings.Join(strings.Fields(token.Data), " ")
+				if text == "" {
+					text = " "
+				} else {
+					if strings.TrimLeft(token.Data, " \t\r\n") != token.Data {
+						text = " " + text
+					}
+					if strings.TrimRight(token.Data, " \t\r\n") != token.Data {
+						text += " "
+					}
+				}
+				result.WriteString(text)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if commentRemovedTags[token.DataAtom] {
+				if tokenType == html.StartTagToken {
+					removedDepth++
+				}
+				continue
+			}
+			switch token.DataAtom {
+			case atom.Br:
+				result.WriteString("\n")
+			case atom.P, atom.Div, atom.Li, atom.Blockquote:
+				result.WriteString("\n\n")
+			case atom.Strong, atom.B:
+				result.WriteString("**")
+			case atom.Em, atom.I:
+				result.WriteString("*")
+			case atom.Del, atom.S:
+				result.WriteString("~~")
+			case atom.Code:
+				if !inPre {
+					result.WriteString("`")
+				}
+			case atom.Pre:
+				inPre = true
+				result.WriteString("\n\n```\n")
+			case atom.A:
+				href := getAttr(&token, "href")
+				hrefs = append(hrefs, href)
+				if href != "" {
+					result.WriteString("[")
+				}
+			case atom.Img:
+				if alt := getAttr(&token, "alt"); alt != "" {
+					result.WriteString(alt)
+				} else if src := getAttr(&token, "src"); src != "" {
+					result.WriteString(src)
+				}
+			}
+		case html.EndTagToken:
+			if commentRemovedTags[token.DataAtom] {
+				if removedDepth > 0 {
+					removedDepth--
+				}
+				continue
+			}
+			switch token.DataAtom {
+			case atom.P, atom.Div, atom.Li, atom.Blockquote:
+				result.WriteString("\n\n")
+			case atom.Strong, atom.B:
+				result.WriteString("**")
+			case atom.Em, atom.I:
+				result.WriteString("*")
+			case atom.Del, atom.S:
+				result.WriteString("~~")
+			case atom.Code:
+				if !inPre {
+					result.WriteString("`")
+				}
+			case atom.Pre:
+				inPre = false
+				result.WriteString("\n```\n\n")
+			case atom.A:
+				href := ""
+				if len(hrefs) > 0 {
+					href, hrefs = hrefs[len(hrefs)-1], hrefs[:len(hrefs)-1]
+				}
+				if href != "" {
+					result.WriteString("](" + href + ")")
+				}
+			}
+		}
+	}
+	lines := strings.Split(result.String(), "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "    ") {
+			line = strings.TrimLeft(line, " ")
+		}
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	text := strings.Join(lines, "\n")
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+	return strings.TrimSpace(text)
+}
+
+// foreignID is an ID exported as a string, a number or a MongoDB object ID.
+type foreignID string
+
+func (f *foreignID) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*f = foreignID(str)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err == nil {
+		*f = foreignID(number.String())
+		return nil
+	}
+	objectID := struct {
+		OID string `json:"$oid"`
+	}{}
+	if err := json.Unmarshal(data, &objectID); err != nil {
+		return err
+	}
+	*f = foreignID(objectID.OID)
+	return nil
+}
+
+// foreignTime is a time exported as milliseconds, a formatted string or a MongoDB date.
+type foreignTime time.Time
+
+var foreignTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+func (f *foreignTime) UnmarshalJSON(data []byte) error {
+	var millis int64
+	if err := json.Unmarshal(data, &millis); err == nil {
+		*f = foreignTime(time.UnixMilli(millis))
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		for _, layout := range foreignTimeLayouts {
+			if t, err := time.Parse(layout, str); err == nil {
+				*f = foreignTime(t)
+				return nil
+			}
+		}
+		return nil
+	}
+	date := struct {
+		Date foreignTime `json:"$date"`
+	}{}
+	if err := json.Unmarshal(data, &date); err != nil {
+		return err
+	}
+	*f = date.Date
+	return nil
+}
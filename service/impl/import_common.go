@@ -0,0 +1,285 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+)
+
+// contentImporter writes the entities mapped from another blog system.
+// Tags and categories with an existing slug are reused and posts with an existing slug are skipped,
+// so importing the same file twice does not duplicate content.
+type contentImporter struct {
+	report *dto.ImportReport
+}
+
+func newContentImporter() *contentImporter {
+	return &contentImporter{
+		report: &dto.ImportReport{
+			Imported: make(map[string]int),
+			Unmapped: make([]string, 0),
+		},
+	}
+}
+
+func (c *contentImporter) imported(item string) {
+	c.report.Imported[item]++
+}
+
+func (c *contentImporter) unmapped(format string, args ...interface{}) {
+	c.report.Unmapped = append(c.report.Unmapped, fmt.Sprintf(format, args...))
+}
+
+// the hooks of the entities overwrite the create time
+func skipHooks() *gorm.Session {
+	return &gorm.Session{SkipHooks: true}
+}
+
+func fillCreateTime(createTime *time.Time) {
+	if createTime.IsZero() {
+		*createTime = time.Now()
+	}
+}
+
+func (c *contentImporter) importTag(ctx context.Context, tag *entity.Tag) (int32, error) {
+	tagDAL := dal.GetQueryByCtx(ctx).Tag
+	if tag.Slug == "" {
+		tag.Slug = util.Slug(tag.Name)
+	}
+	existed, err := tagDAL.WithContext(ctx).Where(tagDAL.Slug.Eq(tag.Slug)).Take()
+	if err == nil {
+		return existed.ID, nil
+	} else if err != gorm.ErrRecordNotFound {
+		return 0, WrapDBErr(err)
+	}
+	if tag.Color == "" {
+		tag.Color = consts.SonicDefaultTagColor
+	}
+	fillCreateTime(&tag.CreateTime)
+	err = tagDAL.WithContext(ctx).Session(skipHooks()).Create(tag)
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	c.imported("tags")
+	return tag.ID, nil
+}
+
+func (c *contentImporter) importCategory(ctx context.Context, category *entity.Category) (int32, error) {
+	categoryDAL := dal.GetQueryByCtx(ctx).Category
+	if category.Slug == "" {
+		category.Slug = util.Slug(category.Name)
+	}
+	existed, err := categoryDAL.WithContext(ctx).Where(categoryDAL.Slug.Eq(category.Slug)).Take()
+	if err == nil {
+		return existed.ID, nil
+	} else if err != gorm.ErrRecordNotFound {
+		return 0, WrapDBErr(err)
+	}
+	if category.Password != "" {
+		category.Type = consts.CategoryTypeIntimate
+	}
+	fillCreateTime(&category.CreateTime)
+	err = categoryDAL.WithContext(ctx).Session(skipHooks()).Create(category)
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	c.imported("categories")
+	return category.ID, nil
+}
+
+// importPost creates a post or a sheet, it returns 0 if a post with the same slug already exists.
+func (c *contentImporter) importPost(ctx context.Context, post *entity.Post) (int32, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	item := "posts"
+	if post.Type == consts.PostTypeSheet {
+		item = "sheets"
+	}
+	if post.Slug == "" {
+		post.Slug = util.Slug(post.Title)
+	}
+	count, err := postDAL.WithContext(ctx).Where(postDAL.Slug.Eq(post.Slug)).Count()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	if count > 0 {
+		c.unmapped("%s %q: slug %q already exists", strings.TrimSuffix(item, "s"), post.Title, post.Slug)
+		return 0, nil
+	}
+	if post.WordCount == 0 {
+		post.WordCount = util.HTMLFormatWordCount(post.FormatContent)
+	}
+	fillCreateTime(&post.CreateTime)
+	status := post.Status
+	err = postDAL.WithContext(ctx).Session(skipHooks()).Create(post)
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	// gorm does not insert zero values of columns with a default value
+	if status == consts.PostStatusPublished {
+		_, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(post.ID)).UpdateColumnSimple(postDAL.Status.Value(status))
+		if err != nil {
+			return 0, WrapDBErr(err)
+		}
+		post.Status = status
+	}
+	c.imported(item)
+	return post.ID, nil
+}
+
+func (c *contentImporter) importPostCategory(ctx context.Context, postID, categoryID int32) error {
+	postCategoryDAL := dal.GetQueryByCtx(ctx).PostCategory
+	err := postCategoryDAL.WithContext(ctx).Create(&entity.PostCategory{PostID: postID, CategoryID: categoryID})
+	return WrapDBErr(err)
+}
+
+func (c *contentImporter) importPostTag(ctx context.Context, postID, tagID int32) error {
+	postTagDAL := dal.GetQueryByCtx(ctx).PostTag
+	err := postTagDAL.WithContext(ctx).Create(&entity.PostTag{PostID: postID, TagID: tagID})
+	return WrapDBErr(err)
+}
+
+func (c *contentImporter) importMeta(ctx context.Context, meta *entity.Meta) error {
+	metaDAL := dal.GetQueryByCtx(ctx).Meta
+	err := metaDAL.WithContext(ctx).Create(meta)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	c.imported("metas")
+	return nil
+}
+
+func (c *contentImporter) importComment(ctx context.Context, comment *entity.Comment) error {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	if comment.GravatarMd5 == "" && comment.Email != "" {
+		comment.GravatarMd5 = util.Md5Hex(comment.Email)
+	}
+	fillCreateTime(&comment.CreateTime)
+	allowNotification := comment.AllowNotification
+	err := commentDAL.WithContext(ctx).Session(skipHooks()).Create(comment)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if !allowNotification {
+		_, err = commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(comment.ID)).UpdateColumnSimple(commentDAL.AllowNotification.Value(false))
+		if err != nil {
+			return WrapDBErr(err)
+		}
+	}
+	c.imported("comments")
+	return nil
+}
+
+func (c *contentImporter) importMenu(ctx context.Context, menu *entity.Menu) (int32, error) {
+	menuDAL := dal.GetQueryByCtx(ctx).Menu
+	existed, err := menuDAL.WithContext(ctx).Where(menuDAL.Name.Eq(menu.Name), menuDAL.URL.Eq(menu.URL), menuDAL.Team.Eq(menu.Team)).Take()
+	if err == nil {
+		return existed.ID, nil
+	} else if err != gorm.ErrRecordNotFound {
+		return 0, WrapDBErr(err)
+	}
+	fillCreateTime(&menu.CreateTime)
+	err = menuDAL.WithContext(ctx).Session(skipHooks()).Create(menu)
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	c.imported("menus")
+	return menu.ID, nil
+}
+
+func (c *contentImporter) importLink(ctx context.Context, link *entity.Link) error {
+	linkDAL := dal.GetQueryByCtx(ctx).Link
+	count, err := linkDAL.WithContext(ctx).Where(linkDAL.URL.Eq(link.URL)).Count()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if count > 0 {
+		return nil
+	}
+	fillCreateTime(&link.CreateTime)
+	err = linkDAL.WithContext(ctx).Session(skipHooks()).Create(link)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	c.imported("links")
+	return nil
+}
+
+func (c *contentImporter) importPhoto(ctx context.Context, photo *entity.Photo) error {
+	photoDAL := dal.GetQueryByCtx(ctx).Photo
+	count, err := photoDAL.WithContext(ctx).Where(photoDAL.URL.Eq(photo.URL)).Count()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if count > 0 {
+		return nil
+	}
+	fillCreateTime(&photo.CreateTime)
+	err = photoDAL.WithContext(ctx).Session(skipHooks()).Create(photo)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	c.imported("photos")
+	return nil
+}
+
+// importJournal creates a journal, it returns 0 if the same journal already exists.
+func (c *contentImporter) importJournal(ctx context.Context, journal *entity.Journal) (int32, error) {
+	journalDAL := dal.GetQueryByCtx(ctx).Journal
+	fillCreateTime(&journal.CreateTime)
+	count, err := journalDAL.WithContext(ctx).Where(journalDAL.SourceContent.Eq(journal.SourceContent), journalDAL.CreateTime.Eq(journal.CreateTime)).Count()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	if count > 0 {
+		return 0, nil
+	}
+	err = journalDAL.WithContext(ctx).Session(skipHooks()).Create(journal)
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	c.imported("journals")
+	return journal.ID, nil
+}
+
+// options that describe the installation itself rather than the blog
+var notImportedOptionKeys = map[string]struct{}{
+	property.IsInstalled.KeyValue: {},
+	property.Theme.KeyValue:       {},
+	property.JWTSecret.KeyValue:   {},
+	property.BlogURL.KeyValue:     {},
+	property.BirthDay.KeyValue:    {},
+}
+
+// importOptions saves the options one by one so that an invalid value does not discard the others.
+func (c *contentImporter) importOptions(ctx context.Context, optionService service.OptionService, options map[string]string) {
+	knownKeys := make(map[string]struct{}, len(property.AllProperty))
+	for _, p := range property.AllProperty {
+		knownKeys[p.KeyValue] = struct{}{}
+	}
+	for key, value := range options {
+		if _, ok := notImportedOptionKeys[key]; ok {
+			continue
+		}
+		if _, ok := knownKeys[key]; !ok {
+			c.unmapped("option %q: not supported", key)
+			continue
+		}
+		err := optionService.Save(ctx, map[string]string{key: value})
+		if err != nil {
+			c.unmapped("option %q: %v", key, err)
+			continue
+		}
+		c.imported("options")
+	}
+}
@@ -0,0 +1,108 @@
+package impl
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// disqusRef refers to a thread or a post by the ID in the namespace of the Disqus internals
+type disqusRef struct {
+	ID string `xml:"http://disqus.com/disqus-internals id,attr"`
+}
+
+type disqusThread struct {
+	ID         string `xml:"http://disqus.com/disqus-internals id,attr"`
+	Identifier string `xml:"id"`
+	Link       string `xml:"link"`
+	Title      string `xml:"title"`
+}
+
+type disqusAuthor struct {
+	Email       string `xml:"email"`
+	Name        string `xml:"name"`
+	Username    string `xml:"username"`
+	IsAnonymous bool   `xml:"isAnonymous"`
+}
+
+type disqusPost struct {
+	ID        string       `xml:"http://disqus.com/disqus-internals id,attr"`
+	Message   string       `xml:"message"`
+	CreatedAt time.Time    `xml:"createdAt"`
+	IsDeleted bool         `xml:"isDeleted"`
+	IsSpam    bool         `xml:"isSpam"`
+	Author    disqusAuthor `xml:"author"`
+	IPAddress string       `xml:"ipAddress"`
+	Thread    disqusRef    `xml:"thread"`
+	Parent    *disqusRef   `xml:"parent"`
+}
+
+// disqusExport is the XML file exported by "Moderation - Export" of Disqus.
+type disqusExport struct {
+	XMLName xml.Name       `xml:"disqus"`
+	Threads []disqusThread `xml:"thread"`
+	Posts   []disqusPost   `xml:"post"`
+}
+
+func (e *exportImport) ImportDisqus(ctx context.Context, reader io.Reader) (*dto.ImportReport, error) {
+	export := &disqusExport{}
+	err := xml.NewDecoder(reader).Decode(export)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid Disqus data").WithStatus(xerr.StatusBadRequest)
+	}
+	resolver, err := e.newCommentThreadResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	importer := newContentImporter()
+	threads := make(map[string]*disqusThread, len(export.Threads))
+	for i := range export.Threads {
+		threads[export.Threads[i].ID] = &export.Threads[i]
+	}
+	comments := make([]*foreignComment, 0, len(export.Posts))
+	deleted := 0
+	for _, post := range export.Posts {
+		if post.IsDeleted {
+			deleted++
+			continue
+		}
+		comment := &foreignComment{
+			ID:         post.ID,
+			Author:     post.Author.Name,
+			Email:      post.Author.Email,
+			IPAddress:  post.IPAddress,
+			Content:    commentHTMLToMarkdown(post.Message),
+			Status:     consts.CommentStatusPublished,
+			CreateTime: post.CreatedAt,
+		}
+		if comment.Author == "" {
+			comment.Author = post.Author.Username
+		}
+		if post.IsSpam {
+			comment.Status = consts.CommentStatusSpam
+		}
+		if post.Parent != nil {
+			comment.ParentID = post.Parent.ID
+		}
+		if thread, ok := threads[post.Thread.ID]; ok {
+			comment.ThreadURLs = []string{thread.Link, thread.Identifier}
+		}
+		comments = append(comments, comment)
+	}
+	if deleted > 0 {
+		importer.unmapped("%d comments: deleted on Disqus", deleted)
+	}
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		return importer.importForeignComments(txCtx, resolver, comments)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return importer.report, nil
+}
@@ -0,0 +1,314 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// ghostURLPlaceholder is written by Ghost in place of the site url
+const ghostURLPlaceholder = "__GHOST_URL__"
+
+type ghostPost struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	Slug            string     `json:"slug"`
+	Mobiledoc       string     `json:"mobiledoc"`
+	HTML            string     `json:"html"`
+	FeatureImage    string     `json:"feature_image"`
+	Featured        bool       `json:"featured"`
+	Type            string     `json:"type"`
+	Status          string     `json:"status"`
+	Visibility      string     `json:"visibility"`
+	CustomExcerpt   string     `json:"custom_excerpt"`
+	CustomTemplate  string     `json:"custom_template"`
+	MetaDescription string     `json:"meta_description"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+	PublishedAt     *time.Time `json:"published_at"`
+}
+
+type ghostPostMeta struct {
+	PostID          string `json:"post_id"`
+	MetaDescription string `json:"meta_description"`
+}
+
+type ghostTag struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	FeatureImage string    `json:"feature_image"`
+	Visibility   string    `json:"visibility"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ghostPostTag struct {
+	PostID string `json:"post_id"`
+	TagID  string `json:"tag_id"`
+}
+
+type ghostUser struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ProfileImage string `json:"profile_image"`
+	Bio          string `json:"bio"`
+}
+
+type ghostSetting struct {
+	Key   string  `json:"key"`
+	Value *string `json:"value"`
+}
+
+type ghostNavigation struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+type ghostData struct {
+	Posts     []ghostPost     `json:"posts"`
+	PostsMeta []ghostPostMeta `json:"posts_meta"`
+	Tags      []ghostTag      `json:"tags"`
+	PostsTags []ghostPostTag  `json:"posts_tags"`
+	Users     []ghostUser     `json:"users"`
+	Settings  []ghostSetting  `json:"settings"`
+}
+
+// ghostExport is the JSON file exported by "Settings - Labs - Export your content" of Ghost.
+// Older versions wrap the export in a db array.
+type ghostExport struct {
+	DB   []ghostExport `json:"db"`
+	Data *ghostData    `json:"data"`
+}
+
+// ghostMobiledoc is the document format of the Ghost editor before 5.0.
+type ghostMobiledoc struct {
+	Cards    [][]json.RawMessage `json:"cards"`
+	Sections [][]json.RawMessage `json:"sections"`
+}
+
+// the settings of Ghost that have a counterpart in sonic
+var ghostSettingKeys = map[string]string{
+	"title":              property.BlogTitle.KeyValue,
+	"description":        property.SeoDescription.KeyValue,
+	"logo":               property.BlogLogo.KeyValue,
+	"icon":               property.BlogFavicon.KeyValue,
+	"codeinjection_head": property.CustomHead.KeyValue,
+}
+
+func (e *exportImport) ImportGhost(ctx context.Context, reader io.Reader) (*dto.ImportReport, error) {
+	export := &ghostExport{}
+	err := json.NewDecoder(reader).Decode(export)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid Ghost data").WithStatus(xerr.StatusBadRequest)
+	}
+	if export.Data == nil && len(export.DB) > 0 {
+		export = &export.DB[0]
+	}
+	if export.Data == nil {
+		return nil, xerr.BadParam.New("").WithMsg("not a Ghost data file").WithStatus(xerr.StatusBadRequest)
+	}
+	data := export.Data
+
+	importer := newContentImporter()
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		return importer.importGhost(txCtx, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(map[string]string)
+	for _, setting := range data.Settings {
+		key, ok := ghostSettingKeys[setting.Key]
+		if !ok || setting.Value == nil || *setting.Value == "" {
+			continue
+		}
+		options[key] = replaceGhostURL(*setting.Value)
+	}
+	importer.importOptions(ctx, e.OptionService, options)
+	return importer.report, nil
+}
+
+func (c *contentImporter) importGhost(ctx context.Context, data *ghostData) error {
+	tagIDs := make(map[string]int32, len(data.Tags))
+	for _, ghostTag := range data.Tags {
+		// internal tags are used by themes to style the post, they are not shown to visitors
+		if ghostTag.Visibility == "internal" || strings.HasPrefix(ghostTag.Name, "#") {
+			c.unmapped("tag %q: internal tags are not supported", ghostTag.Name)
+			continue
+		}
+		tagID, err := c.importTag(ctx, &entity.Tag{
+			Name:       ghostTag.Name,
+			Slug:       ghostTag.Slug,
+			Thumbnail:  replaceGhostURL(ghostTag.FeatureImage),
+			CreateTime: ghostTag.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		tagIDs[ghostTag.ID] = tagID
+	}
+
+	metaDescriptions := make(map[string]string, len(data.PostsMeta))
+	for _, postMeta := range data.PostsMeta {
+		metaDescriptions[postMeta.PostID] = postMeta.MetaDescription
+	}
+
+	postIDs := make(map[string]int32, len(data.Posts))
+	for _, ghostPost := range data.Posts {
+		post := c.convertGhostPost(&ghostPost)
+		if post.MetaDescription == "" {
+			post.MetaDescription = metaDescriptions[ghostPost.ID]
+		}
+		postID, err := c.importPost(ctx, post)
+		if err != nil {
+			return err
+		}
+		if postID != 0 {
+			postIDs[ghostPost.ID] = postID
+		}
+	}
+
+	for _, postTag := range data.PostsTags {
+		postID, tagID := postIDs[postTag.PostID], tagIDs[postTag.TagID]
+		if postID == 0 || tagID == 0 {
+			continue
+		}
+		if err := c.importPostTag(ctx, postID, tagID); err != nil {
+			return err
+		}
+	}
+
+	for _, setting := range data.Settings {
+		if setting.Value == nil {
+			continue
+		}
+		switch setting.Key {
+		case "navigation":
+			if err := c.importGhostNavigation(ctx, *setting.Value, ""); err != nil {
+				return err
+			}
+		case "secondary_navigation":
+			if err := c.importGhostNavigation(ctx, *setting.Value, "secondary"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(data.Users) > 0 {
+		for _, user := range data.Users[1:] {
+			c.unmapped("author %q: posts are assigned to the blog owner", user.Name)
+		}
+		return c.importAuthor(ctx, replaceGhostURL(data.Users[0].ProfileImage), data.Users[0].Bio)
+	}
+	return nil
+}
+
+func (c *contentImporter) convertGhostPost(ghostPost *ghostPost) *entity.Post {
+	post := &entity.Post{
+		Type:            consts.PostTypePost,
+		Title:           ghostPost.Title,
+		Slug:            ghostPost.Slug,
+		Thumbnail:       replaceGhostURL(ghostPost.FeatureImage),
+		Summary:         ghostPost.CustomExcerpt,
+		MetaDescription: ghostPost.MetaDescription,
+		CreateTime:      ghostPost.CreatedAt,
+		UpdateTime:      ghostPost.UpdatedAt,
+		EditTime:        ghostPost.UpdatedAt,
+		FormatContent:   replaceGhostURL(ghostPost.HTML),
+	}
+	if ghostPost.Type == "page" {
+		post.Type = consts.PostTypeSheet
+	}
+	if ghostPost.PublishedAt != nil {
+		post.CreateTime = *ghostPost.PublishedAt
+	}
+	if ghostPost.Featured {
+		post.TopPriority = 1
+	}
+	if ghostPost.CustomTemplate != "" {
+		c.unmapped("post %q: template %q of the Ghost theme is not imported", ghostPost.Title, ghostPost.CustomTemplate)
+	}
+
+	if markdown, ok := ghostMarkdown(ghostPost.Mobiledoc); ok {
+		post.EditorType = consts.EditorTypeMarkdown
+		post.OriginalContent = replaceGhostURL(markdown)
+	} else {
+		post.EditorType = consts.EditorTypeRichText
+		post.OriginalContent = post.FormatContent
+	}
+
+	switch ghostPost.Status {
+	case "published":
+		post.Status = consts.PostStatusPublished
+	case "scheduled":
+		post.Status = consts.PostStatusDraft
+		c.unmapped("post %q: scheduled posts are imported as drafts", ghostPost.Title)
+	default:
+		post.Status = consts.PostStatusDraft
+	}
+	if post.Status == consts.PostStatusPublished && ghostPost.Visibility != "" && ghostPost.Visibility != "public" {
+		post.Status = consts.PostStatusDraft
+		c.unmapped("post %q: visibility %q is not supported, imported as a draft", ghostPost.Title, ghostPost.Visibility)
+	}
+	return post
+}
+
+// ghostMarkdown returns the markdown source of a post written in a single markdown card.
+func ghostMarkdown(mobiledoc string) (string, bool) {
+	if mobiledoc == "" {
+		return "", false
+	}
+	doc := &ghostMobiledoc{}
+	if err := json.Unmarshal([]byte(mobiledoc), doc); err != nil {
+		return "", false
+	}
+	if len(doc.Cards) != 1 || len(doc.Sections) != 1 || len(doc.Cards[0]) != 2 {
+		return "", false
+	}
+	var name string
+	if err := json.Unmarshal(doc.Cards[0][0], &name); err != nil || (name != "markdown" && name != "card-markdown") {
+		return "", false
+	}
+	payload := struct {
+		Markdown string `json:"markdown"`
+	}{}
+	if err := json.Unmarshal(doc.Cards[0][1], &payload); err != nil {
+		return "", false
+	}
+	return payload.Markdown, true
+}
+
+func (c *contentImporter) importGhostNavigation(ctx context.Context, value, team string) error {
+	navigations := make([]ghostNavigation, 0)
+	if err := json.Unmarshal([]byte(value), &navigations); err != nil {
+		c.unmapped("navigation: %v", err)
+		return nil
+	}
+	for i, navigation := range navigations {
+		_, err := c.importMenu(ctx, &entity.Menu{
+			Name:     navigation.Label,
+			URL:      replaceGhostURL(navigation.URL),
+			Priority: int32(i),
+			Target:   "_self",
+			Team:     team,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceGhostURL(str string) string {
+	return strings.ReplaceAll(str, ghostURLPlaceholder, "")
+}
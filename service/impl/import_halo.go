@@ -0,0 +1,573 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// haloTime is a time exported by Halo, either milliseconds since epoch or a formatted string.
+type haloTime time.Time
+
+func (h *haloTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if ms, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+		*h = haloTime(time.UnixMilli(ms))
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05.000+0000"} {
+		if t, err := time.ParseInLocation(layout, str, time.Local); err == nil {
+			*h = haloTime(t)
+			return nil
+		}
+	}
+	return xerr.BadParam.New("time=%s", str).WithMsg("unknown time format")
+}
+
+func (h haloTime) Time() time.Time {
+	return time.Time(h)
+}
+
+func (h *haloTime) Ptr() *time.Time {
+	if h == nil || time.Time(*h).IsZero() {
+		return nil
+	}
+	t := time.Time(*h)
+	return &t
+}
+
+type haloPost struct {
+	ID              int32     `json:"id"`
+	Title           string    `json:"title"`
+	Status          string    `json:"status"`
+	Slug            string    `json:"slug"`
+	URL             string    `json:"url"`
+	EditorType      string    `json:"editorType"`
+	OriginalContent string    `json:"originalContent"`
+	FormatContent   string    `json:"formatContent"`
+	Summary         string    `json:"summary"`
+	Thumbnail       string    `json:"thumbnail"`
+	Visits          int64     `json:"visits"`
+	DisallowComment bool      `json:"disallowComment"`
+	Password        string    `json:"password"`
+	Template        string    `json:"template"`
+	TopPriority     int32     `json:"topPriority"`
+	Likes           int64     `json:"likes"`
+	WordCount       int64     `json:"wordCount"`
+	MetaKeywords    string    `json:"metaKeywords"`
+	MetaDescription string    `json:"metaDescription"`
+	EditTime        *haloTime `json:"editTime"`
+	CreateTime      haloTime  `json:"createTime"`
+	UpdateTime      *haloTime `json:"updateTime"`
+}
+
+type haloCategory struct {
+	ID          int32    `json:"id"`
+	Name        string   `json:"name"`
+	Slug        string   `json:"slug"`
+	Description string   `json:"description"`
+	Thumbnail   string   `json:"thumbnail"`
+	ParentID    int32    `json:"parentId"`
+	Password    string   `json:"password"`
+	Priority    int32    `json:"priority"`
+	CreateTime  haloTime `json:"createTime"`
+}
+
+type haloTag struct {
+	ID         int32    `json:"id"`
+	Name       string   `json:"name"`
+	Slug       string   `json:"slug"`
+	Thumbnail  string   `json:"thumbnail"`
+	Color      string   `json:"color"`
+	CreateTime haloTime `json:"createTime"`
+}
+
+type haloPostRelation struct {
+	PostID     int32 `json:"postId"`
+	CategoryID int32 `json:"categoryId"`
+	TagID      int32 `json:"tagId"`
+}
+
+type haloMeta struct {
+	PostID     int32    `json:"postId"`
+	Key        string   `json:"key"`
+	Value      string   `json:"value"`
+	CreateTime haloTime `json:"createTime"`
+}
+
+type haloComment struct {
+	ID                int32    `json:"id"`
+	Author            string   `json:"author"`
+	Email             string   `json:"email"`
+	IPAddress         string   `json:"ipAddress"`
+	AuthorURL         string   `json:"authorUrl"`
+	GravatarMd5       string   `json:"gravatarMd5"`
+	Content           string   `json:"content"`
+	Status            string   `json:"status"`
+	UserAgent         string   `json:"userAgent"`
+	IsAdmin           bool     `json:"isAdmin"`
+	AllowNotification bool     `json:"allowNotification"`
+	PostID            int32    `json:"postId"`
+	TopPriority       int32    `json:"topPriority"`
+	ParentID          int32    `json:"parentId"`
+	CreateTime        haloTime `json:"createTime"`
+}
+
+type haloJournal struct {
+	ID            int32    `json:"id"`
+	SourceContent string   `json:"sourceContent"`
+	Content       string   `json:"content"`
+	Likes         int64    `json:"likes"`
+	Type          string   `json:"type"`
+	CreateTime    haloTime `json:"createTime"`
+}
+
+type haloLink struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Logo        string   `json:"logo"`
+	Description string   `json:"description"`
+	Team        string   `json:"team"`
+	Priority    int32    `json:"priority"`
+	CreateTime  haloTime `json:"createTime"`
+}
+
+type haloMenu struct {
+	ID         int32    `json:"id"`
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Priority   int32    `json:"priority"`
+	Target     string   `json:"target"`
+	Icon       string   `json:"icon"`
+	ParentID   int32    `json:"parentId"`
+	Team       string   `json:"team"`
+	CreateTime haloTime `json:"createTime"`
+}
+
+type haloPhoto struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	TakeTime    *haloTime `json:"takeTime"`
+	Location    string    `json:"location"`
+	Thumbnail   string    `json:"thumbnail"`
+	URL         string    `json:"url"`
+	Team        string    `json:"team"`
+	Likes       int64     `json:"likes"`
+	CreateTime  haloTime  `json:"createTime"`
+}
+
+type haloOption struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type haloUser struct {
+	Username    string `json:"username"`
+	Nickname    string `json:"nickname"`
+	Email       string `json:"email"`
+	Avatar      string `json:"avatar"`
+	Description string `json:"description"`
+}
+
+// haloData is the data exported by "Backup - Export data" of Halo 1.x.
+type haloData struct {
+	Version         string             `json:"version"`
+	Posts           []haloPost         `json:"posts"`
+	Sheets          []haloPost         `json:"sheets"`
+	Categories      []haloCategory     `json:"categories"`
+	Tags            []haloTag          `json:"tags"`
+	PostCategories  []haloPostRelation `json:"post_categories"`
+	PostTags        []haloPostRelation `json:"post_tags"`
+	PostMetas       []haloMeta         `json:"post_metas"`
+	SheetMetas      []haloMeta         `json:"sheet_metas"`
+	PostComments    []haloComment      `json:"post_comments"`
+	SheetComments   []haloComment      `json:"sheet_comments"`
+	JournalComments []haloComment      `json:"journal_comments"`
+	Journals        []haloJournal      `json:"journals"`
+	Links           []haloLink         `json:"links"`
+	Menus           []haloMenu         `json:"menus"`
+	Photos          []haloPhoto        `json:"photos"`
+	Options         []haloOption       `json:"options"`
+	User            *haloUser          `json:"user"`
+
+	Attachments      []json.RawMessage `json:"attachments"`
+	CommentBlackList []json.RawMessage `json:"comment_black_list"`
+	ThemeSettings    []json.RawMessage `json:"theme_settings"`
+}
+
+func (e *exportImport) ImportHalo(ctx context.Context, reader io.Reader) (*dto.ImportReport, error) {
+	data := &haloData{}
+	err := json.NewDecoder(reader).Decode(data)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid Halo data").WithStatus(xerr.StatusBadRequest)
+	}
+	if data.Version == "" {
+		return nil, xerr.BadParam.New("").WithMsg("not a Halo data file").WithStatus(xerr.StatusBadRequest)
+	}
+
+	importer := newContentImporter()
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		return importer.importHalo(txCtx, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(map[string]string, len(data.Options))
+	for _, option := range data.Options {
+		options[option.Key] = option.Value
+	}
+	importer.importOptions(ctx, e.OptionService, options)
+
+	if len(data.Attachments) > 0 {
+		importer.unmapped("attachments: %d records are not imported, copy the upload directory of Halo instead", len(data.Attachments))
+	}
+	if len(data.CommentBlackList) > 0 {
+		importer.unmapped("comment_black_list: %d records are not imported", len(data.CommentBlackList))
+	}
+	if len(data.ThemeSettings) > 0 {
+		importer.unmapped("theme_settings: %d records are not imported", len(data.ThemeSettings))
+	}
+	return importer.report, nil
+}
+
+func (c *contentImporter) importHalo(ctx context.Context, data *haloData) error {
+	tagIDs := make(map[int32]int32, len(data.Tags))
+	for _, haloTag := range data.Tags {
+		tagID, err := c.importTag(ctx, &entity.Tag{
+			Name:       haloTag.Name,
+			Slug:       haloTag.Slug,
+			Thumbnail:  haloTag.Thumbnail,
+			Color:      haloTag.Color,
+			CreateTime: haloTag.CreateTime.Time(),
+		})
+		if err != nil {
+			return err
+		}
+		tagIDs[haloTag.ID] = tagID
+	}
+
+	categoryIDs, err := c.importHaloCategories(ctx, data.Categories)
+	if err != nil {
+		return err
+	}
+
+	postIDs, err := c.importHaloPosts(ctx, data.Posts, consts.PostTypePost)
+	if err != nil {
+		return err
+	}
+	sheetIDs, err := c.importHaloPosts(ctx, data.Sheets, consts.PostTypeSheet)
+	if err != nil {
+		return err
+	}
+
+	for _, postCategory := range data.PostCategories {
+		postID, categoryID := postIDs[postCategory.PostID], categoryIDs[postCategory.CategoryID]
+		if postID == 0 || categoryID == 0 {
+			continue
+		}
+		if err := c.importPostCategory(ctx, postID, categoryID); err != nil {
+			return err
+		}
+	}
+	for _, postTag := range data.PostTags {
+		postID, tagID := postIDs[postTag.PostID], tagIDs[postTag.TagID]
+		if postID == 0 || tagID == 0 {
+			continue
+		}
+		if err := c.importPostTag(ctx, postID, tagID); err != nil {
+			return err
+		}
+	}
+	if err := c.importHaloMetas(ctx, data.PostMetas, postIDs, consts.MetaTypePost); err != nil {
+		return err
+	}
+	if err := c.importHaloMetas(ctx, data.SheetMetas, sheetIDs, consts.MetaTypeSheet); err != nil {
+		return err
+	}
+
+	journalIDs := make(map[int32]int32, len(data.Journals))
+	for _, haloJournal := range data.Journals {
+		journalType := consts.JournalTypePublic
+		if haloJournal.Type == "INTIMATE" {
+			journalType = consts.JournalTypeIntimate
+		}
+		journalID, err := c.importJournal(ctx, &entity.Journal{
+			SourceContent: haloJournal.SourceContent,
+			Content:       haloJournal.Content,
+			Likes:         haloJournal.Likes,
+			Type:          journalType,
+			CreateTime:    haloJournal.CreateTime.Time(),
+		})
+		if err != nil {
+			return err
+		}
+		journalIDs[haloJournal.ID] = journalID
+	}
+
+	if err := c.importHaloComments(ctx, data.PostComments, postIDs, consts.CommentTypePost, "post"); err != nil {
+		return err
+	}
+	if err := c.importHaloComments(ctx, data.SheetComments, sheetIDs, consts.CommentTypeSheet, "sheet"); err != nil {
+		return err
+	}
+	if err := c.importHaloComments(ctx, data.JournalComments, journalIDs, consts.CommentTypeJournal, "journal"); err != nil {
+		return err
+	}
+
+	if err := c.importHaloMenus(ctx, data.Menus); err != nil {
+		return err
+	}
+	for _, haloLink := range data.Links {
+		err := c.importLink(ctx, &entity.Link{
+			Name:        haloLink.Name,
+			URL:         haloLink.URL,
+			Logo:        haloLink.Logo,
+			Description: haloLink.Description,
+			Team:        haloLink.Team,
+			Priority:    haloLink.Priority,
+			CreateTime:  haloLink.CreateTime.Time(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, haloPhoto := range data.Photos {
+		err := c.importPhoto(ctx, &entity.Photo{
+			Name:        haloPhoto.Name,
+			Description: haloPhoto.Description,
+			TakeTime:    haloPhoto.TakeTime.Ptr(),
+			Location:    haloPhoto.Location,
+			Thumbnail:   haloPhoto.Thumbnail,
+			URL:         haloPhoto.URL,
+			Team:        haloPhoto.Team,
+			Likes:       haloPhoto.Likes,
+			CreateTime:  haloPhoto.CreateTime.Time(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if data.User != nil {
+		return c.importAuthor(ctx, data.User.Avatar, data.User.Description)
+	}
+	return nil
+}
+
+func (c *contentImporter) importHaloCategories(ctx context.Context, haloCategories []haloCategory) (map[int32]int32, error) {
+	categoryIDs := make(map[int32]int32, len(haloCategories))
+	created := make(map[int32]int32)
+	for _, haloCategory := range haloCategories {
+		count := c.report.Imported["categories"]
+		categoryID, err := c.importCategory(ctx, &entity.Category{
+			Name:        haloCategory.Name,
+			Slug:        haloCategory.Slug,
+			Description: haloCategory.Description,
+			Thumbnail:   haloCategory.Thumbnail,
+			Password:    haloCategory.Password,
+			Priority:    haloCategory.Priority,
+			CreateTime:  haloCategory.CreateTime.Time(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		categoryIDs[haloCategory.ID] = categoryID
+		if c.report.Imported["categories"] > count && haloCategory.ParentID != 0 {
+			created[categoryID] = haloCategory.ParentID
+		}
+	}
+	// parents may be exported after their children
+	categoryDAL := dal.GetQueryByCtx(ctx).Category
+	for categoryID, haloParentID := range created {
+		parentID, ok := categoryIDs[haloParentID]
+		if !ok {
+			c.unmapped("category %d: parent category %d not found", categoryID, haloParentID)
+			continue
+		}
+		_, err := categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Eq(categoryID)).UpdateColumnSimple(categoryDAL.ParentID.Value(parentID))
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+	}
+	return categoryIDs, nil
+}
+
+func (c *contentImporter) importHaloPosts(ctx context.Context, haloPosts []haloPost, postType consts.PostType) (map[int32]int32, error) {
+	postIDs := make(map[int32]int32, len(haloPosts))
+	for _, haloPost := range haloPosts {
+		status, err := consts.PostStatusFromString(haloPost.Status)
+		if err != nil {
+			c.unmapped("post %q: unknown status %q, imported as draft", haloPost.Title, haloPost.Status)
+		}
+		editorType := consts.EditorTypeMarkdown
+		if haloPost.EditorType == "RICHTEXT" {
+			editorType = consts.EditorTypeRichText
+		}
+		slug := haloPost.Slug
+		if slug == "" {
+			// the slug is named url before Halo 1.4
+			slug = haloPost.URL
+		}
+		post := &entity.Post{
+			Type:            postType,
+			Title:           haloPost.Title,
+			Status:          status,
+			Slug:            slug,
+			EditorType:      editorType,
+			OriginalContent: haloPost.OriginalContent,
+			FormatContent:   haloPost.FormatContent,
+			Summary:         haloPost.Summary,
+			Thumbnail:       haloPost.Thumbnail,
+			Visits:          haloPost.Visits,
+			DisallowComment: haloPost.DisallowComment,
+			Password:        haloPost.Password,
+			Template:        haloPost.Template,
+			TopPriority:     haloPost.TopPriority,
+			Likes:           haloPost.Likes,
+			WordCount:       haloPost.WordCount,
+			MetaKeywords:    haloPost.MetaKeywords,
+			MetaDescription: haloPost.MetaDescription,
+			EditTime:        haloPost.EditTime.Ptr(),
+			CreateTime:      haloPost.CreateTime.Time(),
+			UpdateTime:      haloPost.UpdateTime.Ptr(),
+		}
+		postID, err := c.importPost(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		if postID != 0 {
+			postIDs[haloPost.ID] = postID
+		}
+	}
+	return postIDs, nil
+}
+
+func (c *contentImporter) importHaloMetas(ctx context.Context, haloMetas []haloMeta, postIDs map[int32]int32, metaType consts.MetaType) error {
+	for _, haloMeta := range haloMetas {
+		postID, ok := postIDs[haloMeta.PostID]
+		if !ok {
+			continue
+		}
+		err := c.importMeta(ctx, &entity.Meta{
+			Type:       metaType,
+			PostID:     postID,
+			MetaKey:    haloMeta.Key,
+			MetaValue:  haloMeta.Value,
+			CreateTime: haloMeta.CreateTime.Time(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importHaloComments imports the comments of the imported content, parents are imported before their replies.
+func (c *contentImporter) importHaloComments(ctx context.Context, haloComments []haloComment, contentIDs map[int32]int32, commentType consts.CommentType, contentName string) error {
+	sort.Slice(haloComments, func(i, j int) bool {
+		return haloComments[i].ID < haloComments[j].ID
+	})
+	commentIDs := make(map[int32]int32, len(haloComments))
+	skipped := 0
+	for _, haloComment := range haloComments {
+		postID, ok := contentIDs[haloComment.PostID]
+		if !ok {
+			skipped++
+			continue
+		}
+		status, err := consts.CommentStatusFromString(haloComment.Status)
+		if err != nil {
+			status = consts.CommentStatusAuditing
+		}
+		parentID := int32(0)
+		if haloComment.ParentID != 0 {
+			if parentID, ok = commentIDs[haloComment.ParentID]; !ok {
+				skipped++
+				continue
+			}
+		}
+		comment := &entity.Comment{
+			Type:              commentType,
+			Author:            haloComment.Author,
+			Email:             haloComment.Email,
+			IPAddress:         haloComment.IPAddress,
+			AuthorURL:         haloComment.AuthorURL,
+			GravatarMd5:       haloComment.GravatarMd5,
+			Content:           haloComment.Content,
+			Status:            status,
+			UserAgent:         haloComment.UserAgent,
+			IsAdmin:           haloComment.IsAdmin,
+			AllowNotification: haloComment.AllowNotification,
+			PostID:            postID,
+			TopPriority:       haloComment.TopPriority,
+			ParentID:          parentID,
+			CreateTime:        haloComment.CreateTime.Time(),
+		}
+		if err := c.importComment(ctx, comment); err != nil {
+			return err
+		}
+		commentIDs[haloComment.ID] = comment.ID
+	}
+	if skipped > 0 {
+		c.unmapped("%d %s comments: the commented content or the parent comment is not imported", skipped, contentName)
+	}
+	return nil
+}
+
+func (c *contentImporter) importHaloMenus(ctx context.Context, haloMenus []haloMenu) error {
+	sort.Slice(haloMenus, func(i, j int) bool {
+		return haloMenus[i].ID < haloMenus[j].ID
+	})
+	menuIDs := make(map[int32]int32, len(haloMenus))
+	for _, haloMenu := range haloMenus {
+		menuID, err := c.importMenu(ctx, &entity.Menu{
+			Name:       haloMenu.Name,
+			URL:        haloMenu.URL,
+			Priority:   haloMenu.Priority,
+			Target:     haloMenu.Target,
+			Icon:       haloMenu.Icon,
+			ParentID:   menuIDs[haloMenu.ParentID],
+			Team:       haloMenu.Team,
+			CreateTime: haloMenu.CreateTime.Time(),
+		})
+		if err != nil {
+			return err
+		}
+		menuIDs[haloMenu.ID] = menuID
+	}
+	return nil
+}
+
+// importAuthor fills the profile of the blog owner, sonic has only one user so the author itself is not imported.
+func (c *contentImporter) importAuthor(ctx context.Context, avatar, description string) error {
+	userDAL := dal.GetQueryByCtx(ctx).User
+	user, err := userDAL.WithContext(ctx).Order(userDAL.ID).First()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if user.Avatar == "" && avatar != "" {
+		user.Avatar = avatar
+	}
+	if user.Description == "" && description != "" {
+		user.Description = description
+	}
+	_, err = userDAL.WithContext(ctx).Where(userDAL.ID.Eq(user.ID)).UpdateSimple(userDAL.Avatar.Value(user.Avatar), userDAL.Description.Value(user.Description))
+	return WrapDBErr(err)
+}
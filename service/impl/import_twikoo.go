@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// twikooComment is a record of the JSON file exported by the admin panel of Twikoo, the comment is HTML.
+type twikooComment struct {
+	ID      foreignID   `json:"_id"`
+	Nick    string      `json:"nick"`
+	Mail    string      `json:"mail"`
+	Link    string      `json:"link"`
+	UA      string      `json:"ua"`
+	IP      string      `json:"ip"`
+	Master  bool        `json:"master"`
+	URL     string      `json:"url"`
+	Href    string      `json:"href"`
+	Comment string      `json:"comment"`
+	PID     foreignID   `json:"pid"`
+	IsSpam  bool        `json:"isSpam"`
+	Top     bool        `json:"top"`
+	Created foreignTime `json:"created"`
+}
+
+func (e *exportImport) ImportTwikoo(ctx context.Context, reader io.Reader) (*dto.ImportReport, error) {
+	twikooComments := make([]twikooComment, 0)
+	err := json.NewDecoder(reader).Decode(&twikooComments)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid Twikoo data").WithStatus(xerr.StatusBadRequest)
+	}
+	resolver, err := e.newCommentThreadResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]*foreignComment, 0, len(twikooComments))
+	for _, twikooComment := range twikooComments {
+		comment := &foreignComment{
+			ID:         string(twikooComment.ID),
+			ParentID:   string(twikooComment.PID),
+			ThreadURLs: []string{twikooComment.URL, twikooComment.Href},
+			Author:     twikooComment.Nick,
+			Email:      twikooComment.Mail,
+			AuthorURL:  twikooComment.Link,
+			IPAddress:  twikooComment.IP,
+			UserAgent:  twikooComment.UA,
+			Content:    commentHTMLToMarkdown(twikooComment.Comment),
+			Status:     consts.CommentStatusPublished,
+			IsAdmin:    twikooComment.Master,
+			CreateTime: time.Time(twikooComment.Created),
+		}
+		// Twikoo keeps the comments waiting for review as spam
+		if twikooComment.IsSpam {
+			comment.Status = consts.CommentStatusAuditing
+		}
+		if twikooComment.Top {
+			comment.TopPriority = 1
+		}
+		comments = append(comments, comment)
+	}
+	importer := newContentImporter()
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		return importer.importForeignComments(txCtx, resolver, comments)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return importer.report, nil
+}
@@ -0,0 +1,91 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// walineComment is a comment of the JSON file exported by the admin panel of Waline, the comment is markdown.
+type walineComment struct {
+	ObjectID   foreignID       `json:"objectId"`
+	Nick       string          `json:"nick"`
+	Mail       string          `json:"mail"`
+	Link       string          `json:"link"`
+	UA         string          `json:"ua"`
+	IP         string          `json:"ip"`
+	URL        string          `json:"url"`
+	Comment    string          `json:"comment"`
+	PID        foreignID       `json:"pid"`
+	Status     string          `json:"status"`
+	Sticky     json.RawMessage `json:"sticky"`
+	InsertedAt *foreignTime    `json:"insertedAt"`
+	CreatedAt  *foreignTime    `json:"createdAt"`
+}
+
+type walineExport struct {
+	Type string `json:"type"`
+	Data struct {
+		Comment []walineComment `json:"Comment"`
+	} `json:"data"`
+}
+
+func (e *exportImport) ImportWaline(ctx context.Context, reader io.Reader) (*dto.ImportReport, error) {
+	export := &walineExport{}
+	err := json.NewDecoder(reader).Decode(export)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid Waline data").WithStatus(xerr.StatusBadRequest)
+	}
+	if export.Type != "waline" {
+		return nil, xerr.BadParam.New("").WithMsg("not a Waline data file").WithStatus(xerr.StatusBadRequest)
+	}
+	resolver, err := e.newCommentThreadResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	importer := newContentImporter()
+	comments := make([]*foreignComment, 0, len(export.Data.Comment))
+	for _, walineComment := range export.Data.Comment {
+		comment := &foreignComment{
+			ID:         string(walineComment.ObjectID),
+			ParentID:   string(walineComment.PID),
+			ThreadURLs: []string{walineComment.URL},
+			Author:     walineComment.Nick,
+			Email:      walineComment.Mail,
+			AuthorURL:  walineComment.Link,
+			IPAddress:  walineComment.IP,
+			UserAgent:  walineComment.UA,
+			Content:    walineComment.Comment,
+		}
+		if walineComment.InsertedAt != nil {
+			comment.CreateTime = time.Time(*walineComment.InsertedAt)
+		} else if walineComment.CreatedAt != nil {
+			comment.CreateTime = time.Time(*walineComment.CreatedAt)
+		}
+		switch walineComment.Status {
+		case "approved", "":
+			comment.Status = consts.CommentStatusPublished
+		case "spam":
+			comment.Status = consts.CommentStatusSpam
+		default:
+			comment.Status = consts.CommentStatusAuditing
+		}
+		if sticky := string(walineComment.Sticky); sticky == "true" || sticky == "1" {
+			comment.TopPriority = 1
+		}
+		comments = append(comments, comment)
+	}
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		return importer.importForeignComments(txCtx, resolver, comments)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return importer.report, nil
+}
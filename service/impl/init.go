@@ -12,6 +12,15 @@ func init() {
 		NewAuthenticateService,
 		NewBackUpService,
 		NewBaseCommentService,
+		NewCommentBlackService,
+		NewCommentSpamService,
+		NewCommentSubscriptionService,
+		NewCommentRevisionService,
+		NewReactionService,
+		NewWebmentionService,
+		NewActivityPubService,
+		NewSitemapService,
+		NewSeoService,
 		NewBasePostService,
 		NewCategoryService,
 		NewEmailService,
@@ -25,6 +34,7 @@ func init() {
 		NewBaseMFAService,
 		NewTwoFactorTOTPMFAService,
 		NewOneTimeTokenService,
+		NewCaptchaService,
 		NewOptionService,
 		NewClientOptionService,
 		NewPhotoService,
@@ -38,7 +48,12 @@ func init() {
 		NewTagService,
 		NewThemeService,
 		NewUserService,
+		NewWebhookService,
 		NewExportImport,
+		NewHTTPClient,
+		NewLinkCheckService,
+		NewResponsiveImageService,
+		NewShortcodeService,
 		storage.NewFileStorageComposite,
 	)
 }
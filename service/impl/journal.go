@@ -14,6 +14,7 @@ import (
 
 type journalServiceImpl struct {
 	JournalCommentService service.JournalCommentService
+	ReactionService       service.ReactionService
 }
 
 func (*journalServiceImpl) Page(ctx context.Context, page param.Page, sort *param.Sort) ([]*entity.Journal, int64, error) {
@@ -33,9 +34,10 @@ func (*journalServiceImpl) Page(ctx context.Context, page param.Page, sort *para
 	return journals, totalCount, nil
 }
 
-func NewJournalService(journalCommentService service.JournalCommentService) service.JournalService {
+func NewJournalService(journalCommentService service.JournalCommentService, reactionService service.ReactionService) service.JournalService {
 	return &journalServiceImpl{
 		JournalCommentService: journalCommentService,
+		ReactionService:       reactionService,
 	}
 }
 
@@ -59,10 +61,15 @@ func (j *journalServiceImpl) ConvertToWithCommentDTOList(ctx context.Context, jo
 	if err != nil {
 		return nil, err
 	}
+	reactionMap, err := j.ReactionService.CountByTargetIDs(ctx, consts.ReactionTargetJournal, journalIDs)
+	if err != nil {
+		return nil, err
+	}
 	result := make([]*dto.JournalWithComment, 0, len(journals))
 	for _, journal := range journals {
 		journalWithCommentCount := &dto.JournalWithComment{
-			Journal: *j.ConvertToDTO(journal),
+			Journal:   *j.ConvertToDTO(journal),
+			Reactions: reactionMap[journal.ID],
 		}
 		if commentCount, ok := commentCountMap[journal.ID]; ok {
 			journalWithCommentCount.CommentCount = commentCount
@@ -165,15 +172,3 @@ func (j *journalServiceImpl) Count(ctx context.Context) (int64, error) {
 	}
 	return count, nil
 }
-
-func (*journalServiceImpl) IncreaseLike(ctx context.Context, journalID int32) error {
-	journalDAL := dal.GetQueryByCtx(ctx).Journal
-	updateResult, err := journalDAL.WithContext(ctx).Where(journalDAL.ID.Eq(journalID)).UpdateSimple(journalDAL.Likes.Add(1))
-	if err != nil {
-		return WrapDBErr(err)
-	}
-	if updateResult.RowsAffected != 1 {
-		return xerr.NoType.New("increase journal like failed id=%v", journalID).WithMsg("increase like failed").WithStatus(xerr.StatusInternalServerError)
-	}
-	return nil
-}
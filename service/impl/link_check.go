@@ -0,0 +1,528 @@
+package impl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/time/rate"
+
+	"github.com/go-sonic/sonic/config"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	// linkCheckConcurrency is the max number of the external links checked at the same time
+	linkCheckConcurrency = 4
+	// linkCheckInterval is the min interval between two requests to external sites
+	linkCheckInterval = 200 * time.Millisecond
+	linkCheckTimeout  = 15 * time.Second
+)
+
+var pageSuffixRegexp = regexp.MustCompile(`/page/\d+$`)
+
+type linkCheckServiceImpl struct {
+	Config          *config.Config
+	OptionService   service.OptionService
+	BasePostService service.BasePostService
+	HTTPClient      service.HTTPClient
+
+	mutex  sync.Mutex
+	report *dto.LinkCheckReport
+}
+
+func NewLinkCheckService(config *config.Config,
+	optionService service.OptionService,
+	basePostService service.BasePostService,
+	httpClient service.HTTPClient,
+) service.LinkCheckService {
+	return &linkCheckServiceImpl{
+		Config:          config,
+		OptionService:   optionService,
+		BasePostService: basePostService,
+		HTTPClient:      httpClient,
+		report: &dto.LinkCheckReport{
+			Status:      consts.LinkCheckStatusIdle,
+			BrokenLinks: make([]*dto.BrokenLink, 0),
+		},
+	}
+}
+
+// checkedLink is a link found in the content, it may be referenced by many posts.
+type checkedLink struct {
+	URL        string
+	Internal   bool
+	Path       string
+	RawQuery   string
+	StatusCode int
+	Reason     string
+	Sources    []*dto.LinkSource
+}
+
+func (l *linkCheckServiceImpl) Start(ctx context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.report.Status == consts.LinkCheckStatusRunning {
+		return xerr.BadParam.New("").WithMsg("link check is running").WithStatus(xerr.StatusBadRequest)
+	}
+	l.report = &dto.LinkCheckReport{
+		Status:      consts.LinkCheckStatusRunning,
+		StartTime:   time.Now().UnixMilli(),
+		BrokenLinks: make([]*dto.BrokenLink, 0),
+	}
+	// the request context is canceled once the response is written
+	go l.run(context.Background())
+	return nil
+}
+
+func (l *linkCheckServiceImpl) GetReport(ctx context.Context) *dto.LinkCheckReport {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	report := *l.report
+	report.BrokenLinks = append(make([]*dto.BrokenLink, 0, len(l.report.BrokenLinks)), l.report.BrokenLinks...)
+	return &report
+}
+
+func (l *linkCheckServiceImpl) run(ctx context.Context) {
+	brokenLinks, err := l.check(ctx)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.report.FinishTime = time.Now().UnixMilli()
+	if err != nil {
+		log.CtxErrorf(ctx, "link check err=%v", err)
+		l.report.Status = consts.LinkCheckStatusFailed
+		return
+	}
+	l.report.Status = consts.LinkCheckStatusFinished
+	l.report.BrokenLinks = brokenLinks
+	l.report.BrokenCount = len(brokenLinks)
+}
+
+func (l *linkCheckServiceImpl) check(ctx context.Context) ([]*dto.BrokenLink, error) {
+	blogBaseURL, err := l.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := url.Parse(blogBaseURL + "/")
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid blog url")
+	}
+	routes, err := l.loadSiteRoutes(ctx, blogBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	links, err := l.collectLinks(ctx, baseURL, routes)
+	if err != nil {
+		return nil, err
+	}
+
+	externalLinks := make([]*checkedLink, 0)
+	for _, link := range links {
+		if link.Internal {
+			link.Reason = routes.resolve(link.Path, link.RawQuery)
+			l.increaseChecked()
+		} else {
+			externalLinks = append(externalLinks, link)
+		}
+	}
+	l.checkExternalLinks(ctx, externalLinks)
+
+	brokenLinks := make([]*dto.BrokenLink, 0)
+	for _, link := range links {
+		if link.Reason == "" {
+			continue
+		}
+		brokenLinks = append(brokenLinks, &dto.BrokenLink{
+			URL:        link.URL,
+			Internal:   link.Internal,
+			StatusCode: link.StatusCode,
+			Reason:     link.Reason,
+			Sources:    link.Sources,
+		})
+	}
+	sort.Slice(brokenLinks, func(i, j int) bool {
+		return brokenLinks[i].URL < brokenLinks[j].URL
+	})
+	return brokenLinks, nil
+}
+
+func (l *linkCheckServiceImpl) increaseChecked() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.report.CheckedCount++
+}
+
+// collectLinks extracts the links from the content of posts, sheets and journals.
+func (l *linkCheckServiceImpl) collectLinks(ctx context.Context, baseURL *url.URL, routes *siteRoutes) (map[string]*checkedLink, error) {
+	links := make(map[string]*checkedLink)
+	addLinks := func(source *dto.LinkSource, pageURL *url.URL, content string) {
+		added := make(map[string]struct{})
+		for _, ref := range extractLinks(content) {
+			link, ok := newCheckedLink(baseURL, pageURL, ref.URL)
+			if !ok {
+				continue
+			}
+			if _, ok := added[ref.Element+link.URL]; ok {
+				continue
+			}
+			added[ref.Element+link.URL] = struct{}{}
+			if existed, ok := links[link.URL]; ok {
+				link = existed
+			} else {
+				links[link.URL] = link
+			}
+			s := *source
+			s.Element = ref.Element
+			link.Sources = append(link.Sources, &s)
+		}
+	}
+
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.Status.Neq(consts.PostStatusRecycle)).Order(postDAL.ID).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, post := range posts {
+		pageURL := baseURL
+		if fullPath, err := l.BasePostService.BuildFullPath(ctx, post); err == nil {
+			if u, err := baseURL.Parse(fullPath); err == nil {
+				pageURL = u
+			}
+		}
+		sourceType := "post"
+		if post.Type == consts.PostTypeSheet {
+			sourceType = "sheet"
+		}
+		addLinks(&dto.LinkSource{Type: sourceType, ID: post.ID, Title: post.Title}, pageURL, post.FormatContent)
+	}
+
+	journalDAL := dal.GetQueryByCtx(ctx).Journal
+	journals, err := journalDAL.WithContext(ctx).Order(journalDAL.ID).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	journalURL, err := baseURL.Parse(strings.TrimPrefix(routes.journalPath, "/"))
+	if err != nil {
+		journalURL = baseURL
+	}
+	for _, journal := range journals {
+		addLinks(&dto.LinkSource{Type: "journal", ID: journal.ID}, journalURL, journal.Content)
+	}
+	return links, nil
+}
+
+// newCheckedLink resolves the link found in the page, links such as mailto: or anchors in the same page are ignored.
+func newCheckedLink(baseURL, pageURL *url.URL, ref string) (*checkedLink, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+	u, err := pageURL.Parse(ref)
+	if err != nil {
+		return &checkedLink{URL: ref, Reason: "invalid url"}, true
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, false
+	}
+	u.Fragment = ""
+	link := &checkedLink{
+		URL:     u.String(),
+		Sources: make([]*dto.LinkSource, 0),
+	}
+	if strings.EqualFold(u.Host, baseURL.Host) && strings.HasPrefix(u.Path, baseURL.Path) {
+		link.Internal = true
+		link.Path = "/" + strings.TrimPrefix(u.Path, baseURL.Path)
+		link.RawQuery = u.RawQuery
+	}
+	return link, true
+}
+
+type linkRef struct {
+	Element string
+	URL     string
+}
+
+func extractLinks(content string) []linkRef {
+	refs := make([]linkRef, 0)
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return refs
+		}
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+		token := tokenizer.Token()
+		var key string
+		switch token.DataAtom {
+		case atom.A:
+			key = "href"
+		case atom.Img:
+			key = "src"
+		default:
+			continue
+		}
+		for _, attr := range token.Attr {
+			if attr.Key == key {
+				refs = append(refs, linkRef{Element: token.Data, URL: attr.Val})
+			}
+		}
+	}
+}
+
+func (l *linkCheckServiceImpl) checkExternalLinks(ctx context.Context, links []*checkedLink) {
+	limiter := rate.NewLimiter(rate.Every(linkCheckInterval), 1)
+	linkChan := make(chan *checkedLink)
+	wg := sync.WaitGroup{}
+	for i := 0; i < linkCheckConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range linkChan {
+				if link.Reason == "" {
+					link.StatusCode, link.Reason = l.checkExternalLink(ctx, limiter, link.URL)
+				}
+				l.increaseChecked()
+			}
+		}()
+	}
+	for _, link := range links {
+		linkChan <- link
+	}
+	close(linkChan)
+	wg.Wait()
+}
+
+// checkExternalLink returns the reason why the link is broken, or empty if the link works.
+func (l *linkCheckServiceImpl) checkExternalLink(ctx context.Context, limiter *rate.Limiter, rawURL string) (int, string) {
+	statusCode, err := l.request(ctx, limiter, http.MethodHead, rawURL)
+	if err == nil && statusCode < http.StatusBadRequest {
+		return statusCode, ""
+	}
+	// some sites do not support HEAD requests
+	statusCode, err = l.request(ctx, limiter, http.MethodGet, rawURL)
+	if err != nil {
+		return 0, err.Error()
+	}
+	if statusCode >= http.StatusBadRequest {
+		return statusCode, http.StatusText(statusCode)
+	}
+	return statusCode, ""
+}
+
+func (l *linkCheckServiceImpl) request(ctx context.Context, limiter *rate.Limiter, method, rawURL string) (int, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, linkCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion+" link-checker")
+	resp, err := l.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	return resp.StatusCode, nil
+}
+
+// siteRoutes contains the pages of the blog, it is used to check internal links without requesting the blog itself.
+type siteRoutes struct {
+	config        *config.Config
+	archivePath   string
+	categoryPath  string
+	tagPath       string
+	journalPath   string
+	pathSuffix    string
+	fixedPaths    map[string]struct{}
+	pagedPaths    map[string]struct{}
+	contentPaths  map[string]consts.PostStatus
+	postSlugs     map[string]consts.PostStatus
+	postIDs       map[string]consts.PostStatus
+	categorySlugs map[string]struct{}
+	tagSlugs      map[string]struct{}
+}
+
+func (l *linkCheckServiceImpl) loadSiteRoutes(ctx context.Context, blogBaseURL string) (*siteRoutes, error) {
+	routes := &siteRoutes{
+		config:        l.Config,
+		contentPaths:  make(map[string]consts.PostStatus),
+		postSlugs:     make(map[string]consts.PostStatus),
+		postIDs:       make(map[string]consts.PostStatus),
+		categorySlugs: make(map[string]struct{}),
+		tagSlugs:      make(map[string]struct{}),
+	}
+	prefixes := make([]string, 0)
+	for _, getPrefix := range []func(context.Context) (string, error){
+		l.OptionService.GetArchivePrefix,
+		l.OptionService.GetCategoryPrefix,
+		l.OptionService.GetTagPrefix,
+		l.OptionService.GetJournalPrefix,
+		l.OptionService.GetPhotoPrefix,
+		l.OptionService.GetLinkPrefix,
+	} {
+		prefix, err := getPrefix(ctx)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, "/"+strings.Trim(prefix, "/"))
+	}
+	routes.archivePath, routes.categoryPath, routes.tagPath, routes.journalPath = prefixes[0], prefixes[1], prefixes[2], prefixes[3]
+	pathSuffix, err := l.OptionService.GetPathSuffix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routes.pathSuffix = pathSuffix
+
+	routes.fixedPaths = map[string]struct{}{
+		"/": {}, "/robots.txt": {}, "/atom": {}, "/atom.xml": {}, "/rss": {}, "/rss.xml": {}, "/feed": {}, "/feed.xml": {},
+		"/sitemap.xml": {}, "/sitemap.html": {}, "/version": {}, "/logo": {}, "/favicon": {}, "/search": {},
+	}
+	for _, prefix := range prefixes {
+		routes.fixedPaths[prefix] = struct{}{}
+	}
+	routes.pagedPaths = map[string]struct{}{
+		"": {}, "/search": {}, routes.archivePath: {}, routes.journalPath: {}, prefixes[4]: {},
+	}
+
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, post := range posts {
+		fullPath, err := l.BasePostService.BuildFullPath(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		fullPath = strings.TrimPrefix(fullPath, blogBaseURL)
+		routes.contentPaths[strings.TrimSuffix(fullPath, "/")] = post.Status
+		if post.Type == consts.PostTypePost {
+			routes.postSlugs[post.Slug] = post.Status
+			routes.postIDs[strconv.Itoa(int(post.ID))] = post.Status
+		}
+	}
+
+	categoryDAL := dal.GetQueryByCtx(ctx).Category
+	categories, err := categoryDAL.WithContext(ctx).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, category := range categories {
+		routes.categorySlugs[category.Slug] = struct{}{}
+	}
+	tagDAL := dal.GetQueryByCtx(ctx).Tag
+	tags, err := tagDAL.WithContext(ctx).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, tag := range tags {
+		routes.tagSlugs[tag.Slug] = struct{}{}
+	}
+	return routes, nil
+}
+
+// resolve returns the reason why the internal link is broken, or empty if the page exists.
+func (s *siteRoutes) resolve(path, rawQuery string) string {
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	for prefix, dir := range map[string]string{
+		"/" + consts.SonicUploadDir + "/": s.config.Sonic.UploadDir,
+		"/themes/":                        s.config.Sonic.ThemeDir,
+	} {
+		relativePath, ok := strings.CutPrefix(path, prefix)
+		if !ok {
+			continue
+		}
+		root := filepath.Clean(dir)
+		filePath := filepath.Join(root, filepath.FromSlash(relativePath))
+		if !strings.HasPrefix(filePath, root+string(os.PathSeparator)) {
+			return "file not found"
+		}
+		if _, err := os.Stat(filePath); err != nil {
+			return "file not found"
+		}
+		return ""
+	}
+	for _, prefix := range []string{"/css/", "/js/", "/images/", "/" + strings.Trim(s.config.Sonic.AdminURLPath, "/") + "/"} {
+		if strings.HasPrefix(path+"/", prefix) {
+			return ""
+		}
+	}
+
+	if path == "/" && rawQuery != "" {
+		if query, err := url.ParseQuery(rawQuery); err == nil && query.Get("p") != "" {
+			return contentStatusReason(s.postIDs, query.Get("p"))
+		}
+	}
+	if _, ok := s.fixedPaths[path]; ok {
+		return ""
+	}
+	if status, ok := s.contentPaths[path]; ok {
+		return postStatusReason(status)
+	}
+	if slug, ok := strings.CutPrefix(path, s.archivePath+"/"); ok {
+		return contentStatusReason(s.postSlugs, strings.TrimSuffix(slug, s.pathSuffix))
+	}
+
+	if pageSuffixRegexp.MatchString(path) {
+		path = pageSuffixRegexp.ReplaceAllString(path, "")
+		if _, ok := s.pagedPaths[path]; ok {
+			return ""
+		}
+	}
+	for _, prefix := range []string{s.categoryPath + "/", "/feed/categories/", "/atom/categories/"} {
+		if slug, ok := strings.CutPrefix(path, prefix); ok {
+			if _, ok := s.categorySlugs[slug]; ok {
+				return ""
+			}
+			return "category not found"
+		}
+	}
+	if slug, ok := strings.CutPrefix(path, s.tagPath+"/"); ok {
+		if _, ok := s.tagSlugs[slug]; ok {
+			return ""
+		}
+		return "tag not found"
+	}
+	return "page not found"
+}
+
+func contentStatusReason(statuses map[string]consts.PostStatus, key string) string {
+	status, ok := statuses[key]
+	if !ok {
+		return "post not found"
+	}
+	return postStatusReason(status)
+}
+
+func postStatusReason(status consts.PostStatus) string {
+	if status == consts.PostStatusDraft || status == consts.PostStatusRecycle {
+		return "post not published"
+	}
+	return ""
+}
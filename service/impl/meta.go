@@ -2,17 +2,27 @@ package impl
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
 	"github.com/go-sonic/sonic/model/dto"
 	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
 	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
 )
 
-type metaServiceImpl struct{}
+type metaServiceImpl struct {
+	OptionService service.OptionService
+	ThemeService  service.ThemeService
+}
 
-func NewMetaService() service.MetaService {
-	return &metaServiceImpl{}
+func NewMetaService(optionService service.OptionService, themeService service.ThemeService) service.MetaService {
+	return &metaServiceImpl{
+		OptionService: optionService,
+		ThemeService:  themeService,
+	}
 }
 
 func (m *metaServiceImpl) GetPostsMeta(ctx context.Context, postIDs []int32) (map[int32][]*entity.Meta, error) {
@@ -63,3 +73,96 @@ func (m *metaServiceImpl) ConvertToMetaDTOs(metas []*entity.Meta) []*dto.Meta {
 	}
 	return result
 }
+
+func (m *metaServiceImpl) ValidateMetas(ctx context.Context, postType consts.PostType, metas []param.Meta) ([]param.Meta, error) {
+	metaFields, err := m.listMetaFields(ctx, postType)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(metas))
+	for _, meta := range metas {
+		values[meta.Key] = meta.Value
+	}
+	for _, metaField := range metaFields {
+		value, ok := values[metaField.Name]
+		if !ok || value == "" {
+			if !metaField.Required {
+				continue
+			}
+			if metaField.DefaultValue == nil {
+				return nil, xerr.BadParam.New("").WithMsg(fmt.Sprintf("meta %s is required", metaLabel(metaField))).WithStatus(xerr.StatusBadRequest)
+			}
+			metas = append(metas, param.Meta{Key: metaField.Name, Value: fmt.Sprint(metaField.DefaultValue)})
+			continue
+		}
+		if _, err := metaField.DataType.Convert(value); err != nil {
+			return nil, xerr.BadParam.Wrap(err).WithMsg(fmt.Sprintf("meta %s is invalid", metaLabel(metaField))).WithStatus(xerr.StatusBadRequest)
+		}
+		if !isMetaOption(metaField, value) {
+			return nil, xerr.BadParam.New("").WithMsg(fmt.Sprintf("meta %s is not one of the options", metaLabel(metaField))).WithStatus(xerr.StatusBadRequest)
+		}
+	}
+	return metas, nil
+}
+
+func (m *metaServiceImpl) ConvertToTypedMetas(ctx context.Context, postType consts.PostType, metas []*entity.Meta) (map[string]interface{}, error) {
+	metaFields, err := m.listMetaFields(ctx, postType)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(metas)+len(metaFields))
+	for _, metaField := range metaFields {
+		if metaField.DefaultValue != nil {
+			result[metaField.Name] = metaField.DefaultValue
+		}
+	}
+	metaFieldMap := make(map[string]*dto.MetaField, len(metaFields))
+	for _, metaField := range metaFields {
+		metaFieldMap[metaField.Name] = metaField
+	}
+	for _, meta := range metas {
+		metaField, ok := metaFieldMap[meta.MetaKey]
+		if !ok {
+			result[meta.MetaKey] = meta.MetaValue
+			continue
+		}
+		value, err := metaField.DataType.Convert(meta.MetaValue)
+		if err != nil {
+			// the value was saved before the theme declared the meta
+			result[meta.MetaKey] = meta.MetaValue
+			continue
+		}
+		result[meta.MetaKey] = value
+	}
+	return result, nil
+}
+
+func (m *metaServiceImpl) listMetaFields(ctx context.Context, postType consts.PostType) ([]*dto.MetaField, error) {
+	activatedThemeID, err := m.OptionService.GetActivatedThemeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.ThemeService.ListMetaFields(ctx, activatedThemeID, postType)
+}
+
+func isMetaOption(metaField *dto.MetaField, value string) bool {
+	if metaField.InputType != consts.ThemeConfigInputTypeSELECT && metaField.InputType != consts.ThemeConfigInputTypeRADIO {
+		return true
+	}
+	if len(metaField.Options) == 0 {
+		return true
+	}
+	for _, option := range metaField.Options {
+		if fmt.Sprint(option.Value) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func metaLabel(metaField *dto.MetaField) string {
+	if metaField.Label != "" {
+		return metaField.Label
+	}
+	return metaField.Name
+}
@@ -164,6 +164,7 @@ func (p *photoServiceImpl) ConvertToDTO(ctx context.Context, photo *entity.Photo
 		Description: photo.Description,
 		Team:        photo.Team,
 		Location:    photo.Location,
+		Likes:       photo.Likes,
 	}
 }
 
@@ -176,18 +177,6 @@ func (p *photoServiceImpl) ConvertToDTOs(ctx context.Context, photos []*entity.P
 	return result
 }
 
-func (p *photoServiceImpl) IncreaseLike(ctx context.Context, id int32) error {
-	photoDAL := dal.GetQueryByCtx(ctx).Photo
-	updateResult, err := photoDAL.WithContext(ctx).Where(photoDAL.ID.Eq(id)).UpdateSimple(photoDAL.Likes.Add(1))
-	if err != nil {
-		return WrapDBErr(err)
-	}
-	if updateResult.RowsAffected != 1 {
-		return xerr.NoType.New("increase photo like failed id=%v", id).WithMsg("increase like failed").WithStatus(xerr.StatusInternalServerError)
-	}
-	return nil
-}
-
 func (p *photoServiceImpl) ListByTeam(ctx context.Context, team string, sort *param.Sort) ([]*entity.Photo, error) {
 	photoDAL := dal.GetQueryByCtx(ctx).Photo
 	photoDO := photoDAL.WithContext(ctx)
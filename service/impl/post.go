@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 
 	"github.com/go-sonic/sonic/cache"
@@ -83,18 +84,6 @@ func (p postServiceImpl) Page(ctx context.Context, postQuery param.PostQuery) ([
 	return posts, totalCount, nil
 }
 
-func (p postServiceImpl) IncreaseLike(ctx context.Context, postID int32) error {
-	postDAL := dal.GetQueryByCtx(ctx).Post
-	info, err := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(postID)).UpdateSimple(postDAL.Likes.Add(1))
-	if err != nil {
-		return WrapDBErr(err)
-	}
-	if info.RowsAffected != 1 {
-		return xerr.NoType.New("increase post like failed postID=%v", postID).WithStatus(xerr.StatusBadRequest).WithMsg("failed to like post")
-	}
-	return nil
-}
-
 func (p postServiceImpl) Create(ctx context.Context, postParam *param.Post) (*entity.Post, error) {
 	post, err := p.ConvertParam(ctx, postParam)
 	if err != nil {
@@ -112,6 +101,11 @@ func (p postServiceImpl) Create(ctx context.Context, postParam *param.Post) (*en
 	if err != nil {
 		return nil, err
 	}
+	if post.Status == consts.PostStatusPublished {
+		p.Event.Publish(ctx, &event.PostPublishedEvent{
+			PostID: post.ID,
+		})
+	}
 	// Todo delete authorization
 	p.Event.Publish(ctx, &event.LogEvent{
 		LogKey:    strconv.Itoa(int(post.ID)),
@@ -136,6 +130,7 @@ func (p postServiceImpl) Update(ctx context.Context, postID int32, postParam *pa
 		postToUpdate.CreateTime = post.CreateTime
 	}
 	postToUpdate.ID = post.ID
+	oldStatus := post.Status
 	post, err = p.CreateOrUpdate(ctx, postToUpdate, postParam.CategoryIDs, postParam.TagIDs, postParam.MetaParam)
 	if err != nil {
 		return nil, err
@@ -144,6 +139,14 @@ func (p postServiceImpl) Update(ctx context.Context, postID int32, postParam *pa
 	p.Event.Publish(ctx, &event.PostUpdateEvent{
 		PostID: post.ID,
 	})
+	if oldStatus != consts.PostStatusPublished && post.Status == consts.PostStatusPublished {
+		// the listener of PostUpdateEvent may have turned the post into an intimate one
+		if current, err := p.GetByPostID(ctx, post.ID); err == nil && current.Status == consts.PostStatusPublished {
+			p.Event.Publish(ctx, &event.PostPublishedEvent{
+				PostID: post.ID,
+			})
+		}
+	}
 	p.Event.Publish(ctx, &event.LogEvent{
 		LogKey:    strconv.Itoa(int(post.ID)),
 		LogType:   consts.LogTypePostEdited,
@@ -153,6 +156,154 @@ func (p postServiceImpl) Update(ctx context.Context, postID int32, postParam *pa
 	return post, nil
 }
 
+func (p postServiceImpl) UpdateBatch(ctx context.Context, batchParam *param.PostBatchUpdate) ([]*entity.Post, error) {
+	postIDs := uniqueInt32s(batchParam.PostIDs)
+	addCategoryIDs := uniqueInt32s(batchParam.AddCategoryIDs)
+	addTagIDs := uniqueInt32s(batchParam.AddTagIDs)
+
+	err := dal.Transaction(ctx, func(txCtx context.Context) error {
+		q := dal.GetQueryByCtx(txCtx)
+		postDAL := q.Post
+		postCount, err := postDAL.WithContext(txCtx).Where(postDAL.ID.In(postIDs...), postDAL.Type.Eq(consts.PostTypePost)).Count()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if int(postCount) != len(postIDs) {
+			return xerr.BadParam.New("").WithMsg("post not exist").WithStatus(xerr.StatusBadRequest)
+		}
+
+		updates := make([]field.AssignExpr, 0)
+		if batchParam.Template != nil {
+			updates = append(updates, postDAL.Template.Value(*batchParam.Template))
+		}
+		if batchParam.DisallowComment != nil {
+			updates = append(updates, postDAL.DisallowComment.Value(*batchParam.DisallowComment))
+		}
+		if batchParam.TopPriority != nil {
+			updates = append(updates, postDAL.TopPriority.Value(*batchParam.TopPriority))
+		}
+		if len(updates) > 0 {
+			_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.In(postIDs...)).UpdateColumnSimple(updates...)
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+
+		postCategoryDAL := q.PostCategory
+		if len(batchParam.RemoveCategoryIDs) > 0 {
+			_, err = postCategoryDAL.WithContext(txCtx).Where(postCategoryDAL.PostID.In(postIDs...), postCategoryDAL.CategoryID.In(batchParam.RemoveCategoryIDs...)).Delete()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+		if len(addCategoryIDs) > 0 {
+			categoryDAL := q.Category
+			categoryCount, err := categoryDAL.WithContext(txCtx).Where(categoryDAL.ID.In(addCategoryIDs...)).Count()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+			if int(categoryCount) != len(addCategoryIDs) {
+				return xerr.BadParam.New("").WithMsg("category not exist").WithStatus(xerr.StatusBadRequest)
+			}
+			existed, err := postCategoryDAL.WithContext(txCtx).Where(postCategoryDAL.PostID.In(postIDs...), postCategoryDAL.CategoryID.In(addCategoryIDs...)).Find()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+			existedMap := make(map[[2]int32]struct{}, len(existed))
+			for _, pc := range existed {
+				existedMap[[2]int32{pc.PostID, pc.CategoryID}] = struct{}{}
+			}
+			pcs := make([]*entity.PostCategory, 0)
+			for _, postID := range postIDs {
+				for _, categoryID := range addCategoryIDs {
+					if _, ok := existedMap[[2]int32{postID, categoryID}]; ok {
+						continue
+					}
+					pcs = append(pcs, &entity.PostCategory{PostID: postID, CategoryID: categoryID})
+				}
+			}
+			if len(pcs) > 0 {
+				err = postCategoryDAL.WithContext(txCtx).Create(pcs...)
+				if err != nil {
+					return WrapDBErr(err)
+				}
+			}
+		}
+
+		postTagDAL := q.PostTag
+		if len(batchParam.RemoveTagIDs) > 0 {
+			_, err = postTagDAL.WithContext(txCtx).Where(postTagDAL.PostID.In(postIDs...), postTagDAL.TagID.In(batchParam.RemoveTagIDs...)).Delete()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+		if len(addTagIDs) > 0 {
+			tagDAL := q.Tag
+			tagCount, err := tagDAL.WithContext(txCtx).Where(tagDAL.ID.In(addTagIDs...)).Count()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+			if int(tagCount) != len(addTagIDs) {
+				return xerr.BadParam.New("").WithMsg("tag not exist").WithStatus(xerr.StatusBadRequest)
+			}
+			existed, err := postTagDAL.WithContext(txCtx).Where(postTagDAL.PostID.In(postIDs...), postTagDAL.TagID.In(addTagIDs...)).Find()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+			existedMap := make(map[[2]int32]struct{}, len(existed))
+			for _, pt := range existed {
+				existedMap[[2]int32{pt.PostID, pt.TagID}] = struct{}{}
+			}
+			pts := make([]*entity.PostTag, 0)
+			for _, postID := range postIDs {
+				for _, tagID := range addTagIDs {
+					if _, ok := existedMap[[2]int32{postID, tagID}]; ok {
+						continue
+					}
+					pts = append(pts, &entity.PostTag{PostID: postID, TagID: tagID})
+				}
+			}
+			if len(pts) > 0 {
+				err = postTagDAL.WithContext(txCtx).Create(pts...)
+				if err != nil {
+					return WrapDBErr(err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the listener updates the status of the posts according to the intimate categories
+	for _, postID := range postIDs {
+		p.Event.Publish(ctx, &event.PostUpdateEvent{
+			PostID: postID,
+			Batch:  true,
+		})
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(postIDs...)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return posts, nil
+}
+
+func uniqueInt32s(values []int32) []int32 {
+	uniqueMap := make(map[int32]struct{}, len(values))
+	uniqueValues := make([]int32, 0, len(values))
+	for _, value := range values {
+		if _, ok := uniqueMap[value]; ok {
+			continue
+		}
+		uniqueMap[value] = struct{}{}
+		uniqueValues = append(uniqueValues, value)
+	}
+	return uniqueValues
+}
+
 func (p postServiceImpl) ConvertParam(ctx context.Context, postParam *param.Post) (*entity.Post, error) {
 	post := &entity.Post{
 		Type:            consts.PostTypePost,
@@ -0,0 +1,324 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/model/vo"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// maxReactionLength is the length of the reaction column
+const maxReactionLength = 32
+
+type reactionServiceImpl struct {
+	OptionService service.OptionService
+}
+
+func NewReactionService(optionService service.OptionService) service.ReactionService {
+	return &reactionServiceImpl{
+		OptionService: optionService,
+	}
+}
+
+func (r *reactionServiceImpl) ListAllowed(ctx context.Context, targetType consts.ReactionTarget) ([]string, error) {
+	var p property.Property
+	switch targetType {
+	case consts.ReactionTargetPost:
+		p = property.ReactionPostTypes
+	case consts.ReactionTargetJournal:
+		p = property.ReactionJournalTypes
+	case consts.ReactionTargetPhoto:
+		p = property.ReactionPhotoTypes
+	case consts.ReactionTargetComment:
+		p = property.ReactionCommentTypes
+	default:
+		return nil, xerr.BadParam.New("targetType=%v", targetType).WithMsg("Unknown reaction target").WithStatus(xerr.StatusBadRequest)
+	}
+	value, err := r.OptionService.GetOrByDefaultWithErr(ctx, p, p.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, reaction := range strings.Split(value.(string), ",") {
+		reaction = strings.TrimSpace(reaction)
+		if reaction == "" || utf8.RuneCountInString(reaction) > maxReactionLength {
+			continue
+		}
+		if _, ok := seen[reaction]; ok {
+			continue
+		}
+		seen[reaction] = struct{}{}
+		allowed = append(allowed, reaction)
+	}
+	return allowed, nil
+}
+
+func (r *reactionServiceImpl) Toggle(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string, reaction string) (*vo.ReactionSummary, error) {
+	allowed, err := r.ListAllowed(ctx, targetType)
+	if err != nil {
+		return nil, err
+	}
+	isAllowed := false
+	for _, allowedReaction := range allowed {
+		if allowedReaction == reaction {
+			isAllowed = true
+			break
+		}
+	}
+	if !isAllowed {
+		return nil, xerr.BadParam.New("reaction=%v", reaction).WithMsg("Unsupported reaction").WithStatus(xerr.StatusBadRequest)
+	}
+	err = r.react(ctx, targetType, targetID, fingerprint, reaction, true)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetSummary(ctx, targetType, targetID, fingerprint)
+}
+
+func (r *reactionServiceImpl) Like(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string) error {
+	return r.react(ctx, targetType, targetID, fingerprint, consts.ReactionLike, false)
+}
+
+// react replaces the reaction of the visitor, the same reaction is taken back if toggle is true.
+func (r *reactionServiceImpl) react(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string, reaction string, toggle bool) error {
+	err := r.checkTarget(ctx, targetType, targetID)
+	if err != nil {
+		return err
+	}
+	return dal.Transaction(ctx, func(txCtx context.Context) error {
+		reactionDAL := dal.GetQueryByCtx(txCtx).Reaction
+		previous, err := reactionDAL.WithContext(txCtx).Where(
+			reactionDAL.TargetType.Eq(targetType),
+			reactionDAL.TargetID.Eq(targetID),
+			reactionDAL.Fingerprint.Eq(fingerprint),
+		).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return WrapDBErr(err)
+		}
+
+		var likesDelta int32
+		switch {
+		case previous == nil:
+			err = reactionDAL.WithContext(txCtx).Create(&entity.Reaction{
+				TargetType:  targetType,
+				TargetID:    targetID,
+				Fingerprint: fingerprint,
+				Reaction:    reaction,
+			})
+			if reaction == consts.ReactionLike {
+				likesDelta = 1
+			}
+		case previous.Reaction == reaction:
+			if !toggle {
+				return nil
+			}
+			_, err = reactionDAL.WithContext(txCtx).Where(reactionDAL.ID.Eq(previous.ID)).Delete()
+			if reaction == consts.ReactionLike {
+				likesDelta = -1
+			}
+		default:
+			_, err = reactionDAL.WithContext(txCtx).Where(reactionDAL.ID.Eq(previous.ID)).UpdateSimple(reactionDAL.Reaction.Value(reaction))
+			if previous.Reaction == consts.ReactionLike {
+				likesDelta = -1
+			} else if reaction == consts.ReactionLike {
+				likesDelta = 1
+			}
+		}
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if likesDelta == 0 {
+			return nil
+		}
+		return r.updateLikes(txCtx, targetType, targetID, likesDelta)
+	})
+}
+
+func (r *reactionServiceImpl) GetSummary(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string) (*vo.ReactionSummary, error) {
+	err := r.checkTarget(ctx, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := r.ListAllowed(ctx, targetType)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := r.CountByTargetIDs(ctx, targetType, []int32{targetID})
+	if err != nil {
+		return nil, err
+	}
+	summary := &vo.ReactionSummary{
+		Allowed:   allowed,
+		Reactions: counts[targetID],
+	}
+
+	reactionDAL := dal.GetQueryByCtx(ctx).Reaction
+	current, err := reactionDAL.WithContext(ctx).Where(
+		reactionDAL.TargetType.Eq(targetType),
+		reactionDAL.TargetID.Eq(targetID),
+		reactionDAL.Fingerprint.Eq(fingerprint),
+	).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, WrapDBErr(err)
+	}
+	if current != nil {
+		summary.Current = current.Reaction
+	}
+	return summary, nil
+}
+
+func (r *reactionServiceImpl) CountByTargetIDs(ctx context.Context, targetType consts.ReactionTarget, targetIDs []int32) (map[int32]map[string]int64, error) {
+	result := make(map[int32]map[string]int64, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+	allowed, err := r.ListAllowed(ctx, targetType)
+	if err != nil {
+		return nil, err
+	}
+	likes, err := r.getLikes(ctx, targetType, targetIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, targetID := range targetIDs {
+		counts := make(map[string]int64, len(allowed))
+		for _, reaction := range allowed {
+			counts[reaction] = 0
+		}
+		if _, ok := counts[consts.ReactionLike]; ok {
+			counts[consts.ReactionLike] = likes[targetID]
+		}
+		result[targetID] = counts
+	}
+
+	var projections []struct {
+		TargetID      int32
+		Reaction      string
+		ReactionCount int64 `gorm:"column:reaction_count"`
+	}
+	reactionDAL := dal.GetQueryByCtx(ctx).Reaction
+	err = reactionDAL.WithContext(ctx).Select(reactionDAL.TargetID, reactionDAL.Reaction, reactionDAL.ID.Count().As("reaction_count")).
+		Where(reactionDAL.TargetType.Eq(targetType), reactionDAL.TargetID.In(targetIDs...), reactionDAL.Reaction.Neq(consts.ReactionLike)).
+		Group(reactionDAL.TargetID, reactionDAL.Reaction).Scan(&projections)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, projection := range projections {
+		if _, ok := result[projection.TargetID][projection.Reaction]; ok {
+			result[projection.TargetID][projection.Reaction] = projection.ReactionCount
+		}
+	}
+	return result, nil
+}
+
+// checkTarget makes sure the target is visible to the visitors.
+func (r *reactionServiceImpl) checkTarget(ctx context.Context, targetType consts.ReactionTarget, targetID int32) error {
+	var err error
+	switch targetType {
+	case consts.ReactionTargetPost:
+		postDAL := dal.GetQueryByCtx(ctx).Post
+		_, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(targetID), postDAL.Status.Eq(consts.PostStatusPublished)).First()
+	case consts.ReactionTargetJournal:
+		journalDAL := dal.GetQueryByCtx(ctx).Journal
+		_, err = journalDAL.WithContext(ctx).Where(journalDAL.ID.Eq(targetID), journalDAL.Type.Eq(consts.JournalTypePublic)).First()
+	case consts.ReactionTargetPhoto:
+		photoDAL := dal.GetQueryByCtx(ctx).Photo
+		_, err = photoDAL.WithContext(ctx).Where(photoDAL.ID.Eq(targetID)).First()
+	case consts.ReactionTargetComment:
+		commentDAL := dal.GetQueryByCtx(ctx).Comment
+		_, err = commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(targetID), commentDAL.Status.Eq(consts.CommentStatusPublished)).First()
+	default:
+		return xerr.BadParam.New("targetType=%v", targetType).WithMsg("Unknown reaction target").WithStatus(xerr.StatusBadRequest)
+	}
+	return WrapDBErr(err)
+}
+
+// updateLikes keeps the likes of the target in step with the like reactions, the likes never go below 0.
+func (r *reactionServiceImpl) updateLikes(ctx context.Context, targetType consts.ReactionTarget, targetID int32, delta int32) error {
+	var err error
+	switch targetType {
+	case consts.ReactionTargetPost:
+		postDAL := dal.GetQueryByCtx(ctx).Post
+		postDO := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(targetID))
+		if delta < 0 {
+			postDO = postDO.Where(postDAL.Likes.Gt(0))
+		}
+		_, err = postDO.UpdateSimple(postDAL.Likes.Add(int64(delta)))
+	case consts.ReactionTargetJournal:
+		journalDAL := dal.GetQueryByCtx(ctx).Journal
+		journalDO := journalDAL.WithContext(ctx).Where(journalDAL.ID.Eq(targetID))
+		if delta < 0 {
+			journalDO = journalDO.Where(journalDAL.Likes.Gt(0))
+		}
+		_, err = journalDO.UpdateSimple(journalDAL.Likes.Add(int64(delta)))
+	case consts.ReactionTargetPhoto:
+		photoDAL := dal.GetQueryByCtx(ctx).Photo
+		photoDO := photoDAL.WithContext(ctx).Where(photoDAL.ID.Eq(targetID))
+		if delta < 0 {
+			photoDO = photoDO.Where(photoDAL.Likes.Gt(0))
+		}
+		_, err = photoDO.UpdateSimple(photoDAL.Likes.Add(int64(delta)))
+	case consts.ReactionTargetComment:
+		commentDAL := dal.GetQueryByCtx(ctx).Comment
+		commentDO := commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(targetID))
+		if delta < 0 {
+			commentDO = commentDO.Where(commentDAL.Likes.Gt(0))
+		}
+		_, err = commentDO.UpdateSimple(commentDAL.Likes.Add(delta))
+	}
+	return WrapDBErr(err)
+}
+
+func (r *reactionServiceImpl) getLikes(ctx context.Context, targetType consts.ReactionTarget, targetIDs []int32) (map[int32]int64, error) {
+	likes := make(map[int32]int64, len(targetIDs))
+	switch targetType {
+	case consts.ReactionTargetPost:
+		postDAL := dal.GetQueryByCtx(ctx).Post
+		posts, err := postDAL.WithContext(ctx).Select(postDAL.ID, postDAL.Likes).Where(postDAL.ID.In(targetIDs...)).Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, post := range posts {
+			likes[post.ID] = post.Likes
+		}
+	case consts.ReactionTargetJournal:
+		journalDAL := dal.GetQueryByCtx(ctx).Journal
+		journals, err := journalDAL.WithContext(ctx).Select(journalDAL.ID, journalDAL.Likes).Where(journalDAL.ID.In(targetIDs...)).Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, journal := range journals {
+			likes[journal.ID] = journal.Likes
+		}
+	case consts.ReactionTargetPhoto:
+		photoDAL := dal.GetQueryByCtx(ctx).Photo
+		photos, err := photoDAL.WithContext(ctx).Select(photoDAL.ID, photoDAL.Likes).Where(photoDAL.ID.In(targetIDs...)).Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, photo := range photos {
+			likes[photo.ID] = photo.Likes
+		}
+	case consts.ReactionTargetComment:
+		commentDAL := dal.GetQueryByCtx(ctx).Comment
+		comments, err := commentDAL.WithContext(ctx).Select(commentDAL.ID, commentDAL.Likes).Where(commentDAL.ID.In(targetIDs...)).Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, comment := range comments {
+			likes[comment.ID] = int64(comment.Likes)
+		}
+	}
+	return likes, nil
+}
@@ -0,0 +1,195 @@
+package impl
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/go-sonic/sonic/config"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/service"
+	storageimpl "github.com/go-sonic/sonic/service/storage/impl"
+)
+
+// responsiveImageSetting is the theme setting that turns on the responsive images
+const responsiveImageSetting = "responsive_images"
+
+type responsiveImageServiceImpl struct {
+	Config        *config.Config
+	OptionService service.OptionService
+	ThemeService  service.ThemeService
+}
+
+func NewResponsiveImageService(config *config.Config, optionService service.OptionService, themeService service.ThemeService) service.ResponsiveImageService {
+	return &responsiveImageServiceImpl{
+		Config:        config,
+		OptionService: optionService,
+		ThemeService:  themeService,
+	}
+}
+
+func (r *responsiveImageServiceImpl) Filter(ctx context.Context, content string) (string, error) {
+	if content == "" {
+		return content, nil
+	}
+	enabled, err := r.isEnabled(ctx)
+	if err != nil || !enabled {
+		return content, err
+	}
+	blogBaseURL, err := r.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	paths := make([]string, 0)
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for tokenType := tokenizer.Next(); tokenType != html.ErrorToken; tokenType = tokenizer.Next() {
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+		token := tokenizer.Token()
+		if token.DataAtom != atom.Img {
+			continue
+		}
+		if attachmentPath := localAttachmentPath(getAttr(&token, "src"), blogBaseURL); attachmentPath != "" {
+			paths = append(paths, attachmentPath)
+		}
+	}
+	if len(paths) == 0 {
+		return content, nil
+	}
+
+	attachmentDAL := dal.GetQueryByCtx(ctx).Attachment
+	attachments, err := attachmentDAL.WithContext(ctx).Where(
+		attachmentDAL.Type.Eq(consts.AttachmentTypeLocal),
+		attachmentDAL.Path.In(paths...),
+	).Find()
+	if err != nil {
+		return "", WrapDBErr(err)
+	}
+	if len(attachments) == 0 {
+		return content, nil
+	}
+	attachmentMap := make(map[string]*entity.Attachment, len(attachments))
+	for _, attachment := range attachments {
+		attachmentMap[attachment.Path] = attachment
+	}
+
+	result := strings.Builder{}
+	result.Grow(len(content))
+	tokenizer = html.NewTokenizer(strings.NewReader(content))
+	for tokenType := tokenizer.Next(); tokenType != html.ErrorToken; tokenType = tokenizer.Next() {
+		raw := string(tokenizer.Raw())
+		if tokenType == html.StartTagToken || tokenType == html.SelfClosingTagToken {
+			token := tokenizer.Token()
+			if token.DataAtom == atom.Img {
+				if attachment, ok := attachmentMap[localAttachmentPath(getAttr(&token, "src"), blogBaseURL)]; ok {
+					r.rewriteImage(&token, attachment)
+					result.WriteString(token.String())
+					continue
+				}
+			}
+		}
+		result.WriteString(raw)
+	}
+	return result.String(), nil
+}
+
+func (r *responsiveImageServiceImpl) isEnabled(ctx context.Context) (bool, error) {
+	theme, err := r.ThemeService.GetActivateTheme(ctx)
+	if err != nil {
+		return false, err
+	}
+	settings, err := r.ThemeService.GetThemeSettingMap(ctx, theme.ID)
+	if err != nil {
+		return false, err
+	}
+	return cast.ToBool(settings[responsiveImageSetting]), nil
+}
+
+func (r *responsiveImageServiceImpl) rewriteImage(token *html.Token, attachment *entity.Attachment) {
+	setAttrIfAbsent(token, "loading", "lazy")
+	if attachment.Width <= 0 || attachment.Height <= 0 {
+		return
+	}
+	width := strconv.Itoa(int(attachment.Width))
+	if getAttr(token, "width") == "" && getAttr(token, "height") == "" {
+		setAttrIfAbsent(token, "width", width)
+		setAttrIfAbsent(token, "height", strconv.Itoa(int(attachment.Height)))
+	}
+	if getAttr(token, "srcset") != "" {
+		return
+	}
+	src := getAttr(token, "src")
+	srcURL, err := url.Parse(src)
+	if err != nil {
+		return
+	}
+	srcset := make([]string, 0, len(storageimpl.ResponsiveWidths)+1)
+	for _, responsiveWidth := range storageimpl.ResponsiveWidths {
+		if responsiveWidth >= int(attachment.Width) {
+			break
+		}
+		responsivePath := storageimpl.ResponsiveImageName(attachment.Path, responsiveWidth)
+		if _, err := os.Stat(filepath.Join(r.Config.Sonic.WorkDir, filepath.FromSlash(responsivePath))); err != nil {
+			continue
+		}
+		responsiveURL := *srcURL
+		responsiveURL.Path = storageimpl.ResponsiveImageName(srcURL.Path, responsiveWidth)
+		responsiveURL.RawPath = ""
+		srcset = append(srcset, responsiveURL.String()+" "+strconv.Itoa(responsiveWidth)+"w")
+	}
+	if len(srcset) == 0 {
+		return
+	}
+	srcset = append(srcset, src+" "+width+"w")
+	setAttrIfAbsent(token, "srcset", strings.Join(srcset, ", "))
+	setAttrIfAbsent(token, "sizes", "(max-width: "+width+"px) 100vw, "+width+"px")
+}
+
+// localAttachmentPath returns the path of the local attachment the src points at,
+// which is relative to the work dir, or empty if src is not on this site.
+func localAttachmentPath(src, blogBaseURL string) string {
+	if src == "" {
+		return ""
+	}
+	if blogBaseURL != "" {
+		src = strings.TrimPrefix(src, strings.TrimSuffix(blogBaseURL, "/"))
+	}
+	srcURL, err := url.Parse(src)
+	if err != nil || srcURL.Scheme != "" || srcURL.Host != "" {
+		return ""
+	}
+	attachmentPath := strings.TrimPrefix(srcURL.Path, "/")
+	if !strings.HasPrefix(attachmentPath, consts.SonicUploadDir+"/") {
+		return ""
+	}
+	return attachmentPath
+}
+
+func getAttr(token *html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func setAttrIfAbsent(token *html.Token, key, val string) {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return
+		}
+	}
+	token.Attr = append(token.Attr, html.Attribute{Key: key, Val: val})
+}
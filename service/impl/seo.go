@@ -0,0 +1,344 @@
+package impl
+
+import (
+	"context"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/model/vo"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	schemaContext = "https://schema.org"
+	// seoHeadlineLength is the max length of the headline of an article, longer headlines are truncated by the search engines
+	seoHeadlineLength = 110
+
+	seoTypeWebsite = "website"
+	seoTypeArticle = "article"
+)
+
+type seoServiceImpl struct {
+	OptionService service.OptionService
+	UserService   service.UserService
+}
+
+func NewSeoService(optionService service.OptionService, userService service.UserService) service.SeoService {
+	return &seoServiceImpl{
+		OptionService: optionService,
+		UserService:   userService,
+	}
+}
+
+// seoSite is the metadata of the blog shared by all the pages
+type seoSite struct {
+	base        *url.URL
+	home        string
+	title       string
+	description string
+	keywords    string
+	logo        string
+}
+
+// breadcrumb is an item of the BreadcrumbList, the url of the last item is the page itself
+type breadcrumb struct {
+	name string
+	url  string
+}
+
+func (s *seoServiceImpl) BuildForSite(ctx context.Context, title string, path string, page int) (*vo.SeoMeta, error) {
+	site, err := s.getSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = site.title
+	}
+	meta := site.newMeta(title, pagedURL(site.base, path, page))
+	if strings.Trim(path, "/") == "" {
+		meta.JSONLD = newGraph(site.website())
+		return meta, nil
+	}
+	meta.JSONLD = newGraph(site.breadcrumbList([]breadcrumb{{name: title, url: meta.CanonicalURL}}))
+	return meta, nil
+}
+
+func (s *seoServiceImpl) BuildForPost(ctx context.Context, post *dto.Post, categories []*dto.CategoryDTO, tags []*dto.Tag) (*vo.SeoMeta, error) {
+	site, err := s.getSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meta, article, err := s.buildArticle(ctx, site, post)
+	if err != nil {
+		return nil, err
+	}
+
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	meta.Tags = tagNames
+	if post.MetaKeywords == "" && len(tagNames) > 0 {
+		meta.Keywords = strings.Join(tagNames, ",")
+	}
+	if meta.Keywords != "" {
+		article["keywords"] = meta.Keywords
+	}
+
+	crumbs := make([]breadcrumb, 0, 2)
+	if len(categories) > 0 {
+		article["articleSection"] = categories[0].Name
+		crumbs = append(crumbs, breadcrumb{name: categories[0].Name, url: util.ResolveURL(site.base, categories[0].FullPath)})
+	}
+	crumbs = append(crumbs, breadcrumb{name: post.Title, url: meta.CanonicalURL})
+	meta.JSONLD = newGraph(article, site.breadcrumbList(crumbs))
+	return meta, nil
+}
+
+func (s *seoServiceImpl) BuildForSheet(ctx context.Context, sheet *dto.Post) (*vo.SeoMeta, error) {
+	site, err := s.getSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meta, article, err := s.buildArticle(ctx, site, sheet)
+	if err != nil {
+		return nil, err
+	}
+	meta.JSONLD = newGraph(article, site.breadcrumbList([]breadcrumb{{name: sheet.Title, url: meta.CanonicalURL}}))
+	return meta, nil
+}
+
+func (s *seoServiceImpl) BuildForCategory(ctx context.Context, category *dto.CategoryDTO, listTitle string, page int) (*vo.SeoMeta, error) {
+	site, err := s.getSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := s.OptionService.GetCategoryPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	canonicalURL := util.ResolveURL(site.base, category.FullPath)
+	if page > 0 {
+		canonicalURL = pagedURL(site.base, prefix+"/"+category.Slug, page)
+	}
+	meta := site.newMeta(category.Name, canonicalURL)
+	if category.Description != "" {
+		meta.Description = category.Description
+	}
+	if category.Thumbnail != "" {
+		meta.Image = util.ResolveURL(site.base, category.Thumbnail)
+	}
+	meta.TwitterCard = twitterCard(meta.Image)
+	meta.JSONLD = newGraph(site.breadcrumbList([]breadcrumb{
+		{name: listTitle, url: pagedURL(site.base, prefix, 0)},
+		{name: category.Name, url: canonicalURL},
+	}))
+	return meta, nil
+}
+
+func (s *seoServiceImpl) BuildForTag(ctx context.Context, tag *dto.Tag, listTitle string, page int) (*vo.SeoMeta, error) {
+	site, err := s.getSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := s.OptionService.GetTagPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	canonicalURL := util.ResolveURL(site.base, tag.FullPath)
+	if page > 0 {
+		canonicalURL = pagedURL(site.base, prefix+"/"+tag.Slug, page)
+	}
+	meta := site.newMeta(tag.Name, canonicalURL)
+	if tag.Thumbnail != "" {
+		meta.Image = util.ResolveURL(site.base, tag.Thumbnail)
+	}
+	meta.TwitterCard = twitterCard(meta.Image)
+	meta.JSONLD = newGraph(site.breadcrumbList([]breadcrumb{
+		{name: listTitle, url: pagedURL(site.base, prefix, 0)},
+		{name: tag.Name, url: canonicalURL},
+	}))
+	return meta, nil
+}
+
+func (s *seoServiceImpl) getSite(ctx context.Context) (*seoSite, error) {
+	blogURL, err := s.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(blogURL + "/")
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid blog url")
+	}
+	site := &seoSite{
+		base:        base,
+		home:        base.String(),
+		title:       s.OptionService.GetOrByDefault(ctx, property.BlogTitle).(string),
+		description: s.OptionService.GetOrByDefault(ctx, property.SeoDescription).(string),
+		keywords:    s.OptionService.GetOrByDefault(ctx, property.SeoKeywords).(string),
+	}
+	if logo := s.OptionService.GetOrByDefault(ctx, property.BlogLogo).(string); logo != "" {
+		site.logo = util.ResolveURL(base, logo)
+	}
+	return site, nil
+}
+
+// buildArticle builds the metadata and the Article node shared by the posts and the sheets
+func (s *seoServiceImpl) buildArticle(ctx context.Context, site *seoSite, post *dto.Post) (*vo.SeoMeta, map[string]interface{}, error) {
+	meta := site.newMeta(post.Title, util.ResolveURL(site.base, post.FullPath))
+	meta.Type = seoTypeArticle
+	if post.MetaDescription != "" {
+		meta.Description = post.MetaDescription
+	} else if post.Summary != "" {
+		// the summary is cut from the rendered content, the entities are kept
+		meta.Description = html.UnescapeString(post.Summary)
+	}
+	if post.MetaKeywords != "" {
+		meta.Keywords = post.MetaKeywords
+	}
+	if post.Thumbnail != "" {
+		meta.Image = util.ResolveURL(site.base, post.Thumbnail)
+	}
+	meta.TwitterCard = twitterCard(meta.Image)
+	meta.PublishedTime = time.UnixMilli(post.CreateTime).Format(time.RFC3339)
+	modifiedTime := post.EditTime
+	if modifiedTime == 0 {
+		modifiedTime = post.UpdateTime
+	}
+	if modifiedTime == 0 {
+		modifiedTime = post.CreateTime
+	}
+	meta.ModifiedTime = time.UnixMilli(modifiedTime).Format(time.RFC3339)
+
+	users, err := s.UserService.GetAllUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(users) > 0 {
+		meta.Author = users[0].Nickname
+	}
+
+	article := map[string]interface{}{
+		"@type":            "Article",
+		"@id":              meta.CanonicalURL + "#article",
+		"headline":         truncateRunes(post.Title, seoHeadlineLength),
+		"url":              meta.CanonicalURL,
+		"mainEntityOfPage": meta.CanonicalURL,
+		"datePublished":    meta.PublishedTime,
+		"dateModified":     meta.ModifiedTime,
+		"publisher":        site.organization(),
+	}
+	if meta.Description != "" {
+		article["description"] = meta.Description
+	}
+	if meta.Image != "" {
+		article["image"] = []string{meta.Image}
+	}
+	if meta.Author != "" {
+		article["author"] = map[string]interface{}{
+			"@type": "Person",
+			"name":  meta.Author,
+			"url":   site.home,
+		}
+	}
+	return meta, article, nil
+}
+
+// newMeta builds the metadata of a page of the site, the description, the keywords and the image default to the ones of the blog
+func (site *seoSite) newMeta(title string, canonicalURL string) *vo.SeoMeta {
+	return &vo.SeoMeta{
+		Title:        title,
+		Description:  site.description,
+		Keywords:     site.keywords,
+		CanonicalURL: canonicalURL,
+		Image:        site.logo,
+		Type:         seoTypeWebsite,
+		SiteName:     site.title,
+		TwitterCard:  twitterCard(site.logo),
+	}
+}
+
+func (site *seoSite) website() map[string]interface{} {
+	website := map[string]interface{}{
+		"@type":     "WebSite",
+		"@id":       site.home + "#website",
+		"url":       site.home,
+		"name":      site.title,
+		"publisher": site.organization(),
+		"potentialAction": map[string]interface{}{
+			"@type":       "SearchAction",
+			"target":      site.home + "search?keyword={search_term_string}",
+			"query-input": "required name=search_term_string",
+		},
+	}
+	if site.description != "" {
+		website["description"] = site.description
+	}
+	return website
+}
+
+func (site *seoSite) organization() map[string]interface{} {
+	organization := map[string]interface{}{
+		"@type": "Organization",
+		"name":  site.title,
+		"url":   site.home,
+	}
+	if site.logo != "" {
+		organization["logo"] = map[string]interface{}{
+			"@type": "ImageObject",
+			"url":   site.logo,
+		}
+	}
+	return organization
+}
+
+// breadcrumbList builds the BreadcrumbList of the crumbs, the home page is always the first item
+func (site *seoSite) breadcrumbList(crumbs []breadcrumb) map[string]interface{} {
+	crumbs = append([]breadcrumb{{name: site.title, url: site.home}}, crumbs...)
+	items := make([]map[string]interface{}, 0, len(crumbs))
+	for i, crumb := range crumbs {
+		items = append(items, map[string]interface{}{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"name":     crumb.name,
+			"item":     crumb.url,
+		})
+	}
+	return map[string]interface{}{
+		"@type":           "BreadcrumbList",
+		"itemListElement": items,
+	}
+}
+
+func newGraph(nodes ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"@context": schemaContext,
+		"@graph":   nodes,
+	}
+}
+
+// pagedURL returns the absolute url of the page of the list at the path, the pages start from 0
+func pagedURL(base *url.URL, path string, page int) string {
+	path = strings.Trim(path, "/")
+	if page > 0 {
+		if path != "" {
+			path += "/"
+		}
+		path += "page/" + strconv.Itoa(page+1)
+	}
+	return util.ResolveURL(base, path)
+}
+
+func twitterCard(image string) string {
+	if image != "" {
+		return "summary_large_image"
+	}
+	return "summary"
+}
@@ -70,6 +70,11 @@ func (s sheetServiceImpl) Create(ctx context.Context, sheetParam *param.Sheet) (
 	if err != nil {
 		return nil, err
 	}
+	if sheet.Status == consts.PostStatusPublished {
+		s.Event.Publish(ctx, &event.PostPublishedEvent{
+			PostID: sheet.ID,
+		})
+	}
 	return sheet, nil
 }
 
@@ -127,10 +132,19 @@ func (s sheetServiceImpl) Update(ctx context.Context, sheetID int32, sheetParam
 	sheetToUpdate.Likes = sheet.Likes
 	sheetToUpdate.Visits = sheet.Visits
 
+	oldStatus := sheet.Status
 	sheet, err = s.CreateOrUpdate(ctx, sheetToUpdate, nil, nil, sheetParam.Metas)
 	if err != nil {
 		return nil, err
 	}
+	s.Event.Publish(ctx, &event.SheetUpdateEvent{
+		SheetID: sheet.ID,
+	})
+	if oldStatus != consts.PostStatusPublished && sheet.Status == consts.PostStatusPublished {
+		s.Event.Publish(ctx, &event.PostPublishedEvent{
+			PostID: sheet.ID,
+		})
+	}
 	s.Event.Publish(ctx, &event.LogEvent{
 		LogKey:    strconv.Itoa(int(sheet.ID)),
 		LogType:   consts.LogTypeSheetEdited,
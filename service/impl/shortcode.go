@@ -0,0 +1,261 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	htmlTemplate "html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/vo"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util/pageparser"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const builtinShortcodePrefix = "common/shortcode/"
+
+var (
+	// the editor escapes the shortcodes written in markdown
+	escapedShortcodeRegexp = regexp.MustCompile(`\{\{(?:&lt;|<|%)(?:[^}]|\}[^}])*?(?:&gt;|>|%)\}\}`)
+	// a shortcode in a paragraph of its own is unwrapped, so that its output is not nested in the paragraph
+	paragraphShortcodeRegexp = regexp.MustCompile(`<p>\s*(\{\{(?:<|%)(?:[^}]|\}[^}])*?(?:>|%)\}\})\s*</p>`)
+)
+
+type shortcodeServiceImpl struct {
+	Template     *template.Template
+	ThemeService service.ThemeService
+}
+
+func NewShortcodeService(template *template.Template, themeService service.ThemeService) service.ShortcodeService {
+	return &shortcodeServiceImpl{
+		Template:     template,
+		ThemeService: themeService,
+	}
+}
+
+// shortcodeTag is an opening, closing or self-closing shortcode tag in the content.
+type shortcodeTag struct {
+	shortcode   *vo.Shortcode
+	source      string
+	closing     bool
+	selfClosing bool
+	// closeIndex is the index of the closing tag of an opening tag, or -1 if it has no inner content
+	closeIndex int
+}
+
+func (s *shortcodeServiceImpl) Render(ctx context.Context, content string) (string, error) {
+	if !strings.Contains(content, "{{") {
+		return content, nil
+	}
+	source, codes := protectCode(content)
+	if !strings.Contains(source, "{{") {
+		return content, nil
+	}
+	source = escapedShortcodeRegexp.ReplaceAllStringFunc(source, html.UnescapeString)
+	source = paragraphShortcodeRegexp.ReplaceAllString(source, "$1")
+
+	pieces, err := parseShortcodes([]byte(source))
+	if err != nil {
+		log.CtxWarn(ctx, "parse shortcode err", zap.Error(err))
+		return content, nil
+	}
+
+	// pair the opening tags with their closing tags
+	openIndexes := make([]int, 0)
+	for i, piece := range pieces {
+		tag, ok := piece.(*shortcodeTag)
+		if !ok || tag.selfClosing {
+			continue
+		}
+		if !tag.closing {
+			openIndexes = append(openIndexes, i)
+			continue
+		}
+		for j := len(openIndexes) - 1; j >= 0; j-- {
+			openTag := pieces[openIndexes[j]].(*shortcodeTag)
+			if openTag.shortcode.Name == tag.shortcode.Name {
+				openTag.closeIndex = i
+				openIndexes = openIndexes[:j]
+				break
+			}
+		}
+	}
+
+	result := &strings.Builder{}
+	s.render(ctx, result, pieces, 0, len(pieces))
+	return restoreCode(result.String(), codes), nil
+}
+
+// protectCode replaces the pre and code elements of the content with placeholders,
+// so that the shortcodes shown in code blocks are not rendered.
+func protectCode(content string) (string, []string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	result := &strings.Builder{}
+	code := &strings.Builder{}
+	codes := make([]string, 0)
+	depth := 0
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		raw := string(tokenizer.Raw())
+		name, _ := tokenizer.TagName()
+		isCode := string(name) == "pre" || string(name) == "code"
+		switch {
+		case tokenType == html.StartTagToken && isCode:
+			depth++
+		case tokenType == html.EndTagToken && isCode && depth > 0:
+			depth--
+			code.WriteString(raw)
+			if depth == 0 {
+				result.WriteString(codePlaceholder(len(codes)))
+				codes = append(codes, code.String())
+				code.Reset()
+			}
+			continue
+		}
+		if depth > 0 {
+			code.WriteString(raw)
+		} else {
+			result.WriteString(raw)
+		}
+	}
+	// an unclosed code block lasts until the end of the content
+	if code.Len() > 0 {
+		result.WriteString(codePlaceholder(len(codes)))
+		codes = append(codes, code.String())
+	}
+	return result.String(), codes
+}
+
+func restoreCode(content string, codes []string) string {
+	for i, code := range codes {
+		content = strings.Replace(content, codePlaceholder(i), code, 1)
+	}
+	return content
+}
+
+func codePlaceholder(index int) string {
+	return "\x00code-" + strconv.Itoa(index) + "\x00"
+}
+
+// render writes the output of pieces[from:to] to the result.
+func (s *shortcodeServiceImpl) render(ctx context.Context, result *strings.Builder, pieces []any, from, to int) {
+	for i := from; i < to; i++ {
+		tag, ok := pieces[i].(*shortcodeTag)
+		if !ok {
+			result.WriteString(pieces[i].(string))
+			continue
+		}
+		if tag.closing {
+			// a closing tag without opening tag
+			result.WriteString(tag.source)
+			continue
+		}
+		if tag.closeIndex < 0 {
+			s.execute(ctx, result, tag, "")
+			continue
+		}
+		inner := &strings.Builder{}
+		s.render(ctx, inner, pieces, i+1, tag.closeIndex)
+		tag.shortcode.Inner = htmlTemplate.HTML(inner.String())
+		s.execute(ctx, result, tag, pieces[tag.closeIndex].(*shortcodeTag).source)
+		i = tag.closeIndex
+	}
+}
+
+func (s *shortcodeServiceImpl) execute(ctx context.Context, result *strings.Builder, tag *shortcodeTag, closeSource string) {
+	templateName, err := s.templateName(ctx, tag.shortcode.Name)
+	if err == nil && templateName != "" {
+		buf := &bytes.Buffer{}
+		err = s.Template.ExecuteTemplate(buf, templateName, template.Model{"shortcode": tag.shortcode})
+		if err == nil {
+			result.Write(buf.Bytes())
+			return
+		}
+	}
+	if err != nil {
+		log.CtxWarn(ctx, "render shortcode err", zap.String("name", tag.shortcode.Name), zap.Error(err))
+	}
+	// leave the shortcode as it is if it can not be rendered
+	result.WriteString(tag.source)
+	result.WriteString(string(tag.shortcode.Inner))
+	result.WriteString(closeSource)
+}
+
+// templateName returns the template of the shortcode in the activated theme, or the built-in one.
+func (s *shortcodeServiceImpl) templateName(ctx context.Context, name string) (string, error) {
+	themeTemplateName, err := s.ThemeService.Render(ctx, "shortcodes/"+name)
+	if err != nil {
+		return "", err
+	}
+	if s.Template.Exists(themeTemplateName) {
+		return themeTemplateName, nil
+	}
+	if s.Template.Exists(builtinShortcodePrefix + name) {
+		return builtinShortcodePrefix + name, nil
+	}
+	return "", nil
+}
+
+// parseShortcodes splits the content into text and shortcode tags.
+func parseShortcodes(source []byte) ([]any, error) {
+	result, err := pageparser.ParseMain(bytes.NewReader(source), pageparser.Config{})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]pageparser.Item, 0)
+	iterator := result.Iterator()
+	for item := iterator.Next(); !item.IsEOF(); item = iterator.Next() {
+		if item.IsError() {
+			return nil, item.Err
+		}
+		items = append(items, item)
+	}
+
+	pieces := make([]any, 0)
+	for i := 0; i < len(items); i++ {
+		if !items[i].IsLeftShortcodeDelim() {
+			pieces = append(pieces, items[i].ValStr(source))
+			continue
+		}
+		tag := &shortcodeTag{
+			shortcode:  &vo.Shortcode{Params: make(map[string]any)},
+			closeIndex: -1,
+		}
+		start := items[i].Pos()
+		for i++; i < len(items); i++ {
+			item := items[i]
+			if item.IsRightShortcodeDelim() {
+				tag.source = string(source[start : item.Pos()+len(item.Val(source))])
+				break
+			}
+			switch {
+			case item.IsShortcodeClose():
+				tag.closing = tag.shortcode.Name == ""
+				tag.selfClosing = !tag.closing
+			case item.IsShortcodeName():
+				tag.shortcode.Name = item.ValStr(source)
+			case item.IsInlineShortcodeName():
+				return nil, xerr.BadParam.New("inline shortcode %s is not supported", item.ValStr(source))
+			case item.IsShortcodeParam():
+				if i+1 < len(items) && items[i+1].IsShortcodeParamVal() {
+					tag.shortcode.Params[item.ValStr(source)] = items[i+1].ValTyped(source)
+					i++
+				} else {
+					tag.shortcode.Positional = append(tag.shortcode.Positional, item.ValTyped(source))
+				}
+			}
+		}
+		pieces = append(pieces, tag)
+	}
+	return pieces, nil
+}
@@ -0,0 +1,493 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/go-sonic/sonic/cache"
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	sitemapCacheKey = "sitemap"
+	// sitemapCacheTTL bounds the age of the cached sitemaps, they are evicted by the listener as soon as the content changes
+	sitemapCacheTTL = time.Hour
+	// sitemapMaxURLs and sitemapMaxBytes are the limits of a sitemap file, see https://www.sitemaps.org/protocol.html
+	sitemapMaxURLs  = 50000
+	sitemapMaxBytes = 50 * 1024 * 1024
+	// sitemapMaxImages is the max number of the images of a page
+	sitemapMaxImages = 1000
+	sitemapBatchSize = 1000
+
+	sitemapTypePosts      = "posts"
+	sitemapTypePages      = "pages"
+	sitemapTypeCategories = "categories"
+	sitemapTypeTags       = "tags"
+
+	sitemapHeader      = xml.Header + `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">`
+	sitemapFooter      = `</urlset>`
+	sitemapIndexHeader = xml.Header + `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`
+	sitemapIndexFooter = `</sitemapindex>`
+)
+
+var sitemapTypes = []string{sitemapTypePages, sitemapTypePosts, sitemapTypeCategories, sitemapTypeTags}
+
+type sitemapURL struct {
+	XMLName xml.Name       `xml:"url"`
+	Loc     string         `xml:"loc"`
+	LastMod string         `xml:"lastmod,omitempty"`
+	Images  []sitemapImage `xml:"image:image"`
+	// lastMod is used to compute the lastmod of the sitemap in the index
+	lastMod time.Time
+}
+
+type sitemapImage struct {
+	Loc string `xml:"image:loc"`
+}
+
+type sitemapRef struct {
+	XMLName xml.Name `xml:"sitemap"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+}
+
+// sitemaps is the cached output, the pages of the sitemaps are kept by type
+type sitemaps struct {
+	index []byte
+	pages map[string][][]byte
+}
+
+type sitemapServiceImpl struct {
+	OptionService       service.OptionService
+	PostService         service.PostService
+	SheetService        service.SheetService
+	CategoryService     service.CategoryService
+	PostCategoryService service.PostCategoryService
+	TagService          service.TagService
+	PostTagService      service.PostTagService
+	Cache               cache.Cache
+	// building keeps the sitemaps from being generated by several requests at the same time
+	building sync.Mutex
+	// generation is increased by every eviction, a build overlapping an eviction is not cached
+	generation atomic.Uint64
+}
+
+func NewSitemapService(
+	optionService service.OptionService,
+	postService service.PostService,
+	sheetService service.SheetService,
+	categoryService service.CategoryService,
+	postCategoryService service.PostCategoryService,
+	tagService service.TagService,
+	postTagService service.PostTagService,
+	cache cache.Cache,
+) service.SitemapService {
+	return &sitemapServiceImpl{
+		OptionService:       optionService,
+		PostService:         postService,
+		SheetService:        sheetService,
+		CategoryService:     categoryService,
+		PostCategoryService: postCategoryService,
+		TagService:          tagService,
+		PostTagService:      postTagService,
+		Cache:               cache,
+	}
+}
+
+func (s *sitemapServiceImpl) GetIndex(ctx context.Context) ([]byte, error) {
+	result, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result.index, nil
+}
+
+func (s *sitemapServiceImpl) GetSitemap(ctx context.Context, sitemapType string, page int) ([]byte, error) {
+	result, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pages := result.pages[sitemapType]
+	if page < 1 || page > len(pages) {
+		return nil, xerr.WithStatus(nil, xerr.StatusNotFound).WithMsg("The sitemap does not exist")
+	}
+	return pages[page-1], nil
+}
+
+func (s *sitemapServiceImpl) Evict() {
+	s.generation.Add(1)
+	s.Cache.Delete(sitemapCacheKey)
+}
+
+func (s *sitemapServiceImpl) get(ctx context.Context) (*sitemaps, error) {
+	if cached, ok := s.Cache.Get(sitemapCacheKey); ok {
+		return cached.(*sitemaps), nil
+	}
+	s.building.Lock()
+	defer s.building.Unlock()
+	if cached, ok := s.Cache.Get(sitemapCacheKey); ok {
+		return cached.(*sitemaps), nil
+	}
+	generation := s.generation.Load()
+	result, err := s.build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.generation.Load() == generation {
+		s.Cache.Set(sitemapCacheKey, result, sitemapCacheTTL)
+	}
+	return result, nil
+}
+
+func (s *sitemapServiceImpl) build(ctx context.Context) (*sitemaps, error) {
+	blogURL, err := s.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(blogURL + "/")
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid blog url")
+	}
+
+	posts, err := s.listPublicPosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postURLs, err := s.buildPostURLs(ctx, base, posts)
+	if err != nil {
+		return nil, err
+	}
+	sheets, err := s.listPublicSheets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pageURLs, err := s.buildPostURLs(ctx, base, sheets)
+	if err != nil {
+		return nil, err
+	}
+	home := &sitemapURL{Loc: base.String()}
+	for _, postURL := range postURLs {
+		if postURL.lastMod.After(home.lastMod) {
+			home.lastMod = postURL.lastMod
+		}
+	}
+	pageURLs = append([]*sitemapURL{home}, pageURLs...)
+	categoryURLs, err := s.buildCategoryURLs(ctx, base, posts)
+	if err != nil {
+		return nil, err
+	}
+	tagURLs, err := s.buildTagURLs(ctx, base, posts)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := map[string][]*sitemapURL{
+		sitemapTypePages:      pageURLs,
+		sitemapTypePosts:      postURLs,
+		sitemapTypeCategories: categoryURLs,
+		sitemapTypeTags:       tagURLs,
+	}
+	result := &sitemaps{pages: make(map[string][][]byte, len(urls))}
+	index := bytes.NewBufferString(sitemapIndexHeader)
+	for _, sitemapType := range sitemapTypes {
+		pages, lastMods, err := splitSitemap(urls[sitemapType])
+		if err != nil {
+			return nil, err
+		}
+		result.pages[sitemapType] = pages
+		for i := range pages {
+			ref := sitemapRef{Loc: blogURL + "/sitemap/" + sitemapType + "-" + strconv.Itoa(i+1) + ".xml"}
+			if !lastMods[i].IsZero() {
+				ref.LastMod = lastMods[i].Format(time.RFC3339)
+			}
+			encoded, err := xml.Marshal(ref)
+			if err != nil {
+				return nil, xerr.WithStatus(err, xerr.StatusInternalServerError)
+			}
+			index.Write(encoded)
+		}
+	}
+	index.WriteString(sitemapIndexFooter)
+	result.index = index.Bytes()
+	return result, nil
+}
+
+// splitSitemap encodes the urls into as many sitemaps as the limits of the count and the size require,
+// and returns the latest lastmod of every sitemap. A type without urls has no sitemap.
+func splitSitemap(urls []*sitemapURL) ([][]byte, []time.Time, error) {
+	pages := make([][]byte, 0)
+	lastMods := make([]time.Time, 0)
+	var page *bytes.Buffer
+	var count int
+	for _, u := range urls {
+		if !u.lastMod.IsZero() {
+			u.LastMod = u.lastMod.Format(time.RFC3339)
+		}
+		encoded, err := xml.Marshal(u)
+		if err != nil {
+			return nil, nil, xerr.WithStatus(err, xerr.StatusInternalServerError)
+		}
+		if page == nil || count == sitemapMaxURLs || page.Len()+len(encoded)+len(sitemapFooter) > sitemapMaxBytes {
+			if page != nil {
+				page.WriteString(sitemapFooter)
+				pages = append(pages, page.Bytes())
+			}
+			page = bytes.NewBufferString(sitemapHeader)
+			count = 0
+			lastMods = append(lastMods, time.Time{})
+		}
+		page.Write(encoded)
+		count++
+		if u.lastMod.After(lastMods[len(lastMods)-1]) {
+			lastMods[len(lastMods)-1] = u.lastMod
+		}
+	}
+	if page != nil {
+		page.WriteString(sitemapFooter)
+		pages = append(pages, page.Bytes())
+	}
+	return pages, lastMods, nil
+}
+
+// listPublicPosts lists the published posts which are not protected by a password.
+func (s *sitemapServiceImpl) listPublicPosts(ctx context.Context) ([]*entity.Post, error) {
+	result := make([]*entity.Post, 0)
+	for pageNum := 0; ; pageNum++ {
+		posts, _, err := s.PostService.Page(ctx, param.PostQuery{
+			Page:     param.Page{PageNum: pageNum, PageSize: sitemapBatchSize},
+			Sort:     &param.Sort{Fields: []string{"createTime,desc"}},
+			Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			if post.Password == "" {
+				result = append(result, post)
+			}
+		}
+		if len(posts) < sitemapBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (s *sitemapServiceImpl) listPublicSheets(ctx context.Context) ([]*entity.Post, error) {
+	result := make([]*entity.Post, 0)
+	for pageNum := 0; ; pageNum++ {
+		sheets, _, err := s.SheetService.Page(ctx, param.Page{PageNum: pageNum, PageSize: sitemapBatchSize}, &param.Sort{Fields: []string{"createTime,desc"}})
+		if err != nil {
+			return nil, err
+		}
+		for _, sheet := range sheets {
+			if sheet.Status == consts.PostStatusPublished && sheet.Password == "" {
+				result = append(result, sheet)
+			}
+		}
+		if len(sheets) < sitemapBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func (s *sitemapServiceImpl) buildPostURLs(ctx context.Context, base *url.URL, posts []*entity.Post) ([]*sitemapURL, error) {
+	urls := make([]*sitemapURL, 0, len(posts))
+	for _, post := range posts {
+		fullPath, err := s.PostService.BuildFullPath(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, &sitemapURL{
+			Loc:     util.ResolveURL(base, fullPath),
+			Images:  extractSitemapImages(base, post.Thumbnail, post.FormatContent),
+			lastMod: postLastModified(post),
+		})
+	}
+	return urls, nil
+}
+
+// buildCategoryURLs lists the categories which are not encrypted, a category is modified when its posts are.
+func (s *sitemapServiceImpl) buildCategoryURLs(ctx context.Context, base *url.URL, posts []*entity.Post) ([]*sitemapURL, error) {
+	categories, err := s.CategoryService.ListAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	categoryMap := make(map[int32]*entity.Category, len(categories))
+	for _, category := range categories {
+		categoryMap[category.ID] = category
+	}
+	lastMods, err := s.contentLastModified(ctx, posts, func(postIDs []int32) (map[int32][]int32, error) {
+		postCategories, err := s.PostCategoryService.ListByPostIDs(ctx, postIDs)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[int32][]int32)
+		for _, postCategory := range postCategories {
+			result[postCategory.PostID] = append(result[postCategory.PostID], postCategory.CategoryID)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	public := make([]*entity.Category, 0, len(categories))
+	for _, category := range categories {
+		if !isCategoryEncrypted(category, categoryMap) {
+			public = append(public, category)
+		}
+	}
+	categoryDTOs, err := s.CategoryService.ConvertToCategoryDTOs(ctx, public)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]*sitemapURL, 0, len(public))
+	for i, category := range public {
+		urls = append(urls, &sitemapURL{
+			Loc:     util.ResolveURL(base, categoryDTOs[i].FullPath),
+			Images:  extractSitemapImages(base, category.Thumbnail, ""),
+			lastMod: latestTime(lastMods[category.ID], category.UpdateTime, category.CreateTime),
+		})
+	}
+	return urls, nil
+}
+
+// isCategoryEncrypted reports whether the category or one of its parents is encrypted.
+func isCategoryEncrypted(category *entity.Category, categoryMap map[int32]*entity.Category) bool {
+	for visited := 0; category != nil && visited <= len(categoryMap); visited++ {
+		if category.Type == consts.CategoryTypeIntimate || category.Password != "" {
+			return true
+		}
+		category = categoryMap[category.ParentID]
+	}
+	return false
+}
+
+func (s *sitemapServiceImpl) buildTagURLs(ctx context.Context, base *url.URL, posts []*entity.Post) ([]*sitemapURL, error) {
+	tags, err := s.TagService.ListAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	lastMods, err := s.contentLastModified(ctx, posts, func(postIDs []int32) (map[int32][]int32, error) {
+		tagMap, err := s.PostTagService.ListTagMapByPostID(ctx, postIDs)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[int32][]int32, len(tagMap))
+		for postID, postTags := range tagMap {
+			for _, tag := range postTags {
+				result[postID] = append(result[postID], tag.ID)
+			}
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	tagDTOs, err := s.TagService.ConvertToDTOs(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]*sitemapURL, 0, len(tags))
+	for i, tag := range tags {
+		urls = append(urls, &sitemapURL{
+			Loc:     util.ResolveURL(base, tagDTOs[i].FullPath),
+			Images:  extractSitemapImages(base, tag.Thumbnail, ""),
+			lastMod: latestTime(lastMods[tag.ID], tag.UpdateTime, tag.CreateTime),
+		})
+	}
+	return urls, nil
+}
+
+// contentLastModified returns the latest modification of the posts of every category or tag,
+// listContentIDs maps the posts to the categories or the tags.
+func (s *sitemapServiceImpl) contentLastModified(ctx context.Context, posts []*entity.Post, listContentIDs func(postIDs []int32) (map[int32][]int32, error)) (map[int32]time.Time, error) {
+	result := make(map[int32]time.Time)
+	for start := 0; start < len(posts); start += sitemapBatchSize {
+		batch := posts[start:min(start+sitemapBatchSize, len(posts))]
+		postIDs := make([]int32, 0, len(batch))
+		for _, post := range batch {
+			postIDs = append(postIDs, post.ID)
+		}
+		contentIDs, err := listContentIDs(postIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range batch {
+			lastMod := postLastModified(post)
+			for _, contentID := range contentIDs[post.ID] {
+				if lastMod.After(result[contentID]) {
+					result[contentID] = lastMod
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func postLastModified(post *entity.Post) time.Time {
+	if post.EditTime != nil {
+		return *post.EditTime
+	}
+	return latestTime(time.Time{}, post.UpdateTime, post.CreateTime)
+}
+
+// latestTime returns the later one of the last modified time of the contents and the time of the item itself.
+func latestTime(lastMod time.Time, updateTime *time.Time, createTime time.Time) time.Time {
+	itemTime := createTime
+	if updateTime != nil {
+		itemTime = *updateTime
+	}
+	if lastMod.After(itemTime) {
+		return lastMod
+	}
+	return itemTime
+}
+
+// extractSitemapImages lists the thumbnail and the images embedded in the content, without duplicates.
+func extractSitemapImages(base *url.URL, thumbnail string, content string) []sitemapImage {
+	images := make([]sitemapImage, 0)
+	seen := make(map[string]struct{})
+	add := func(src string) {
+		src = strings.TrimSpace(src)
+		if src == "" || strings.HasPrefix(src, "data:") || len(images) >= sitemapMaxImages {
+			return
+		}
+		src = util.ResolveURL(base, src)
+		if _, ok := seen[src]; ok {
+			return
+		}
+		seen[src] = struct{}{}
+		images = append(images, sitemapImage{Loc: src})
+	}
+	add(thumbnail)
+	if content == "" {
+		return images
+	}
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return images
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data == "img" {
+				add(getAttr(&token, "src"))
+			}
+		}
+	}
+}
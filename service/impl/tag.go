@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/event"
 	"github.com/go-sonic/sonic/model/dto"
 	"github.com/go-sonic/sonic/model/entity"
 	"github.com/go-sonic/sonic/model/param"
@@ -17,11 +18,13 @@ import (
 
 type tagServiceImpl struct {
 	OptionService service.OptionService
+	Event         event.Bus
 }
 
-func NewTagService(optionService service.OptionService) service.TagService {
+func NewTagService(optionService service.OptionService, event event.Bus) service.TagService {
 	return &tagServiceImpl{
 		OptionService: optionService,
+		Event:         event,
 	}
 }
 
@@ -57,6 +60,7 @@ func (t tagServiceImpl) Create(ctx context.Context, tagParam *param.Tag) (*entit
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	t.Event.Publish(ctx, &event.TagUpdateEvent{})
 	return tag, nil
 }
 
@@ -86,6 +90,7 @@ func (t tagServiceImpl) Update(ctx context.Context, id int32, tagParam *param.Ta
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	t.Event.Publish(ctx, &event.TagUpdateEvent{})
 	return tag, nil
 }
 
@@ -101,8 +106,11 @@ func (t tagServiceImpl) Delete(ctx context.Context, id int32) error {
 		_, err = postTagDAL.WithContext(txCtx).Where(postTagDAL.TagID.Eq(id)).Delete()
 		return err
 	})
-
-	return err
+	if err != nil {
+		return err
+	}
+	t.Event.Publish(ctx, &event.TagUpdateEvent{})
+	return nil
 }
 
 func (t tagServiceImpl) ListAll(ctx context.Context, sort *param.Sort) ([]*entity.Tag, error) {
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/go-sonic/sonic/config"
+	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/dal"
 	"github.com/go-sonic/sonic/event"
 	"github.com/go-sonic/sonic/model/dto"
@@ -207,6 +208,34 @@ func (t *themeServiceImpl) ListCustomTemplates(ctx context.Context, themeID, pre
 	return result, nil
 }
 
+func (t *themeServiceImpl) ListMetaFields(ctx context.Context, themeID string, postType consts.PostType) ([]*dto.MetaField, error) {
+	themeProperty, err := t.PropertyScanner.GetThemeByThemeID(ctx, themeID)
+	if err != nil {
+		return nil, err
+	}
+	if themeProperty == nil {
+		return nil, xerr.WithMsg(nil, "theme does not exist").WithStatus(xerr.StatusInternalServerError)
+	}
+	schema := themeProperty.PostMetaSchema
+	if postType == consts.PostTypeSheet {
+		schema = themeProperty.SheetMetaSchema
+	}
+	// the fields are copied, the theme property is cached and shared
+	metaFields := make([]*dto.MetaField, 0, len(schema))
+	for _, field := range schema {
+		metaField := *field
+		// number and switch inputs are not strings even if the data type is omitted
+		switch {
+		case metaField.InputType == consts.ThemeConfigInputTypeSWITCH:
+			metaField.DataType = consts.ThemeConfigDataTypeBool
+		case metaField.InputType == consts.ThemeConfigInputTypeNUMBER && metaField.DataType == consts.ThemeConfigDataTypeString:
+			metaField.DataType = consts.ThemeConfigDataTypeDouble
+		}
+		metaFields = append(metaFields, &metaField)
+	}
+	return metaFields, nil
+}
+
 func (t *themeServiceImpl) ActivateTheme(ctx context.Context, themeID string) (*dto.ThemeProperty, error) {
 	err := t.OptionService.Save(ctx, map[string]string{property.Theme.KeyValue: themeID})
 	if err != nil {
@@ -0,0 +1,420 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gen/field"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	webhookMaxAttempts    = 5
+	webhookRetryBaseDelay = time.Minute
+	webhookRetryInterval  = time.Second * 30
+	// webhookLease keeps the retry loop from sending a delivery which is being sent
+	webhookLease         = time.Minute * 2
+	webhookTimeout       = time.Second * 15
+	webhookMaxTextLength = 1023
+	// webhookSecretMask replaces the secret in the responses, it keeps the secret when it is sent back
+	webhookSecretMask = "******"
+	webhookWorkers    = 4
+	webhookQueueSize  = 256
+)
+
+type webhookServiceImpl struct {
+	HTTPClient service.HTTPClient
+	// sender sends the deliveries in the background, the deliveries left out when it is full are sent by the retry loop
+	sender *util.WorkerPool
+}
+
+// NewWebhookService sends the deliveries with a client of its own rather than the shared one refusing the private addresses,
+// the webhooks are set by the administrator and are often on the internal network, such as a CI or a chat bot.
+func NewWebhookService(lifecycle fx.Lifecycle) service.WebhookService {
+	w := &webhookServiceImpl{
+		HTTPClient: &http.Client{Timeout: webhookTimeout},
+		sender:     util.NewWorkerPool(webhookWorkers, webhookQueueSize),
+	}
+	runPeriodically(lifecycle, webhookRetryInterval, w.retryDueDeliveries)
+	return w
+}
+
+type webhookPayload struct {
+	Event     string      `json:"event"`
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+func (w *webhookServiceImpl) List(ctx context.Context) ([]*entity.Webhook, error) {
+	webhookDAL := dal.GetQueryByCtx(ctx).Webhook
+	webhooks, err := webhookDAL.WithContext(ctx).Order(webhookDAL.ID).Find()
+	return webhooks, WrapDBErr(err)
+}
+
+func (w *webhookServiceImpl) Create(ctx context.Context, webhookParam *param.Webhook) (*entity.Webhook, error) {
+	webhook, err := w.convertParam(webhookParam)
+	if err != nil {
+		return nil, err
+	}
+	// the mask sent back by a form copied from another webhook is not a secret
+	if webhook.Secret == webhookSecretMask {
+		webhook.Secret = ""
+	}
+	webhookDAL := dal.GetQueryByCtx(ctx).Webhook
+	err = webhookDAL.WithContext(ctx).Create(webhook)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return webhook, nil
+}
+
+func (w *webhookServiceImpl) Update(ctx context.Context, id int32, webhookParam *param.Webhook) (*entity.Webhook, error) {
+	webhook, err := w.convertParam(webhookParam)
+	if err != nil {
+		return nil, err
+	}
+	webhookDAL := dal.GetQueryByCtx(ctx).Webhook
+	columns := []field.AssignExpr{
+		webhookDAL.Name.Value(webhook.Name),
+		webhookDAL.URL.Value(webhook.URL),
+		webhookDAL.Events.Value(webhook.Events),
+		webhookDAL.Enabled.Value(webhook.Enabled),
+		webhookDAL.UpdateTime.Value(time.Now()),
+	}
+	if webhook.Secret != webhookSecretMask {
+		columns = append(columns, webhookDAL.Secret.Value(webhook.Secret))
+	}
+	updateResult, err := webhookDAL.WithContext(ctx).Where(webhookDAL.ID.Eq(id)).UpdateSimple(columns...)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	if updateResult.RowsAffected != 1 {
+		return nil, xerr.NoRecord.New("webhook id=%d", id).WithMsg("webhook does not exist").WithStatus(xerr.StatusNotFound)
+	}
+	webhook, err = webhookDAL.WithContext(ctx).Where(webhookDAL.ID.Eq(id)).First()
+	return webhook, WrapDBErr(err)
+}
+
+func (w *webhookServiceImpl) Delete(ctx context.Context, id int32) error {
+	return dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
+		webhookDAL := tx.Webhook
+		deleteResult, err := webhookDAL.WithContext(ctx).Where(webhookDAL.ID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if deleteResult.RowsAffected != 1 {
+			return xerr.NoRecord.New("webhook id=%d", id).WithMsg("webhook does not exist").WithStatus(xerr.StatusNotFound)
+		}
+		deliveryDAL := tx.WebhookDelivery
+		_, err = deliveryDAL.WithContext(ctx).Where(deliveryDAL.WebhookID.Eq(id)).Delete()
+		return WrapDBErr(err)
+	})
+}
+
+func (w *webhookServiceImpl) Trigger(ctx context.Context, webhookEvent string, data interface{}) error {
+	webhookDAL := dal.GetQueryByCtx(ctx).Webhook
+	webhooks, err := webhookDAL.WithContext(ctx).Where(webhookDAL.Enabled.Is(true)).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	subscribers := make([]*entity.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		for _, e := range splitWebhookEvents(webhook.Events) {
+			if e == webhookEvent {
+				subscribers = append(subscribers, webhook)
+				break
+			}
+		}
+	}
+	if len(subscribers) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(&webhookPayload{
+		Event:     webhookEvent,
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	})
+	if err != nil {
+		return xerr.WithStatus(err, xerr.StatusInternalServerError)
+	}
+	for _, webhook := range subscribers {
+		delivery, err := w.createDelivery(ctx, webhook.ID, webhookEvent, string(payload))
+		if err != nil {
+			return err
+		}
+		w.sendInBackground(ctx, webhook, delivery)
+	}
+	return nil
+}
+
+func (w *webhookServiceImpl) sendInBackground(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) {
+	queued := w.sender.TrySubmit(func() {
+		w.send(context.Background(), webhook, delivery)
+	})
+	if !queued {
+		log.CtxWarn(ctx, "webhook queue is full, the delivery is left to the retry", zap.Int32("deliveryID", delivery.ID))
+	}
+}
+
+func (w *webhookServiceImpl) PageDeliveries(ctx context.Context, webhookID int32, page param.Page) ([]*entity.WebhookDelivery, int64, error) {
+	deliveryDAL := dal.GetQueryByCtx(ctx).WebhookDelivery
+	deliveries, totalCount, err := deliveryDAL.WithContext(ctx).Where(deliveryDAL.WebhookID.Eq(webhookID)).Order(deliveryDAL.ID.Desc()).FindByPage(page.PageNum*page.PageSize, page.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return deliveries, totalCount, nil
+}
+
+func (w *webhookServiceImpl) Redeliver(ctx context.Context, deliveryID int32) (*entity.WebhookDelivery, error) {
+	deliveryDAL := dal.GetQueryByCtx(ctx).WebhookDelivery
+	delivery, err := deliveryDAL.WithContext(ctx).Where(deliveryDAL.ID.Eq(deliveryID)).First()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	webhookDAL := dal.GetQueryByCtx(ctx).Webhook
+	webhook, err := webhookDAL.WithContext(ctx).Where(webhookDAL.ID.Eq(delivery.WebhookID)).First()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	redelivery, err := w.createDelivery(ctx, webhook.ID, delivery.Event, delivery.Payload)
+	if err != nil {
+		return nil, err
+	}
+	w.send(ctx, webhook, redelivery)
+	return redelivery, nil
+}
+
+func (w *webhookServiceImpl) createDelivery(ctx context.Context, webhookID int32, webhookEvent, payload string) (*entity.WebhookDelivery, error) {
+	// the delivery is sent right away, the lease keeps the retry loop from sending it at the same time
+	nextRetryTime := time.Now().Add(webhookLease)
+	delivery := &entity.WebhookDelivery{
+		WebhookID:     webhookID,
+		Event:         webhookEvent,
+		Payload:       payload,
+		Status:        consts.WebhookDeliveryStatusPending,
+		NextRetryTime: &nextRetryTime,
+	}
+	deliveryDAL := dal.GetQueryByCtx(ctx).WebhookDelivery
+	err := deliveryDAL.WithContext(ctx).Create(delivery)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return delivery, nil
+}
+
+// send posts the payload and records the result, the delivery is retried later if it fails.
+func (w *webhookServiceImpl) send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) {
+	responseStatus, responseBody, err := w.post(ctx, webhook, delivery)
+	delivery.Attempts++
+	delivery.ResponseStatus = int32(responseStatus)
+	delivery.ResponseBody = truncateRunes(responseBody, webhookMaxTextLength)
+	delivery.Error = ""
+	delivery.UpdateTime = util.TimePtr(time.Now())
+	if err == nil && (responseStatus < http.StatusOK || responseStatus >= http.StatusMultipleChoices) {
+		err = xerr.NoType.New("unexpected response status %d", responseStatus)
+	}
+	switch {
+	case err == nil:
+		delivery.Status = consts.WebhookDeliveryStatusSuccess
+		delivery.NextRetryTime = nil
+	case delivery.Attempts >= webhookMaxAttempts:
+		delivery.Status = consts.WebhookDeliveryStatusFailed
+		delivery.NextRetryTime = nil
+	default:
+		nextRetryTime := time.Now().Add(webhookRetryBaseDelay << (delivery.Attempts - 1))
+		delivery.Status = consts.WebhookDeliveryStatusPending
+		delivery.NextRetryTime = &nextRetryTime
+	}
+	if err != nil {
+		delivery.Error = truncateRunes(err.Error(), webhookMaxTextLength)
+		log.CtxWarn(ctx, "webhook delivery failed", zap.Int32("deliveryID", delivery.ID), zap.String("url", webhook.URL), zap.Error(err))
+	}
+	deliveryDAL := dal.GetQueryByCtx(ctx).WebhookDelivery
+	if err := deliveryDAL.WithContext(ctx).Save(delivery); err != nil {
+		log.CtxError(ctx, "save webhook delivery err", zap.Int32("deliveryID", delivery.ID), zap.Error(err))
+	}
+}
+
+func (w *webhookServiceImpl) post(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion+" webhook")
+	req.Header.Set(consts.WebhookEventHeader, delivery.Event)
+	req.Header.Set(consts.WebhookDeliveryHeader, strconv.Itoa(int(delivery.ID)))
+	if webhook.Secret != "" {
+		req.Header.Set(consts.WebhookSignatureHeader, consts.WebhookSignatureSchemePrefix+signWebhookPayload(webhook.Secret, delivery.Payload))
+	}
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4*webhookMaxTextLength))
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// retryDueDeliveries sends the pending deliveries whose retry time has come, they survive restarts as they are kept in the database.
+func (w *webhookServiceImpl) retryDueDeliveries(ctx context.Context) {
+	now := time.Now()
+	deliveryDAL := dal.GetQueryByCtx(ctx).WebhookDelivery
+	deliveries, err := deliveryDAL.WithContext(ctx).Where(
+		deliveryDAL.Status.Eq(consts.WebhookDeliveryStatusPending),
+		deliveryDAL.NextRetryTime.Lte(now),
+	).Order(deliveryDAL.NextRetryTime).Limit(100).Find()
+	if err != nil {
+		log.CtxError(ctx, "list due webhook deliveries err", zap.Error(err))
+		return
+	}
+	for _, delivery := range deliveries {
+		// claim the delivery so that it is not sent twice
+		updateResult, err := deliveryDAL.WithContext(ctx).Where(
+			deliveryDAL.ID.Eq(delivery.ID),
+			deliveryDAL.Status.Eq(consts.WebhookDeliveryStatusPending),
+			deliveryDAL.NextRetryTime.Lte(now),
+		).UpdateSimple(deliveryDAL.NextRetryTime.Value(now.Add(webhookLease)))
+		if err != nil || updateResult.RowsAffected != 1 {
+			continue
+		}
+		webhookDAL := dal.GetQueryByCtx(ctx).Webhook
+		webhook, err := webhookDAL.WithContext(ctx).Where(webhookDAL.ID.Eq(delivery.WebhookID)).First()
+		if err != nil || !webhook.Enabled {
+			_, err = deliveryDAL.WithContext(ctx).Where(deliveryDAL.ID.Eq(delivery.ID)).UpdateSimple(
+				deliveryDAL.Status.Value(consts.WebhookDeliveryStatusFailed),
+				deliveryDAL.Error.Value("the webhook is disabled or deleted"),
+				deliveryDAL.UpdateTime.Value(time.Now()),
+			)
+			if err != nil {
+				log.CtxError(ctx, "update webhook delivery err", zap.Int32("deliveryID", delivery.ID), zap.Error(err))
+			}
+			continue
+		}
+		w.send(ctx, webhook, delivery)
+	}
+}
+
+func (w *webhookServiceImpl) convertParam(webhookParam *param.Webhook) (*entity.Webhook, error) {
+	events := make([]string, 0, len(webhookParam.Events))
+	for _, e := range webhookParam.Events {
+		supported := false
+		for _, supportedEvent := range consts.WebhookEvents {
+			supported = supported || e == supportedEvent
+		}
+		if !supported {
+			return nil, xerr.BadParam.New("event=%s", e).WithMsg("unsupported webhook event: " + e).WithStatus(xerr.StatusBadRequest)
+		}
+		duplicate := false
+		for _, existed := range events {
+			duplicate = duplicate || e == existed
+		}
+		if !duplicate {
+			events = append(events, e)
+		}
+	}
+	return &entity.Webhook{
+		Name:    strings.TrimSpace(webhookParam.Name),
+		URL:     strings.TrimSpace(webhookParam.URL),
+		Secret:  webhookParam.Secret,
+		Events:  strings.Join(events, ","),
+		Enabled: webhookParam.Enabled,
+	}, nil
+}
+
+func (w *webhookServiceImpl) ConvertToDTO(webhook *entity.Webhook) *dto.Webhook {
+	return &dto.Webhook{
+		ID:         webhook.ID,
+		Name:       webhook.Name,
+		URL:        webhook.URL,
+		Secret:     util.IfElse(webhook.Secret == "", "", webhookSecretMask).(string),
+		Events:     splitWebhookEvents(webhook.Events),
+		Enabled:    webhook.Enabled,
+		CreateTime: webhook.CreateTime.UnixMilli(),
+	}
+}
+
+func (w *webhookServiceImpl) ConvertToDTOs(webhooks []*entity.Webhook) []*dto.Webhook {
+	result := make([]*dto.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, w.ConvertToDTO(webhook))
+	}
+	return result
+}
+
+func (w *webhookServiceImpl) ConvertToDeliveryDTOs(deliveries []*entity.WebhookDelivery) []*dto.WebhookDelivery {
+	result := make([]*dto.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryDTO := &dto.WebhookDelivery{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			ResponseBody:   delivery.ResponseBody,
+			Error:          delivery.Error,
+			CreateTime:     delivery.CreateTime.UnixMilli(),
+		}
+		if delivery.NextRetryTime != nil && delivery.Status == consts.WebhookDeliveryStatusPending {
+			nextRetryTime := delivery.NextRetryTime.UnixMilli()
+			deliveryDTO.NextRetryTime = &nextRetryTime
+		}
+		if delivery.UpdateTime != nil {
+			updateTime := delivery.UpdateTime.UnixMilli()
+			deliveryDTO.UpdateTime = &updateTime
+		}
+		result = append(result, deliveryDTO)
+	}
+	return result
+}
+
+func splitWebhookEvents(events string) []string {
+	result := make([]string, 0)
+	for _, e := range strings.Split(events, ",") {
+		if e != "" {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func signWebhookPayload(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,545 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/dal"
+	"github.com/go-sonic/sonic/event"
+	"github.com/go-sonic/sonic/log"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/property"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/util"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+const (
+	webmentionPath    = "/api/content/webmention"
+	webmentionTimeout = 15 * time.Second
+	// webmentionMaxBody is the max size of the pages read for the webmentions
+	webmentionMaxBody = 1 << 20
+)
+
+type webmentionServiceImpl struct {
+	OptionService       service.OptionService
+	BasePostService     service.BasePostService
+	CommentBlackService service.CommentBlackService
+	CommentSpamService  service.CommentSpamService
+	HTTPClient          service.HTTPClient
+	Event               event.Bus
+}
+
+func NewWebmentionService(optionService service.OptionService,
+	basePostService service.BasePostService,
+	commentBlackService service.CommentBlackService,
+	commentSpamService service.CommentSpamService,
+	httpClient service.HTTPClient,
+	event event.Bus,
+) service.WebmentionService {
+	return &webmentionServiceImpl{
+		OptionService:       optionService,
+		BasePostService:     basePostService,
+		CommentBlackService: commentBlackService,
+		CommentSpamService:  commentSpamService,
+		HTTPClient:          httpClient,
+		Event:               event,
+	}
+}
+
+func (w *webmentionServiceImpl) GetEndpoint(ctx context.Context) (string, error) {
+	enabled, err := w.OptionService.GetOrByDefaultWithErr(ctx, property.WebmentionEnabled, false)
+	if err != nil || !enabled.(bool) {
+		return "", err
+	}
+	blogBaseURL, err := w.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", err
+	}
+	return blogBaseURL + webmentionPath, nil
+}
+
+// Receive checks the request and verifies the mention in the background as the spec allows.
+// The result of the verification is not reported to the sender, so the endpoint cannot be used to probe the pages the server can reach.
+func (w *webmentionServiceImpl) Receive(ctx context.Context, source string, target string) error {
+	endpoint, err := w.GetEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if endpoint == "" {
+		return xerr.Forbidden.New("").WithMsg("Webmention is disabled").WithStatus(xerr.StatusForbidden)
+	}
+	sourceURL, err := parseWebmentionURL(source)
+	if err != nil {
+		return err
+	}
+	targetURL, err := parseWebmentionURL(target)
+	if err != nil {
+		return err
+	}
+	if sourceURL.String() == targetURL.String() {
+		return xerr.BadParam.New("").WithMsg("The source and the target are the same").WithStatus(xerr.StatusBadRequest)
+	}
+	post, err := w.resolveTarget(ctx, targetURL)
+	if err != nil {
+		return err
+	}
+	ipAddress, userAgent := util.GetClientIP(ctx), util.GetUserAgent(ctx)
+	go func() {
+		ctx := context.Background()
+		if err := w.verify(ctx, sourceURL, targetURL, post, ipAddress, userAgent); err != nil {
+			log.CtxWarn(ctx, "verify webmention err", zap.String("source", sourceURL.String()), zap.String("target", targetURL.String()), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// verify checks that the source links to the target and stores the mention as a comment,
+// the mention is removed if the source no longer links to the target.
+func (w *webmentionServiceImpl) verify(ctx context.Context, sourceURL, targetURL *url.URL, post *entity.Post, ipAddress, userAgent string) error {
+	commentType := util.IfElse(post.Type == consts.PostTypeSheet, consts.CommentTypeSheet, consts.CommentTypePost).(consts.CommentType)
+	existing, err := w.getMention(ctx, commentType, post.ID, sourceURL.String())
+	if err != nil {
+		return err
+	}
+
+	resp, body, err := w.fetch(ctx, sourceURL.String())
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusGone {
+		return w.removeMention(ctx, existing)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return xerr.NoType.New("source status=%v", resp.StatusCode)
+	}
+	var doc *html.Node
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "text/html" || mediaType == "application/xhtml+xml" {
+		doc, err = html.Parse(bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+	}
+	if !linksTo(resp.Request.URL, doc, body, targetURL) {
+		return w.removeMention(ctx, existing)
+	}
+	if post.DisallowComment {
+		return nil
+	}
+
+	mention := buildMention(sourceURL, resp.Request.URL, doc)
+	mention.Type = commentType
+	mention.PostID = post.ID
+	mention.IPAddress = ipAddress
+	mention.UserAgent = userAgent
+	if existing != nil && existing.Content == mention.Content && existing.Author == mention.Author && existing.AuthorURL == mention.AuthorURL {
+		return nil
+	}
+	if err := moderateRemoteComment(ctx, w.CommentBlackService, w.CommentSpamService, mention); err != nil {
+		return err
+	}
+	if existing != nil {
+		return w.updateMention(ctx, existing, mention)
+	}
+	return w.createMention(ctx, mention)
+}
+
+func parseWebmentionURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, xerr.BadParam.New("url=%v", rawURL).WithMsg("Invalid url").WithStatus(xerr.StatusBadRequest)
+	}
+	u.Fragment = ""
+	return u, nil
+}
+
+// resolveTarget finds the published post or sheet of the target url.
+// The id or the slug is parsed from the url the way the permalink types build it, and the full path of the post found must be the target.
+func (w *webmentionServiceImpl) resolveTarget(ctx context.Context, target *url.URL) (*entity.Post, error) {
+	notFound := xerr.BadParam.New("target=%v", target).WithMsg("The target is not a post of this site").WithStatus(xerr.StatusBadRequest)
+	blogBaseURL, err := w.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := url.Parse(blogBaseURL + "/")
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid blog url")
+	}
+	if !strings.EqualFold(target.Host, baseURL.Host) {
+		return nil, notFound
+	}
+
+	var post *entity.Post
+	if id := target.Query().Get("p"); id != "" {
+		post, err = w.getPostByID(ctx, id)
+	} else {
+		post, err = w.getPostByPath(ctx, strings.TrimSuffix(target.Path, "/"))
+	}
+	if xerr.GetType(err) == xerr.NoRecord || (err == nil && (post == nil || post.Status != consts.PostStatusPublished)) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	fullPath, err := w.BasePostService.BuildFullPath(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	postURL, err := baseURL.Parse(fullPath)
+	if err != nil {
+		return nil, notFound
+	}
+	if strings.TrimSuffix(postURL.Path, "/") != strings.TrimSuffix(target.Path, "/") || postURL.Query().Get("p") != target.Query().Get("p") {
+		return nil, notFound
+	}
+	return post, nil
+}
+
+func (w *webmentionServiceImpl) getPostByID(ctx context.Context, id string) (*entity.Post, error) {
+	postID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return nil, nil
+	}
+	return w.BasePostService.GetByPostID(ctx, int32(postID))
+}
+
+// getPostByPath finds the post by the last segment of the path, which is the slug of all the permalink types but ID_SLUG,
+// the segment of ID_SLUG is the id followed by the slug.
+func (w *webmentionServiceImpl) getPostByPath(ctx context.Context, urlPath string) (*entity.Post, error) {
+	pathSuffix, err := w.OptionService.GetPathSuffix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	segment := path.Base(urlPath)
+	post, err := w.BasePostService.GetBySlug(ctx, strings.TrimSuffix(segment, pathSuffix))
+	if xerr.GetType(err) != xerr.NoRecord {
+		return post, err
+	}
+	permalinkType, err := w.OptionService.GetPostPermalinkType(ctx)
+	if err != nil || permalinkType != consts.PostPermalinkTypeIDSlug {
+		return nil, err
+	}
+	// the slug may start with digits too, so every split of the leading digits is tried
+	for i := 1; i <= len(segment) && i <= 10 && segment[i-1] >= '0' && segment[i-1] <= '9'; i++ {
+		post, err := w.getPostByID(ctx, segment[:i])
+		if xerr.GetType(err) == xerr.NoRecord {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if post != nil && post.Type == consts.PostTypePost && post.Slug == segment[i:] {
+			return post, nil
+		}
+	}
+	return nil, nil
+}
+
+func (w *webmentionServiceImpl) getMention(ctx context.Context, commentType consts.CommentType, contentID int32, source string) (*entity.Comment, error) {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	comment, err := commentDAL.WithContext(ctx).Where(
+		commentDAL.Type.Eq(commentType),
+		commentDAL.PostID.Eq(contentID),
+		commentDAL.Kind.Eq(consts.CommentKindWebmention),
+		commentDAL.SourceURL.Eq(source),
+	).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return comment, WrapDBErr(err)
+}
+
+func (w *webmentionServiceImpl) fetch(ctx context.Context, rawURL string) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, webmentionTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion+" webmention")
+	req.Header.Set("Accept", "text/html, */*;q=0.5")
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webmentionMaxBody))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.Request == nil {
+		resp.Request = req
+	}
+	return resp, body, nil
+}
+
+// linksTo checks the links of the html page, or looks for the url in the text of other pages.
+func linksTo(pageURL *url.URL, doc *html.Node, body []byte, target *url.URL) bool {
+	if doc == nil {
+		return bytes.Contains(body, []byte(target.String()))
+	}
+	found := findNode(doc, func(n *html.Node) bool {
+		var ref string
+		switch n.DataAtom {
+		case atom.A, atom.Link:
+			ref = nodeAttr(n, "href")
+		case atom.Img, atom.Video, atom.Audio:
+			ref = nodeAttr(n, "src")
+		default:
+			return false
+		}
+		u, err := pageURL.Parse(strings.TrimSpace(ref))
+		if err != nil {
+			return false
+		}
+		u.Fragment = ""
+		return u.String() == target.String()
+	})
+	return found != nil
+}
+
+// buildMention fills the comment with the h-entry of the source, or with the title of the page if there is none.
+// The fields are escaped like the comments posted by visitors.
+func buildMention(sourceURL, pageURL *url.URL, doc *html.Node) *entity.Comment {
+	mention := &entity.Comment{
+		Kind:      consts.CommentKindWebmention,
+		SourceURL: sourceURL.String(),
+		Author:    sourceURL.Hostname(),
+		AuthorURL: sourceURL.Scheme + "://" + sourceURL.Host,
+		Status:    consts.CommentStatusAuditing,
+	}
+	var content string
+	if entry := parseHEntry(doc, pageURL); entry != nil {
+		content = util.IfElse(entry.Content != "", entry.Content, util.IfElse(entry.Summary != "", entry.Summary, entry.Name)).(string)
+		if entry.AuthorName != "" {
+			mention.Author = entry.AuthorName
+		}
+		if entry.AuthorURL != "" && util.Validate.Var(entry.AuthorURL, "http_url") == nil {
+			mention.AuthorURL = entry.AuthorURL
+		}
+	} else if doc != nil {
+		if title := findNode(doc, func(n *html.Node) bool { return n.DataAtom == atom.Title }); title != nil {
+			content = nodeText(title)
+		}
+	}
+	if content == "" {
+		content = "Mentioned in " + sourceURL.String()
+	}
+	mention.Author = escapeCommentContent(mention.Author, 50)
+	mention.AuthorURL = escapeCommentContent(mention.AuthorURL, 511)
+	mention.Content = escapeCommentContent(content, 1023)
+	mention.GravatarMd5 = util.Md5Hex(mention.Email)
+	return mention
+}
+
+func (w *webmentionServiceImpl) createMention(ctx context.Context, mention *entity.Comment) error {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	err := commentDAL.WithContext(ctx).Select(field.Star).Omit(commentDAL.UpdateTime).Create(mention)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if mention.Status == consts.CommentStatusSpam {
+		return nil
+	}
+	go func() {
+		w.Event.Publish(context.TODO(), &event.CommentCreatedEvent{
+			Comment: mention,
+		})
+	}()
+	return nil
+}
+
+// updateMention applies the change of the source, the changed mention is moderated again.
+func (w *webmentionServiceImpl) updateMention(ctx context.Context, existing, mention *entity.Comment) error {
+	commentDAL := dal.GetQueryByCtx(ctx).Comment
+	_, err := commentDAL.WithContext(ctx).Where(commentDAL.ID.Eq(existing.ID)).UpdateSimple(
+		commentDAL.Content.Value(mention.Content),
+		commentDAL.Author.Value(mention.Author),
+		commentDAL.AuthorURL.Value(mention.AuthorURL),
+		commentDAL.Status.Value(mention.Status),
+		commentDAL.UpdateTime.Value(time.Now()),
+	)
+	return WrapDBErr(err)
+}
+
+func (w *webmentionServiceImpl) removeMention(ctx context.Context, existing *entity.Comment) error {
+	if existing == nil {
+		return nil
+	}
+	return deleteRemoteComment(ctx, existing)
+}
+
+func (w *webmentionServiceImpl) SendForPost(ctx context.Context, postID int32) error {
+	endpoint, err := w.GetEndpoint(ctx)
+	if err != nil || endpoint == "" {
+		return err
+	}
+	post, err := w.BasePostService.GetByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.Status != consts.PostStatusPublished || post.Password != "" {
+		return nil
+	}
+	blogBaseURL, err := w.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return err
+	}
+	baseURL, err := url.Parse(blogBaseURL + "/")
+	if err != nil {
+		return xerr.BadParam.Wrap(err).WithMsg("invalid blog url")
+	}
+	fullPath, err := w.BasePostService.BuildFullPath(ctx, post)
+	if err != nil {
+		return err
+	}
+	sourceURL, err := baseURL.Parse(fullPath)
+	if err != nil {
+		return xerr.BadParam.Wrap(err).WithMsg("invalid post url")
+	}
+
+	sent := make(map[string]struct{})
+	for _, ref := range extractLinks(post.FormatContent) {
+		if ref.Element != "a" {
+			continue
+		}
+		link, ok := newCheckedLink(baseURL, sourceURL, ref.URL)
+		if !ok || link.Internal || link.Reason != "" {
+			continue
+		}
+		if _, ok := sent[link.URL]; ok {
+			continue
+		}
+		sent[link.URL] = struct{}{}
+		if err := w.send(ctx, sourceURL.String(), link.URL); err != nil {
+			log.CtxWarn(ctx, "send webmention err", zap.String("source", sourceURL.String()), zap.String("target", link.URL), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (w *webmentionServiceImpl) send(ctx context.Context, source, target string) error {
+	endpoint, err := w.discoverEndpoint(ctx, target)
+	if err != nil || endpoint == "" {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webmentionTimeout)
+	defer cancel()
+	form := url.Values{"source": {source}, "target": {target}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "sonic/"+consts.SonicVersion+" webmention")
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode >= http.StatusBadRequest {
+		return xerr.NoType.New("endpoint=%v status=%v", endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// discoverEndpoint looks for the webmention endpoint in the Link headers and then in the <link> and <a> elements of the target,
+// it returns empty if the target has none.
+func (w *webmentionServiceImpl) discoverEndpoint(ctx context.Context, target string) (string, error) {
+	resp, body, err := w.fetch(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", nil
+	}
+	pageURL := resp.Request.URL
+	for _, header := range resp.Header.Values("Link") {
+		if ref, ok := findWebmentionLink(header); ok {
+			endpoint, err := pageURL.Parse(ref)
+			if err != nil {
+				return "", err
+			}
+			return endpoint.String(), nil
+		}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "", nil
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	node := findNode(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Link && n.DataAtom != atom.A {
+			return false
+		}
+		if _, ok := nodeAttrOK(n, "href"); !ok {
+			return false
+		}
+		for _, rel := range strings.Fields(nodeAttr(n, "rel")) {
+			if strings.EqualFold(rel, "webmention") {
+				return true
+			}
+		}
+		return false
+	})
+	if node == nil {
+		return "", nil
+	}
+	// an empty href is the target itself
+	endpoint, err := pageURL.Parse(nodeAttr(node, "href"))
+	if err != nil {
+		return "", err
+	}
+	return endpoint.String(), nil
+}
+
+// findWebmentionLink parses a Link header such as `<https://example.com/webmention>; rel="webmention"`.
+func findWebmentionLink(header string) (string, bool) {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		ref := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(ref, "<") || !strings.HasSuffix(ref, ">") {
+			continue
+		}
+		for _, param := range parts[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				if strings.EqualFold(rel, "webmention") {
+					return ref[1 : len(ref)-1], true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func nodeAttrOK(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
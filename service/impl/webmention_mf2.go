@@ -0,0 +1,186 @@
+package impl
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// mf2Entry is the part of a microformats2 h-entry that a webmention needs, see https://microformats.org/wiki/h-entry
+type mf2Entry struct {
+	Name        string
+	Summary     string
+	Content     string
+	URL         string
+	Published   string
+	AuthorName  string
+	AuthorURL   string
+	AuthorPhoto string
+}
+
+// parseHEntry parses the first h-entry of the page, it returns nil if there is none.
+func parseHEntry(doc *html.Node, pageURL *url.URL) *mf2Entry {
+	root := findNode(doc, func(n *html.Node) bool {
+		return hasClass(n, "h-entry")
+	})
+	if root == nil {
+		return nil
+	}
+	entry := &mf2Entry{}
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		parseEntryProperties(child, pageURL, entry)
+	}
+	return entry
+}
+
+func parseEntryProperties(n *html.Node, pageURL *url.URL, entry *mf2Entry) {
+	if n.Type != html.ElementNode {
+		return
+	}
+	if hasClass(n, "p-author") {
+		parseAuthor(n, pageURL, entry)
+		return
+	}
+	if hasClass(n, "p-name") && entry.Name == "" {
+		entry.Name = nodeText(n)
+	}
+	if hasClass(n, "p-summary") && entry.Summary == "" {
+		entry.Summary = nodeText(n)
+	}
+	if (hasClass(n, "e-content") || hasClass(n, "p-content")) && entry.Content == "" {
+		entry.Content = nodeText(n)
+	}
+	if hasClass(n, "u-url") && entry.URL == "" {
+		entry.URL = nodeURL(n, pageURL)
+	}
+	if hasClass(n, "dt-published") && entry.Published == "" {
+		entry.Published = nodeAttr(n, "datetime")
+		if entry.Published == "" {
+			entry.Published = nodeText(n)
+		}
+	}
+	// the properties of a nested microformat, such as the h-cite of a reply, belong to it
+	if isMicroformatRoot(n) {
+		return
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		parseEntryProperties(child, pageURL, entry)
+	}
+}
+
+// parseAuthor parses the author as an h-card, or as the plain text and link of the element.
+func parseAuthor(n *html.Node, pageURL *url.URL, entry *mf2Entry) {
+	if !hasClass(n, "h-card") {
+		entry.AuthorName = nodeText(n)
+		if n.DataAtom == atom.A {
+			entry.AuthorURL = nodeURL(n, pageURL)
+		}
+		return
+	}
+	if name := findNode(n, func(c *html.Node) bool { return hasClass(c, "p-name") }); name != nil {
+		entry.AuthorName = nodeText(name)
+	} else {
+		entry.AuthorName = nodeText(n)
+	}
+	if hasClass(n, "u-url") {
+		entry.AuthorURL = nodeURL(n, pageURL)
+	} else if u := findNode(n, func(c *html.Node) bool { return hasClass(c, "u-url") }); u != nil {
+		entry.AuthorURL = nodeURL(u, pageURL)
+	} else if n.DataAtom == atom.A {
+		// the implied url of an h-card link
+		entry.AuthorURL = nodeURL(n, pageURL)
+	}
+	if photo := findNode(n, func(c *html.Node) bool { return hasClass(c, "u-photo") }); photo != nil {
+		entry.AuthorPhoto = nodeURL(photo, pageURL)
+	}
+}
+
+// findNode returns the first node matched in depth-first order, including the node itself.
+func findNode(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findNode(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(nodeAttr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func isMicroformatRoot(n *html.Node) bool {
+	for _, c := range strings.Fields(nodeAttr(n, "class")) {
+		if strings.HasPrefix(c, "h-") {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// nodeURL returns the url of the u-* property, which is in the href, src or value attribute depending on the element.
+func nodeURL(n *html.Node, pageURL *url.URL) string {
+	var raw string
+	switch n.DataAtom {
+	case atom.A, atom.Area, atom.Link:
+		raw = nodeAttr(n, "href")
+	case atom.Img, atom.Audio, atom.Video, atom.Source:
+		raw = nodeAttr(n, "src")
+	default:
+		raw = nodeAttr(n, "value")
+		if raw == "" {
+			raw = nodeText(n)
+		}
+	}
+	u, err := pageURL.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
+
+// nodeText returns the text of the node with the whitespaces collapsed, the scripts and styles are left out.
+func nodeText(n *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			builder.WriteString(n.Data)
+			builder.WriteString(" ")
+			return
+		case html.ElementNode:
+			if n.DataAtom == atom.Script || n.DataAtom == atom.Style {
+				return
+			}
+			if n.DataAtom == atom.Img && nodeAttr(n, "alt") != "" {
+				builder.WriteString(nodeAttr(n, "alt"))
+				builder.WriteString(" ")
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
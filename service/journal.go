@@ -18,5 +18,4 @@ type JournalService interface {
 	Delete(ctx context.Context, journalID int32) error
 	GetByJournalIDs(ctx context.Context, journalIDs []int32) (map[int32]*entity.Journal, error)
 	Count(ctx context.Context) (int64, error)
-	IncreaseLike(ctx context.Context, journalID int32) error
 }
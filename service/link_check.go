@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/dto"
+)
+
+type LinkCheckService interface {
+	// Start checks the links in the content of posts, sheets and journals in background
+	Start(ctx context.Context) error
+	// GetReport returns the report of the latest check
+	GetReport(ctx context.Context) *dto.LinkCheckReport
+}
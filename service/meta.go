@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 
+	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/model/dto"
 	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
 )
 
 type MetaService interface {
@@ -12,4 +14,9 @@ type MetaService interface {
 	GetPostMeta(ctx context.Context, postID int32) ([]*entity.Meta, error)
 	ConvertToMetaDTO(meta *entity.Meta) *dto.Meta
 	ConvertToMetaDTOs(metas []*entity.Meta) []*dto.Meta
+	// ValidateMetas checks the metas against the meta schema of the activated theme,
+	// the default values of the missing required metas are added to the result
+	ValidateMetas(ctx context.Context, postType consts.PostType, metas []param.Meta) ([]param.Meta, error)
+	// ConvertToTypedMetas converts the values of the metas to the types declared by the activated theme
+	ConvertToTypedMetas(ctx context.Context, postType consts.PostType, metas []*entity.Meta) (map[string]interface{}, error)
 }
@@ -20,6 +20,5 @@ type PhotoService interface {
 	ConvertToDTOs(ctx context.Context, photos []*entity.Photo) []*dto.Photo
 	ListTeams(ctx context.Context) ([]string, error)
 	ListByTeam(ctx context.Context, team string, sort *param.Sort) ([]*entity.Photo, error)
-	IncreaseLike(ctx context.Context, id int32) error
 	GetPhotoCount(ctx context.Context) (int64, error)
 }
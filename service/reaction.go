@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/vo"
+)
+
+// ReactionService keeps one reaction of each visitor on a target, the visitor is identified by the fingerprint.
+// The like reaction is counted by the likes field of the target as well, so the likes keep working.
+type ReactionService interface {
+	// ListAllowed returns the reactions configured for the type of the target
+	ListAllowed(ctx context.Context, targetType consts.ReactionTarget) ([]string, error)
+	// Toggle gives the reaction in place of the previous one, or takes it back if the visitor has given it
+	Toggle(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string, reaction string) (*vo.ReactionSummary, error)
+	// Like gives the like reaction, it does nothing if the visitor has liked the target
+	Like(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string) error
+	GetSummary(ctx context.Context, targetType consts.ReactionTarget, targetID int32, fingerprint string) (*vo.ReactionSummary, error)
+	// CountByTargetIDs counts the allowed reactions of each target, including the ones nobody has given
+	CountByTargetIDs(ctx context.Context, targetType consts.ReactionTarget, targetIDs []int32) (map[int32]map[string]int64, error)
+}
@@ -0,0 +1,9 @@
+package service
+
+import "context"
+
+type ResponsiveImageService interface {
+	// Filter adds lazy loading, size and srcset to the images of local attachments in the content,
+	// the content is returned unchanged unless the activated theme enables responsive_images
+	Filter(ctx context.Context, content string) (string, error)
+}
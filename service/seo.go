@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/vo"
+)
+
+// SeoService builds the metadata of the pages for the search engines and the social networks.
+// The pages of the lists start from 0, the canonical url of a page after the first one ends with /page/{n}.
+type SeoService interface {
+	// BuildForSite builds the metadata of the home page when path is empty, otherwise of the list page of the path, like archives
+	BuildForSite(ctx context.Context, title string, path string, page int) (*vo.SeoMeta, error)
+	BuildForPost(ctx context.Context, post *dto.Post, categories []*dto.CategoryDTO, tags []*dto.Tag) (*vo.SeoMeta, error)
+	BuildForSheet(ctx context.Context, sheet *dto.Post) (*vo.SeoMeta, error)
+	// BuildForCategory builds the metadata of the page of the category, listTitle is the title of the list of all the categories in the breadcrumbs
+	BuildForCategory(ctx context.Context, category *dto.CategoryDTO, listTitle string, page int) (*vo.SeoMeta, error)
+	// BuildForTag builds the metadata of the page of the tag, listTitle is the title of the list of all the tags in the breadcrumbs
+	BuildForTag(ctx context.Context, tag *dto.Tag, listTitle string, page int) (*vo.SeoMeta, error)
+}
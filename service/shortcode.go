@@ -0,0 +1,9 @@
+package service
+
+import "context"
+
+type ShortcodeService interface {
+	// Render replaces the shortcodes in the html content with the output of their templates.
+	// The templates of the activated theme in shortcodes/*.tmpl take precedence over the built-in ones.
+	Render(ctx context.Context, content string) (string, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import "context"
+
+// SitemapService generates the sitemap index and the sitemaps of the posts, the pages, the categories and the tags.
+// A sitemap is split into pages by the limits of the sitemap protocol, and the output is cached until the content changes.
+type SitemapService interface {
+	GetIndex(ctx context.Context) ([]byte, error)
+	// GetSitemap returns the page of the sitemap of the type, the pages start from 1
+	GetSitemap(ctx context.Context, sitemapType string, page int) ([]byte, error)
+	// Evict drops the cached sitemaps, they are generated again on the next request
+	Evict()
+}
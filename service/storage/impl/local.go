@@ -31,6 +31,21 @@ var (
 	thumbnailSuffix = "-thumbnail"
 )
 
+// ResponsiveWidths are the widths of the resized copies generated for uploaded images,
+// they are used as the srcset of the images in posts.
+var ResponsiveWidths = []int{640, 1280}
+
+// ResponsiveImageName returns the file name of the copy of the image resized to the width.
+// The copies of the images that can not be encoded, like webp, are saved as png.
+func ResponsiveImageName(fileName string, width int) string {
+	ext := path.Ext(fileName)
+	name := fileName[:len(fileName)-len(ext)] + "-w" + strconv.Itoa(width) + ext
+	if _, err := imaging.FormatFromExtension(ext); err != nil {
+		name += ".png"
+	}
+	return name
+}
+
 type LocalFileStorage struct {
 	Config        *config.Config
 	OptionService service.OptionService
@@ -101,7 +116,7 @@ func (l *LocalFileStorage) Upload(ctx context.Context, fileHeader *multipart.Fil
 		Size:           fileHeader.Size,
 	}
 
-	thumbnailFn := func(srcImage image.Image) (string, error) {
+	saveThumbnail := func(srcImage image.Image) (string, error) {
 		if fd.getExtensionName() == "webp" {
 			return fd.getRelativePath(), nil
 		}
@@ -129,6 +144,15 @@ func (l *LocalFileStorage) Upload(ctx context.Context, fileHeader *multipart.Fil
 		}
 		return thumbnailFd.getRelativePath(), nil
 	}
+	// the responsive copies are saved for every format but gif, whether a thumbnail is saved or not
+	thumbnailFn := func(srcImage image.Image) (string, error) {
+		if fd.getExtensionName() != "gif" {
+			if err := saveResponsiveImages(srcImage, fd.getFullPath()); err != nil {
+				return "", err
+			}
+		}
+		return saveThumbnail(srcImage)
+	}
 	_, err = srcFile.Seek(0, io.SeekStart)
 	if err != nil {
 		return nil, xerr.WithStatus(err, xerr.StatusInternalServerError)
@@ -160,6 +184,28 @@ func (l *LocalFileStorage) Delete(ctx context.Context, fileKey string) error {
 	if err != nil && !os.IsNotExist(err) {
 		return xerr.NoType.Wrap(err).WithMsg("delete file failed")
 	}
+	for _, width := range ResponsiveWidths {
+		err = os.Remove(filepath.Join(filepath.Dir(filePath), ResponsiveImageName(fullName, width)))
+		if err != nil && !os.IsNotExist(err) {
+			return xerr.NoType.Wrap(err).WithMsg("delete file failed")
+		}
+	}
+	return nil
+}
+
+// saveResponsiveImages saves the copies of the image narrower than it next to the image.
+func saveResponsiveImages(srcImage image.Image, fullPath string) error {
+	srcWidth := srcImage.Bounds().Dx()
+	for _, width := range ResponsiveWidths {
+		if width >= srcWidth {
+			break
+		}
+		dstImage := imaging.Resize(srcImage, width, 0, imaging.Lanczos)
+		dstPath := filepath.Join(filepath.Dir(fullPath), ResponsiveImageName(filepath.Base(fullPath), width))
+		if err := imaging.Save(dstImage, dstPath); err != nil {
+			return xerr.NoType.Wrap(err).WithMsg("Save responsive image err")
+		}
+	}
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"mime/multipart"
 
+	"github.com/go-sonic/sonic/consts"
 	"github.com/go-sonic/sonic/model/dto"
 )
 
@@ -15,6 +16,7 @@ type ThemeService interface {
 	GetThemeFileContent(ctx context.Context, themeID, absPath string) (string, error)
 	UpdateThemeFile(ctx context.Context, themeID, absPath, content string) error
 	ListCustomTemplates(ctx context.Context, themeID, prefix string) ([]string, error)
+	ListMetaFields(ctx context.Context, themeID string, postType consts.PostType) ([]*dto.MetaField, error)
 	ActivateTheme(ctx context.Context, themeID string) (*dto.ThemeProperty, error)
 	GetThemeConfig(ctx context.Context, themeID string) ([]*dto.ThemeConfigGroup, error)
 	GetThemeSettingMap(ctx context.Context, themeID string) (map[string]interface{}, error)
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/model/dto"
+	"github.com/go-sonic/sonic/model/entity"
+	"github.com/go-sonic/sonic/model/param"
+)
+
+type WebhookService interface {
+	List(ctx context.Context) ([]*entity.Webhook, error)
+	Create(ctx context.Context, webhookParam *param.Webhook) (*entity.Webhook, error)
+	Update(ctx context.Context, id int32, webhookParam *param.Webhook) (*entity.Webhook, error)
+	Delete(ctx context.Context, id int32) error
+	// Trigger records a delivery of the event for every enabled webhook subscribing it, and sends them in background.
+	// The failed deliveries are retried with exponential backoff.
+	Trigger(ctx context.Context, webhookEvent string, data interface{}) error
+	PageDeliveries(ctx context.Context, webhookID int32, page param.Page) ([]*entity.WebhookDelivery, int64, error)
+	// Redeliver sends the payload of the delivery again as a new delivery
+	Redeliver(ctx context.Context, deliveryID int32) (*entity.WebhookDelivery, error)
+	ConvertToDTO(webhook *entity.Webhook) *dto.Webhook
+	ConvertToDTOs(webhooks []*entity.Webhook) []*dto.Webhook
+	ConvertToDeliveryDTOs(deliveries []*entity.WebhookDelivery) []*dto.WebhookDelivery
+}
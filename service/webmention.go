@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+)
+
+// WebmentionService receives the mentions of the posts from other sites and notifies the sites linked in the posts.
+type WebmentionService interface {
+	// Receive checks the source and the target, then verifies that the source links to the target in the background
+	// and stores the mention as a comment waiting for moderation, the mention is removed if the source no longer links to the target.
+	Receive(ctx context.Context, source string, target string) error
+	// SendForPost notifies the webmention endpoints of the external links in the published post
+	SendForPost(ctx context.Context, postID int32) error
+	// GetEndpoint returns the url of the webmention receiver, or empty if webmentions are disabled
+	GetEndpoint(ctx context.Context) (string, error)
+}
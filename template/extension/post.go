@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/model/entity"
 	"github.com/go-sonic/sonic/model/param"
 	"github.com/go-sonic/sonic/model/vo"
 	"github.com/go-sonic/sonic/service"
 	"github.com/go-sonic/sonic/service/assembler"
 	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util/xerr"
 )
 
 type postExtension struct {
@@ -40,6 +42,7 @@ func RegisterPostFunc(template *template.Template, postService service.PostServi
 	p.addListPostByTagID()
 	p.addListPostByTagSlug()
 	p.addListMostPopularPost()
+	p.addGetPostBySlug()
 }
 
 func (p *postExtension) addListLatestPost() {
@@ -84,6 +87,25 @@ func (p *postExtension) addListMostPopularPost() {
 	p.Template.AddFunc("listMostPopularPost", listMostPopularPost)
 }
 
+func (p *postExtension) addGetPostBySlug() {
+	getPostBySlug := func(slug string) (*vo.Post, error) {
+		ctx := context.Background()
+		post, err := p.PostService.GetBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		if post.Type != consts.PostTypePost || post.Status != consts.PostStatusPublished {
+			return nil, xerr.NoRecord.New("post %s is not published", slug).WithStatus(xerr.StatusNotFound)
+		}
+		postVOs, err := p.PostAssembler.ConvertToListVO(ctx, []*entity.Post{post})
+		if err != nil {
+			return nil, err
+		}
+		return postVOs[0], nil
+	}
+	p.Template.AddFunc("getPostBySlug", getPostBySlug)
+}
+
 func (p *postExtension) addGetPostCount() {
 	getPostCountFunc := func() (int64, error) {
 		ctx := context.Background()
@@ -0,0 +1,67 @@
+package extension
+
+import (
+	"context"
+
+	"github.com/go-sonic/sonic/consts"
+	"github.com/go-sonic/sonic/service"
+	"github.com/go-sonic/sonic/template"
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+type reactionExtension struct {
+	ReactionService service.ReactionService
+	Template        *template.Template
+}
+
+func RegisterReactionFunc(template *template.Template, reactionService service.ReactionService) {
+	r := &reactionExtension{
+		ReactionService: reactionService,
+		Template:        template,
+	}
+	r.addListAllowedReactions()
+	r.addGetReactions()
+}
+
+// reactionTargets are the names of the targets used in the templates
+var reactionTargets = map[string]consts.ReactionTarget{
+	"post":    consts.ReactionTargetPost,
+	"sheet":   consts.ReactionTargetPost,
+	"journal": consts.ReactionTargetJournal,
+	"photo":   consts.ReactionTargetPhoto,
+	"comment": consts.ReactionTargetComment,
+}
+
+func getReactionTarget(target string) (consts.ReactionTarget, error) {
+	targetType, ok := reactionTargets[target]
+	if !ok {
+		return 0, xerr.BadParam.New("target=%v", target).WithMsg("Unknown reaction target")
+	}
+	return targetType, nil
+}
+
+func (r *reactionExtension) addListAllowedReactions() {
+	listAllowedReactions := func(target string) ([]string, error) {
+		targetType, err := getReactionTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		return r.ReactionService.ListAllowed(context.Background(), targetType)
+	}
+	r.Template.AddFunc("listAllowedReactions", listAllowedReactions)
+}
+
+func (r *reactionExtension) addGetReactions() {
+	getReactions := func(target string, targetID int32) (map[string]int64, error) {
+		targetType, err := getReactionTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		reactionMap, err := r.ReactionService.CountByTargetIDs(context.Background(), targetType, []int32{targetID})
+		if err != nil {
+			return nil, err
+		}
+		return reactionMap[targetID], nil
+	}
+	r.Template.AddFunc("getReactions", getReactions)
+}
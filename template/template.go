@@ -121,6 +121,13 @@ func (t *Template) wrapData(data Model) map[string]any {
 	return data
 }
 
+// Exists reports whether a template with the name has been loaded.
+func (t *Template) Exists(name string) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.HTMLTemplate != nil && t.HTMLTemplate.Lookup(name) != nil
+}
+
 func (t *Template) AddFunc(name string, fn interface{}) {
 	if t.HTMLTemplate != nil {
 		panic("the template has been parsed")
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 	"unicode/utf8"
@@ -30,6 +31,15 @@ func CompositeURL(urls ...string) string {
 	return builder.String()
 }
 
+// ResolveURL returns the absolute url of the reference against the base, or the reference as it is if it is not a valid url.
+func ResolveURL(base *url.URL, ref string) string {
+	resolved, err := base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return resolved.String()
+}
+
 var htmlRegexp = regexp.MustCompile(`(<[^<]*?>)|(<[\s]*?/[^<]*?>)|(<[^<]*?/[\s]*?>)`)
 
 func CleanHTMLTag(htmlContent string) string {
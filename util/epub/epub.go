@@ -0,0 +1,417 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+// ImageLoader loads the image referenced by src. ok is false when the image can not be
+// embedded, e.g. it is stored remotely.
+type ImageLoader func(src string) (data []byte, ok bool)
+
+type Book struct {
+	Identifier  string
+	Title       string
+	Language    string
+	Author      string
+	Publisher   string
+	Description string
+	// BaseURL is used to resolve root-relative links in chapters
+	BaseURL  string
+	Modified time.Time
+
+	ImageLoader ImageLoader
+
+	chapters []*chapter
+	images   []*image
+	imageMap map[string]*image
+}
+
+type chapter struct {
+	ID       string
+	Title    string
+	FileName string
+	Body     string
+	Headings []*heading
+}
+
+type heading struct {
+	Level int
+	ID    string
+	Title string
+}
+
+type image struct {
+	ID        string
+	FileName  string
+	MediaType string
+	Data      []byte
+}
+
+type tocEntry struct {
+	Title    string
+	Href     string
+	Children []*tocEntry
+}
+
+func NewBook(identifier, title string) *Book {
+	return &Book{
+		Identifier: identifier,
+		Title:      title,
+		Language:   "en",
+		Modified:   time.Now(),
+		imageMap:   make(map[string]*image),
+	}
+}
+
+// AddChapter adds a chapter built from an HTML fragment. Headings in the fragment become
+// nested entries of the table of contents.
+func (b *Book) AddChapter(title string, htmlContent string) error {
+	c := &chapter{
+		ID:       "chapter-" + strconv.Itoa(len(b.chapters)+1),
+		Title:    title,
+		FileName: "chapters/chapter-" + strconv.Itoa(len(b.chapters)+1) + ".xhtml",
+	}
+	body, headings, err := b.convertHTML(htmlContent)
+	if err != nil {
+		return err
+	}
+	c.Body = body
+	c.Headings = headings
+	b.chapters = append(b.chapters, c)
+	return nil
+}
+
+func (b *Book) ChapterCount() int {
+	return len(b.chapters)
+}
+
+// addImage embeds the image referenced by src and returns its path relative to the chapter files.
+func (b *Book) addImage(src string) (string, bool) {
+	if img, ok := b.imageMap[src]; ok {
+		return "../" + img.FileName, true
+	}
+	if b.ImageLoader == nil {
+		return "", false
+	}
+	mediaType := imageMediaType(src)
+	if mediaType == "" {
+		return "", false
+	}
+	data, ok := b.ImageLoader(src)
+	if !ok {
+		return "", false
+	}
+	id := "image-" + strconv.Itoa(len(b.images)+1)
+	img := &image{
+		ID:        id,
+		FileName:  "images/" + id + strings.ToLower(path.Ext(stripQuery(src))),
+		MediaType: mediaType,
+		Data:      data,
+	}
+	b.images = append(b.images, img)
+	b.imageMap[src] = img
+	return "../" + img.FileName, true
+}
+
+// Write writes the book as an EPUB 3 container.
+func (b *Book) Write(w io.Writer) error {
+	if len(b.chapters) == 0 {
+		return xerr.BadParam.New("").WithMsg("no chapter to export").WithStatus(xerr.StatusBadRequest)
+	}
+	zw := zip.NewWriter(w)
+
+	// the mimetype file must be the first entry and must not be compressed
+	mimetypeWriter, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write epub err")
+	}
+	if _, err = mimetypeWriter.Write([]byte("application/epub+zip")); err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write epub err")
+	}
+
+	files := []struct {
+		name    string
+		content func() ([]byte, error)
+	}{
+		{"META-INF/container.xml", func() ([]byte, error) { return []byte(containerXML), nil }},
+		{"OEBPS/content.opf", b.packageDocument},
+		{"OEBPS/nav.xhtml", b.navDocument},
+		{"OEBPS/toc.ncx", b.ncxDocument},
+		{"OEBPS/styles/book.css", func() ([]byte, error) { return []byte(stylesheet), nil }},
+	}
+	for _, c := range b.chapters {
+		c := c
+		files = append(files, struct {
+			name    string
+			content func() ([]byte, error)
+		}{"OEBPS/" + c.FileName, func() ([]byte, error) { return b.chapterDocument(c), nil }})
+	}
+	for _, f := range files {
+		content, err := f.content()
+		if err != nil {
+			return err
+		}
+		if err = writeZipEntry(zw, f.name, content, zip.Deflate); err != nil {
+			return err
+		}
+	}
+	for _, img := range b.images {
+		// images are already compressed
+		if err = writeZipEntry(zw, "OEBPS/"+img.FileName, img.Data, zip.Store); err != nil {
+			return err
+		}
+	}
+	if err = zw.Close(); err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write epub err")
+	}
+	return nil
+}
+
+func writeZipEntry(zw *zip.Writer, name string, content []byte, method uint16) error {
+	writer, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: method, Modified: time.Now()})
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write epub err")
+	}
+	_, err = writer.Write(content)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write epub err")
+	}
+	return nil
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const stylesheet = `body { font-family: serif; line-height: 1.6; }
+h1.chapter-title { margin-bottom: 1.5em; }
+img { max-width: 100%; }
+pre { white-space: pre-wrap; word-wrap: break-word; font-size: 0.85em; }
+blockquote { margin-left: 1em; padding-left: 0.8em; border-left: 3px solid #ccc; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 0.2em 0.4em; }
+`
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr,omitempty"`
+}
+
+type opfItemRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+type opfMeta struct {
+	Property string `xml:"property,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type opfIdentifier struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+type opfPackage struct {
+	XMLName          xml.Name    `xml:"http://www.idpf.org/2007/opf package"`
+	Version          string      `xml:"version,attr"`
+	UniqueIdentifier string      `xml:"unique-identifier,attr"`
+	Lang             string      `xml:"xml:lang,attr"`
+	Metadata         opfMetadata `xml:"metadata"`
+	Items            []opfItem   `xml:"manifest>item"`
+	Spine            opfSpine    `xml:"spine"`
+}
+
+type opfSpine struct {
+	Toc      string        `xml:"toc,attr"`
+	ItemRefs []*opfItemRef `xml:"itemref"`
+}
+
+type opfMetadata struct {
+	DC          string        `xml:"xmlns:dc,attr"`
+	Identifier  opfIdentifier `xml:"dc:identifier"`
+	Title       string        `xml:"dc:title"`
+	Language    string        `xml:"dc:language"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Publisher   string        `xml:"dc:publisher,omitempty"`
+	Description string        `xml:"dc:description,omitempty"`
+	Meta        []opfMeta     `xml:"meta"`
+}
+
+func (b *Book) packageDocument() ([]byte, error) {
+	pkg := opfPackage{
+		Version:          "3.0",
+		UniqueIdentifier: "book-id",
+		Lang:             b.Language,
+		Metadata: opfMetadata{
+			DC:          "http://purl.org/dc/elements/1.1/",
+			Identifier:  opfIdentifier{ID: "book-id", Value: b.Identifier},
+			Title:       b.Title,
+			Language:    b.Language,
+			Creator:     b.Author,
+			Publisher:   b.Publisher,
+			Description: b.Description,
+			Meta:        []opfMeta{{Property: "dcterms:modified", Value: b.Modified.UTC().Format("2006-01-02T15:04:05Z")}},
+		},
+		Items: []opfItem{
+			{ID: "nav", Href: "nav.xhtml", MediaType: "application/xhtml+xml", Properties: "nav"},
+			{ID: "ncx", Href: "toc.ncx", MediaType: "application/x-dtbncx+xml"},
+			{ID: "css", Href: "styles/book.css", MediaType: "text/css"},
+		},
+		Spine: opfSpine{
+			Toc:      "ncx",
+			ItemRefs: []*opfItemRef{{IDRef: "nav"}},
+		},
+	}
+	for _, c := range b.chapters {
+		pkg.Items = append(pkg.Items, opfItem{ID: c.ID, Href: c.FileName, MediaType: "application/xhtml+xml"})
+		pkg.Spine.ItemRefs = append(pkg.Spine.ItemRefs, &opfItemRef{IDRef: c.ID})
+	}
+	for _, img := range b.images {
+		pkg.Items = append(pkg.Items, opfItem{ID: img.ID, Href: img.FileName, MediaType: img.MediaType})
+	}
+	content, err := xml.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("marshal epub package document err")
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+func (b *Book) toc() []*tocEntry {
+	entries := make([]*tocEntry, 0, len(b.chapters))
+	for _, c := range b.chapters {
+		root := &tocEntry{Title: c.Title, Href: c.FileName}
+		type level struct {
+			level int
+			entry *tocEntry
+		}
+		stack := []level{{level: 0, entry: root}}
+		for _, h := range c.Headings {
+			for len(stack) > 1 && stack[len(stack)-1].level >= h.Level {
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1].entry
+			entry := &tocEntry{Title: h.Title, Href: c.FileName + "#" + h.ID}
+			parent.Children = append(parent.Children, entry)
+			stack = append(stack, level{level: h.Level, entry: entry})
+		}
+		entries = append(entries, root)
+	}
+	return entries
+}
+
+func (b *Book) navDocument() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="` + escape(b.Language) + `" lang="` + escape(b.Language) + `">
+<head><title>` + escape(b.Title) + `</title></head>
+<body>
+<nav epub:type="toc" id="toc">
+<h1>` + escape(b.Title) + `</h1>
+`)
+	writeNavList(buf, b.toc())
+	buf.WriteString("</nav>\n</body>\n</html>\n")
+	return buf.Bytes(), nil
+}
+
+func writeNavList(buf *bytes.Buffer, entries []*tocEntry) {
+	buf.WriteString("<ol>\n")
+	for _, entry := range entries {
+		buf.WriteString(`<li><a href="` + escape(entry.Href) + `">` + escape(entry.Title) + "</a>")
+		if len(entry.Children) > 0 {
+			buf.WriteString("\n")
+			writeNavList(buf, entry.Children)
+		}
+		buf.WriteString("</li>\n")
+	}
+	buf.WriteString("</ol>\n")
+}
+
+func (b *Book) ncxDocument() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+<head><meta name="dtb:uid" content="` + escape(b.Identifier) + `"/></head>
+<docTitle><text>` + escape(b.Title) + `</text></docTitle>
+<navMap>
+`)
+	playOrder := 0
+	var writeNavPoints func(entries []*tocEntry)
+	writeNavPoints = func(entries []*tocEntry) {
+		for _, entry := range entries {
+			playOrder++
+			order := strconv.Itoa(playOrder)
+			buf.WriteString(`<navPoint id="nav-` + order + `" playOrder="` + order + `"><navLabel><text>` + escape(entry.Title) + `</text></navLabel><content src="` + escape(entry.Href) + `"/>` + "\n")
+			writeNavPoints(entry.Children)
+			buf.WriteString("</navPoint>\n")
+		}
+	}
+	writeNavPoints(b.toc())
+	buf.WriteString("</navMap>\n</ncx>\n")
+	return buf.Bytes(), nil
+}
+
+func (b *Book) chapterDocument(c *chapter) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="` + escape(b.Language) + `" lang="` + escape(b.Language) + `">
+<head>
+<title>` + escape(c.Title) + `</title>
+<link rel="stylesheet" type="text/css" href="../styles/book.css"/>
+</head>
+<body>
+<section epub:type="chapter">
+<h1 class="chapter-title">` + escape(c.Title) + `</h1>
+`)
+	buf.WriteString(c.Body)
+	buf.WriteString("\n</section>\n</body>\n</html>\n")
+	return buf.Bytes()
+}
+
+func escape(s string) string {
+	buf := &strings.Builder{}
+	_ = xml.EscapeText(buf, []byte(s))
+	return buf.String()
+}
+
+func stripQuery(src string) string {
+	if i := strings.IndexAny(src, "?#"); i >= 0 {
+		return src[:i]
+	}
+	return src
+}
+
+// imageMediaType returns the media type of the image if it is one of the EPUB core media types.
+func imageMediaType(src string) string {
+	ext := strings.ToLower(path.Ext(stripQuery(src)))
+	switch ext {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".webp":
+		return "image/webp"
+	case ".svg":
+		return "image/svg+xml"
+	default:
+		return ""
+	}
+}
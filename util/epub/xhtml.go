@@ -0,0 +1,180 @@
+package epub
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/go-sonic/sonic/util/xerr"
+)
+
+var xmlNameRegexp = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9_.]*$`)
+
+// convertHTML converts an HTML fragment into well-formed XHTML, embeds the referenced images
+// and collects the headings for the table of contents.
+func (b *Book) convertHTML(content string) (string, []*heading, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "section", DataAtom: atom.Section}
+	nodes, err := html.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		return "", nil, xerr.WithStatus(err, xerr.StatusInternalServerError).WithMsg("parse post content err")
+	}
+
+	headings := make([]*heading, 0)
+	usedIDs := make(map[string]struct{})
+	for _, node := range nodes {
+		context.AppendChild(node)
+	}
+	collectIDs(context, usedIDs)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			if c.Type == html.ElementNode && (c.DataAtom == atom.Script || c.DataAtom == atom.Style || c.DataAtom == atom.Iframe) {
+				n.RemoveChild(c)
+			} else {
+				b.convertNode(c, usedIDs, &headings)
+				walk(c)
+			}
+			c = next
+		}
+	}
+	walk(context)
+
+	body := &strings.Builder{}
+	for c := context.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(body, c); err != nil {
+			return "", nil, xerr.WithStatus(err, xerr.StatusInternalServerError).WithMsg("render post content err")
+		}
+	}
+	return body.String(), headings, nil
+}
+
+func collectIDs(n *html.Node, usedIDs map[string]struct{}) {
+	if n.Type == html.ElementNode {
+		for _, attr := range n.Attr {
+			if attr.Key == "id" {
+				usedIDs[attr.Val] = struct{}{}
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectIDs(c, usedIDs)
+	}
+}
+
+func (b *Book) convertNode(n *html.Node, usedIDs map[string]struct{}, headings *[]*heading) {
+	if n.Type != html.ElementNode {
+		return
+	}
+	// attributes such as "@click" or "v-bind:x" are not valid XML names
+	attrs := make([]html.Attribute, 0, len(n.Attr))
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && !xmlNameRegexp.MatchString(attr.Key) {
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	n.Attr = attrs
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		id := getAttr(n, "id")
+		if id == "" {
+			for i := len(*headings) + 1; ; i++ {
+				id = "heading-" + strconv.Itoa(i)
+				if _, ok := usedIDs[id]; !ok {
+					break
+				}
+			}
+			usedIDs[id] = struct{}{}
+			setAttr(n, "id", id)
+		}
+		title := strings.TrimSpace(nodeText(n))
+		if title != "" {
+			*headings = append(*headings, &heading{Level: level, ID: id, Title: title})
+		}
+	case atom.Img:
+		src := getAttr(n, "src")
+		if src == "" {
+			return
+		}
+		if localPath, ok := b.addImage(src); ok {
+			setAttr(n, "src", localPath)
+			removeAttr(n, "srcset")
+			if getAttr(n, "alt") == "" {
+				setAttr(n, "alt", "")
+			}
+			return
+		}
+		// remote images are not allowed in EPUB, keep a link to the original image instead
+		text := getAttr(n, "alt")
+		if text == "" {
+			text = src
+		}
+		n.DataAtom = atom.A
+		n.Data = "a"
+		n.Attr = []html.Attribute{{Key: "href", Val: b.resolveURL(src)}}
+		n.AppendChild(&html.Node{Type: html.TextNode, Data: text})
+	case atom.A:
+		if href := getAttr(n, "href"); href != "" {
+			setAttr(n, "href", b.resolveURL(href))
+		}
+	case atom.Svg:
+		if getAttr(n, "xmlns") == "" {
+			setAttr(n, "xmlns", "http://www.w3.org/2000/svg")
+		}
+	}
+}
+
+func (b *Book) resolveURL(href string) string {
+	if b.BaseURL != "" && strings.HasPrefix(href, "/") && !strings.HasPrefix(href, "//") {
+		return strings.TrimSuffix(b.BaseURL, "/") + href
+	}
+	return href
+}
+
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	text := strings.Builder{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		text.WriteString(nodeText(c))
+	}
+	return text.String()
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func setAttr(n *html.Node, key, val string) {
+	for i, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+func removeAttr(n *html.Node, key string) {
+	attrs := n.Attr[:0]
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	n.Attr = attrs
+}
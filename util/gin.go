@@ -26,6 +26,14 @@ func GetUserAgent(ctx context.Context) string {
 	return ginCtx.GetHeader("User-Agent")
 }
 
+func GetReferer(ctx context.Context) string {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		return ""
+	}
+	return ginCtx.GetHeader("Referer")
+}
+
 func MustGetQueryString(ctx *gin.Context, key string) (string, error) {
 	str, ok := ctx.GetQuery(key)
 	if !ok || str == "" {
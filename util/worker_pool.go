@@ -0,0 +1,32 @@
+package util
+
+// WorkerPool runs the tasks in a fixed number of goroutines, so that a burst of tasks does not start a goroutine for each of them.
+type WorkerPool struct {
+	tasks chan func()
+}
+
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	p := &WorkerPool{
+		tasks: make(chan func(), queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// TrySubmit queues the task and returns false without waiting if the queue is full.
+func (p *WorkerPool) TrySubmit(task func()) bool {
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *WorkerPool) work() {
+	for task := range p.tasks {
+		task()
+	}
+}
@@ -12,6 +12,7 @@ type Status int
 
 const (
 	StatusBadRequest          = http.StatusBadRequest
+	StatusUnauthorized        = http.StatusUnauthorized
 	StatusInternalServerError = http.StatusInternalServerError
 	StatusForbidden           = http.StatusForbidden
 	StatusNotFound            = http.StatusNotFound